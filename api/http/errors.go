@@ -36,6 +36,8 @@ var (
 	ErrPeerIdUnavailable    = errors.New("no PeerID available. P2P might be disabled")
 	ErrStreamingUnsupported = errors.New("streaming unsupported")
 	ErrNoEmail              = errors.New("email address must be specified for tls with autocert")
+	ErrMissingCollection    = errors.New("missing collection name")
+	ErrMissingDocKeys       = errors.New("missing document keys")
 )
 
 // ErrorResponse is the GQL top level object holding error items for the response payload.
@@ -31,10 +31,15 @@ type handler struct {
 	options serverOptions
 }
 
+// IdentityHeader is the header carrying the identity of the user making a request. The
+// identity is recorded as the author of the commits created by the request.
+const IdentityHeader = "X-Defra-Identity"
+
 // context variables
 type (
-	ctxDB     struct{}
-	ctxPeerID struct{}
+	ctxDB              struct{}
+	ctxPeerID          struct{}
+	ctxSignaturePolicy struct{}
 )
 
 // DataResponse is the GQL top level object holding data for the response payload.
@@ -83,6 +88,10 @@ func (h *handler) handle(f http.HandlerFunc) http.HandlerFunc {
 		if h.options.peerID != "" {
 			ctx = context.WithValue(ctx, ctxPeerID{}, h.options.peerID)
 		}
+		ctx = context.WithValue(ctx, ctxSignaturePolicy{}, h.options.signaturePolicy)
+		if identity := req.Header.Get(IdentityHeader); identity != "" {
+			ctx = client.WithIdentity(ctx, identity)
+		}
 		f(rw, req.WithContext(ctx))
 	}
 }
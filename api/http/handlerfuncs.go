@@ -17,6 +17,7 @@ import (
 	"io"
 	"mime"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	dshelp "github.com/ipfs/boxo/datastore/dshelp"
@@ -25,15 +26,20 @@ import (
 	ds "github.com/ipfs/go-datastore"
 	"github.com/multiformats/go-multihash"
 	"github.com/pkg/errors"
+	"github.com/sourcenetwork/immutable"
 
+	"github.com/sourcenetwork/defradb/client"
 	corecrdt "github.com/sourcenetwork/defradb/core/crdt"
 	"github.com/sourcenetwork/defradb/events"
+	"github.com/sourcenetwork/defradb/logging"
+	"github.com/sourcenetwork/defradb/net"
 )
 
 const (
 	contentTypeJSON           = "application/json"
 	contentTypeGraphQL        = "application/graphql"
 	contentTypeFormURLEncoded = "application/x-www-form-urlencoded"
+	contentTypeCAR            = "application/vnd.ipld.car"
 )
 
 func rootHandler(rw http.ResponseWriter, req *http.Request) {
@@ -155,6 +161,61 @@ func execGQLHandler(rw http.ResponseWriter, req *http.Request) {
 	sendJSON(req.Context(), rw, newGQLResult(result.GQL), http.StatusOK)
 }
 
+type collectionResponse struct {
+	Name      string          `json:"name"`
+	ID        uint32          `json:"id"`
+	VersionID string          `json:"versionId"`
+	Fields    []fieldResponse `json:"fields"`
+}
+
+type fieldResponse struct {
+	ID   client.FieldID   `json:"id"`
+	Name string           `json:"name"`
+	Kind client.FieldKind `json:"kind"`
+	CRDT string           `json:"crdt"`
+}
+
+func listSchemaHandler(rw http.ResponseWriter, req *http.Request) {
+	db, err := dbFromContext(req.Context())
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusInternalServerError)
+		return
+	}
+
+	cols, err := db.GetAllCollections(req.Context())
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusInternalServerError)
+		return
+	}
+
+	colResponses := make([]collectionResponse, 0, len(cols))
+	for _, col := range cols {
+		desc := col.Description()
+		fields := make([]fieldResponse, 0, len(desc.Schema.Fields))
+		for _, field := range desc.Schema.Fields {
+			fields = append(fields, fieldResponse{
+				ID:   field.ID,
+				Name: field.Name,
+				Kind: field.Kind,
+				CRDT: field.Typ.FieldName(),
+			})
+		}
+		colResponses = append(colResponses, collectionResponse{
+			Name:      desc.Name,
+			ID:        desc.ID,
+			VersionID: desc.Schema.VersionID,
+			Fields:    fields,
+		})
+	}
+
+	sendJSON(
+		req.Context(),
+		rw,
+		simpleDataResponse("collections", colResponses),
+		http.StatusOK,
+	)
+}
+
 func loadSchemaHandler(rw http.ResponseWriter, req *http.Request) {
 	sdl, err := readWithLimit(req.Body, rw)
 	if err != nil {
@@ -209,6 +270,448 @@ func patchSchemaHandler(rw http.ResponseWriter, req *http.Request) {
 	)
 }
 
+type compactRequest struct {
+	Collection string   `json:"collection"`
+	DocKeys    []string `json:"dockeys"`
+	Height     uint64   `json:"height"`
+	Depth      uint64   `json:"depth"`
+}
+
+func compactHandler(rw http.ResponseWriter, req *http.Request) {
+	compactReq := compactRequest{}
+	err := getJSON(req, &compactReq)
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusBadRequest)
+		return
+	}
+	if compactReq.Collection == "" {
+		handleErr(req.Context(), rw, ErrMissingCollection, http.StatusBadRequest)
+		return
+	}
+
+	opts := client.CompactOptions{
+		Height: compactReq.Height,
+		Depth:  compactReq.Depth,
+	}
+	for _, dockey := range compactReq.DocKeys {
+		key, err := client.NewDocKeyFromString(dockey)
+		if err != nil {
+			handleErr(req.Context(), rw, err, http.StatusBadRequest)
+			return
+		}
+		opts.DocKeys = append(opts.DocKeys, key)
+	}
+
+	db, err := dbFromContext(req.Context())
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusInternalServerError)
+		return
+	}
+
+	col, err := db.GetCollectionByName(req.Context(), compactReq.Collection)
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusBadRequest)
+		return
+	}
+
+	res, err := col.Compact(req.Context(), opts)
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusInternalServerError)
+		return
+	}
+
+	sendJSON(
+		req.Context(),
+		rw,
+		simpleDataResponse(
+			"count", res.Count,
+			"dockeys", res.DocKeys,
+			"removedBlocks", res.RemovedBlocks,
+		),
+		http.StatusOK,
+	)
+}
+
+type fsckRequest struct {
+	Collection string   `json:"collection"`
+	DocKeys    []string `json:"dockeys"`
+	Repair     bool     `json:"repair"`
+}
+
+func fsckHandler(rw http.ResponseWriter, req *http.Request) {
+	fsckReq := fsckRequest{}
+	err := getJSON(req, &fsckReq)
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusBadRequest)
+		return
+	}
+	if fsckReq.Collection == "" && len(fsckReq.DocKeys) > 0 {
+		handleErr(req.Context(), rw, ErrMissingCollection, http.StatusBadRequest)
+		return
+	}
+
+	opts := client.FsckOptions{
+		Repair: fsckReq.Repair,
+	}
+	for _, dockey := range fsckReq.DocKeys {
+		key, err := client.NewDocKeyFromString(dockey)
+		if err != nil {
+			handleErr(req.Context(), rw, err, http.StatusBadRequest)
+			return
+		}
+		opts.DocKeys = append(opts.DocKeys, key)
+	}
+
+	db, err := dbFromContext(req.Context())
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusInternalServerError)
+		return
+	}
+
+	// all the collections are checked if none is given
+	var cols []client.Collection
+	if fsckReq.Collection == "" {
+		cols, err = db.GetAllCollections(req.Context())
+		if err != nil {
+			handleErr(req.Context(), rw, err, http.StatusInternalServerError)
+			return
+		}
+	} else {
+		col, err := db.GetCollectionByName(req.Context(), fsckReq.Collection)
+		if err != nil {
+			handleErr(req.Context(), rw, err, http.StatusBadRequest)
+			return
+		}
+		cols = append(cols, col)
+	}
+
+	count := int64(0)
+	inconsistencies := []client.Inconsistency{}
+	for _, col := range cols {
+		res, err := col.Fsck(req.Context(), opts)
+		if err != nil {
+			handleErr(req.Context(), rw, err, http.StatusInternalServerError)
+			return
+		}
+		count += res.Count
+		inconsistencies = append(inconsistencies, res.Inconsistencies...)
+	}
+
+	sendJSON(
+		req.Context(),
+		rw,
+		simpleDataResponse(
+			"count", count,
+			"inconsistencies", inconsistencies,
+		),
+		http.StatusOK,
+	)
+}
+
+// reindexProgressInterval is the number of reindexed documents between two progress logs.
+const reindexProgressInterval = 100
+
+type reindexRequest struct {
+	Collection string   `json:"collection"`
+	DocKeys    []string `json:"dockeys"`
+}
+
+func reindexHandler(rw http.ResponseWriter, req *http.Request) {
+	reindexReq := reindexRequest{}
+	err := getJSON(req, &reindexReq)
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusBadRequest)
+		return
+	}
+	if reindexReq.Collection == "" && len(reindexReq.DocKeys) > 0 {
+		handleErr(req.Context(), rw, ErrMissingCollection, http.StatusBadRequest)
+		return
+	}
+
+	opts := client.ReindexOptions{}
+	for _, dockey := range reindexReq.DocKeys {
+		key, err := client.NewDocKeyFromString(dockey)
+		if err != nil {
+			handleErr(req.Context(), rw, err, http.StatusBadRequest)
+			return
+		}
+		opts.DocKeys = append(opts.DocKeys, key)
+	}
+
+	db, err := dbFromContext(req.Context())
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusInternalServerError)
+		return
+	}
+
+	// all the collections are reindexed if none is given
+	var cols []client.Collection
+	if reindexReq.Collection == "" {
+		cols, err = db.GetAllCollections(req.Context())
+		if err != nil {
+			handleErr(req.Context(), rw, err, http.StatusInternalServerError)
+			return
+		}
+	} else {
+		col, err := db.GetCollectionByName(req.Context(), reindexReq.Collection)
+		if err != nil {
+			handleErr(req.Context(), rw, err, http.StatusBadRequest)
+			return
+		}
+		cols = append(cols, col)
+	}
+
+	count := int64(0)
+	replayedBlocks := int64(0)
+	for _, col := range cols {
+		name := col.Name()
+		opts.OnProgress = func(progress client.ReindexProgress) {
+			if progress.Done%reindexProgressInterval != 0 && progress.Done != progress.Total {
+				return
+			}
+			log.Info(
+				req.Context(),
+				"Reindexing documents",
+				logging.NewKV("Collection", name),
+				logging.NewKV("Done", progress.Done),
+				logging.NewKV("Total", progress.Total),
+			)
+		}
+
+		res, err := col.Reindex(req.Context(), opts)
+		if err != nil {
+			handleErr(req.Context(), rw, err, http.StatusInternalServerError)
+			return
+		}
+		count += res.Count
+		replayedBlocks += res.ReplayedBlocks
+	}
+
+	sendJSON(
+		req.Context(),
+		rw,
+		simpleDataResponse(
+			"count", count,
+			"replayedBlocks", replayedBlocks,
+		),
+		http.StatusOK,
+	)
+}
+
+type purgeRequest struct {
+	Collection string   `json:"collection"`
+	DocKeys    []string `json:"dockeys"`
+}
+
+func purgeHandler(rw http.ResponseWriter, req *http.Request) {
+	purgeReq := purgeRequest{}
+	err := getJSON(req, &purgeReq)
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusBadRequest)
+		return
+	}
+	if purgeReq.Collection == "" {
+		handleErr(req.Context(), rw, ErrMissingCollection, http.StatusBadRequest)
+		return
+	}
+	if len(purgeReq.DocKeys) == 0 {
+		handleErr(req.Context(), rw, ErrMissingDocKeys, http.StatusBadRequest)
+		return
+	}
+
+	keys := make([]client.DocKey, 0, len(purgeReq.DocKeys))
+	for _, dockey := range purgeReq.DocKeys {
+		key, err := client.NewDocKeyFromString(dockey)
+		if err != nil {
+			handleErr(req.Context(), rw, err, http.StatusBadRequest)
+			return
+		}
+		keys = append(keys, key)
+	}
+
+	db, err := dbFromContext(req.Context())
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusInternalServerError)
+		return
+	}
+
+	col, err := db.GetCollectionByName(req.Context(), purgeReq.Collection)
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusBadRequest)
+		return
+	}
+
+	txn, err := db.NewTxn(req.Context(), false)
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusInternalServerError)
+		return
+	}
+	defer txn.Discard(req.Context())
+
+	// all the documents are purged, or none
+	for _, key := range keys {
+		_, err := col.WithTxn(txn).Purge(req.Context(), key)
+		if errors.Is(err, client.ErrDocumentNotFound) {
+			handleErr(req.Context(), rw, err, http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			handleErr(req.Context(), rw, err, http.StatusInternalServerError)
+			return
+		}
+	}
+	if err := txn.Commit(req.Context()); err != nil {
+		handleErr(req.Context(), rw, err, http.StatusInternalServerError)
+		return
+	}
+
+	sendJSON(
+		req.Context(),
+		rw,
+		simpleDataResponse(
+			"count", len(keys),
+			"dockeys", purgeReq.DocKeys,
+		),
+		http.StatusOK,
+	)
+}
+
+type gcRequest struct {
+	DryRun      bool   `json:"dryRun"`
+	GracePeriod string `json:"gracePeriod"`
+}
+
+func gcHandler(rw http.ResponseWriter, req *http.Request) {
+	gcReq := gcRequest{}
+	err := getJSON(req, &gcReq)
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusBadRequest)
+		return
+	}
+
+	db, err := dbFromContext(req.Context())
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusInternalServerError)
+		return
+	}
+
+	opts := client.GCOptions{DryRun: gcReq.DryRun}
+	if gcReq.GracePeriod != "" {
+		gracePeriod, err := time.ParseDuration(gcReq.GracePeriod)
+		if err != nil {
+			handleErr(req.Context(), rw, err, http.StatusBadRequest)
+			return
+		}
+		opts.GracePeriod = immutable.Some(gracePeriod)
+	}
+
+	res, err := db.CollectGarbage(req.Context(), opts)
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusInternalServerError)
+		return
+	}
+
+	sendJSON(
+		req.Context(),
+		rw,
+		simpleDataResponse(
+			"reachable", res.Reachable,
+			"unreachable", res.Unreachable,
+			"removedBlocks", res.RemovedBlocks,
+		),
+		http.StatusOK,
+	)
+}
+
+type exportBlocksRequest struct {
+	Collection string   `json:"collection"`
+	DocKeys    []string `json:"dockeys"`
+}
+
+func exportBlocksHandler(rw http.ResponseWriter, req *http.Request) {
+	exportReq := exportBlocksRequest{}
+	err := getJSON(req, &exportReq)
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusBadRequest)
+		return
+	}
+	if exportReq.Collection == "" {
+		handleErr(req.Context(), rw, ErrMissingCollection, http.StatusBadRequest)
+		return
+	}
+
+	keys := make([]client.DocKey, 0, len(exportReq.DocKeys))
+	for _, dockey := range exportReq.DocKeys {
+		key, err := client.NewDocKeyFromString(dockey)
+		if err != nil {
+			handleErr(req.Context(), rw, err, http.StatusBadRequest)
+			return
+		}
+		keys = append(keys, key)
+	}
+
+	db, err := dbFromContext(req.Context())
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusInternalServerError)
+		return
+	}
+
+	col, err := db.GetCollectionByName(req.Context(), exportReq.Collection)
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusBadRequest)
+		return
+	}
+
+	// the file is buffered so that an error can still be reported with its status
+	var buf bytes.Buffer
+	_, err = col.ExportDAG(req.Context(), &buf, client.ExportDAGOptions{DocKeys: keys})
+	if errors.Is(err, client.ErrDocumentNotFound) {
+		handleErr(req.Context(), rw, err, http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusInternalServerError)
+		return
+	}
+
+	rw.Header().Set("Content-Type", contentTypeCAR)
+	rw.WriteHeader(http.StatusOK)
+	if _, err := buf.WriteTo(rw); err != nil {
+		log.ErrorE(req.Context(), "Failed to write CAR file", err)
+	}
+}
+
+func importBlocksHandler(rw http.ResponseWriter, req *http.Request) {
+	if req.Body == nil {
+		handleErr(req.Context(), rw, ErrBodyEmpty, http.StatusBadRequest)
+		return
+	}
+
+	db, err := dbFromContext(req.Context())
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusInternalServerError)
+		return
+	}
+
+	policy, _ := req.Context().Value(ctxSignaturePolicy{}).(net.SignaturePolicy)
+	res, err := net.ImportDAG(req.Context(), db, req.Body, policy)
+	if err != nil {
+		handleErr(req.Context(), rw, err, http.StatusBadRequest)
+		return
+	}
+
+	sendJSON(
+		req.Context(),
+		rw,
+		simpleDataResponse(
+			"count", res.Count,
+			"dockeys", res.DocKeys,
+			"mergedBlocks", res.MergedBlocks,
+		),
+		http.StatusOK,
+	)
+}
+
 func getBlockHandler(rw http.ResponseWriter, req *http.Request) {
 	cidStr := chi.URLParam(req, "cid")
 
@@ -293,7 +796,7 @@ func peerIDHandler(rw http.ResponseWriter, req *http.Request) {
 	)
 }
 
-func subscriptionHandler(pub *events.Publisher[events.Update], rw http.ResponseWriter, req *http.Request) {
+func subscriptionHandler(pub events.Streamer, rw http.ResponseWriter, req *http.Request) {
 	flusher, ok := rw.(http.Flusher)
 	if !ok {
 		handleErr(req.Context(), rw, ErrStreamingUnsupported, http.StatusInternalServerError)
@@ -25,6 +25,8 @@ import (
 	badger "github.com/dgraph-io/badger/v3"
 	dshelp "github.com/ipfs/boxo/datastore/dshelp"
 	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
@@ -32,6 +34,7 @@ import (
 	badgerds "github.com/sourcenetwork/defradb/datastore/badger/v3"
 	"github.com/sourcenetwork/defradb/db"
 	"github.com/sourcenetwork/defradb/errors"
+	"github.com/sourcenetwork/defradb/net"
 )
 
 type testOptions struct {
@@ -347,6 +350,43 @@ func TestExecGQLHandlerContentTypeJSON(t *testing.T) {
 	assert.Contains(t, users[0].Key, "bae-")
 }
 
+func TestExecGQLHandlerWithIdentityHeader(t *testing.T) {
+	ctx := context.Background()
+	defra := testNewInMemoryDB(t, ctx)
+	defer defra.Close(ctx)
+
+	// load schema
+	testLoadSchema(t, ctx, defra)
+
+	// add document
+	stmt := `mutation {
+		create_user(data: "{\"name\": \"Bob\"}") {_key}
+	}`
+
+	buf := bytes.NewBuffer([]byte(stmt))
+	users := []testUser{}
+	resp := DataResponse{
+		Data: &users,
+	}
+	testRequest(testOptions{
+		Testing: t,
+		DB:      defra,
+		Method:  "POST",
+		Path:    GraphQLPath,
+		Body:    buf,
+		Headers: map[string]string{
+			"Content-Type": contentTypeGraphQL,
+			IdentityHeader: "alice",
+		},
+		ExpectedStatus: 200,
+		ResponseData:   &resp,
+	})
+
+	result := defra.ExecRequest(ctx, `query { commits(fieldId: "C") { author } }`)
+	assert.Empty(t, result.GQL.Errors)
+	assert.Equal(t, []map[string]any{{"author": "alice"}}, result.GQL.Data)
+}
+
 func TestExecGQLHandlerContentTypeJSONWithError(t *testing.T) {
 	ctx := context.Background()
 	defra := testNewInMemoryDB(t, ctx)
@@ -720,6 +760,55 @@ type user {
 	}
 }
 
+func TestListSchemaHandlerWithCRDTTypes(t *testing.T) {
+	ctx := context.Background()
+	defra := testNewInMemoryDB(t, ctx)
+	defer defra.Close(ctx)
+
+	err := defra.AddSchema(ctx, `
+type book {
+	title: String @crdt(type: lww)
+	author: author
+}
+
+type author {
+	name: String
+	published: [book]
+}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := DataResponse{}
+	testRequest(testOptions{
+		Testing:        t,
+		DB:             defra,
+		Method:         "GET",
+		Path:           SchemaPath,
+		Body:           nil,
+		ExpectedStatus: 200,
+		ResponseData:   &resp,
+	})
+
+	data, ok := resp.Data.(map[string]any)
+	if !ok {
+		t.Fatalf("data should be of type map[string]any but got %T", resp.Data)
+	}
+	crdtTypes := map[string]any{}
+	for _, col := range data["collections"].([]any) {
+		col := col.(map[string]any)
+		for _, field := range col["fields"].([]any) {
+			field := field.(map[string]any)
+			crdtTypes[col["name"].(string)+"."+field["name"].(string)] = field["crdt"]
+		}
+	}
+	assert.Equal(t, "lww", crdtTypes["book.title"])
+	assert.Equal(t, "lww", crdtTypes["author.name"])
+	// relation fields hold no value of their own
+	assert.Equal(t, "", crdtTypes["book.author"])
+	assert.Equal(t, "", crdtTypes["author.published"])
+}
+
 func TestGetBlockHandlerWithMultihashError(t *testing.T) {
 	t.Cleanup(CleanupEnv)
 	env = "dev"
@@ -899,6 +988,571 @@ query {
 	}
 }
 
+func TestCompactHandlerWithMissingCollection(t *testing.T) {
+	t.Cleanup(CleanupEnv)
+	env = "dev"
+
+	errResponse := ErrorResponse{}
+	testRequest(testOptions{
+		Testing:        t,
+		DB:             nil,
+		Method:         "POST",
+		Path:           CompactPath,
+		Body:           bytes.NewBuffer([]byte(`{"depth": 1}`)),
+		ExpectedStatus: 400,
+		ResponseData:   &errResponse,
+	})
+
+	assert.Contains(t, errResponse.Errors[0].Extensions.Stack, "missing collection name")
+	assert.Equal(t, http.StatusBadRequest, errResponse.Errors[0].Extensions.Status)
+	assert.Equal(t, "Bad Request", errResponse.Errors[0].Extensions.HTTPError)
+	assert.Equal(t, "missing collection name", errResponse.Errors[0].Message)
+}
+
+func TestCompactHandlerWithValidCollection(t *testing.T) {
+	ctx := context.Background()
+	defra := testNewInMemoryDB(t, ctx)
+	defer defra.Close(ctx)
+
+	testLoadSchema(t, ctx, defra)
+
+	col, err := defra.GetCollectionByName(ctx, "user")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := client.NewDocFromJSON([]byte(`{"name": "Bob", "age": 31}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = col.Create(ctx, doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, age := range []int{32, 33} {
+		err = doc.Set("age", age)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = col.Update(ctx, doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resp := DataResponse{}
+	testRequest(testOptions{
+		Testing:        t,
+		DB:             defra,
+		Method:         "POST",
+		Path:           CompactPath,
+		Body:           bytes.NewBuffer([]byte(`{"collection": "user", "depth": 1}`)),
+		ExpectedStatus: 200,
+		ResponseData:   &resp,
+	})
+
+	switch v := resp.Data.(type) {
+	case map[string]any:
+		assert.Equal(t, float64(1), v["count"])
+		assert.Equal(t, []any{doc.Key().String()}, v["dockeys"])
+		assert.Equal(t, float64(3), v["removedBlocks"])
+	default:
+		t.Fatalf("data should be of type map[string]any but got %T", resp.Data)
+	}
+}
+
+func TestFsckHandlerWithDocKeysAndMissingCollection(t *testing.T) {
+	t.Cleanup(CleanupEnv)
+	env = "dev"
+
+	errResponse := ErrorResponse{}
+	testRequest(testOptions{
+		Testing:        t,
+		DB:             nil,
+		Method:         "POST",
+		Path:           FsckPath,
+		Body:           bytes.NewBuffer([]byte(`{"dockeys": ["bae-52b9170d-b77a-5887-b877-cbdbb99b009f"]}`)),
+		ExpectedStatus: 400,
+		ResponseData:   &errResponse,
+	})
+
+	assert.Equal(t, http.StatusBadRequest, errResponse.Errors[0].Extensions.Status)
+	assert.Equal(t, "missing collection name", errResponse.Errors[0].Message)
+}
+
+func TestFsckHandlerWithAllCollections(t *testing.T) {
+	ctx := context.Background()
+	defra := testNewInMemoryDB(t, ctx)
+	defer defra.Close(ctx)
+
+	testLoadSchema(t, ctx, defra)
+
+	col, err := defra.GetCollectionByName(ctx, "user")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := client.NewDocFromJSON([]byte(`{"name": "Bob", "age": 31}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = col.Create(ctx, doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := DataResponse{}
+	testRequest(testOptions{
+		Testing:        t,
+		DB:             defra,
+		Method:         "POST",
+		Path:           FsckPath,
+		Body:           bytes.NewBuffer([]byte(`{"repair": true}`)),
+		ExpectedStatus: 200,
+		ResponseData:   &resp,
+	})
+
+	switch v := resp.Data.(type) {
+	case map[string]any:
+		assert.Equal(t, float64(1), v["count"])
+		assert.Equal(t, []any{}, v["inconsistencies"])
+	default:
+		t.Fatalf("data should be of type map[string]any but got %T", resp.Data)
+	}
+}
+
+func TestReindexHandlerWithValidCollection(t *testing.T) {
+	ctx := context.Background()
+	defra := testNewInMemoryDB(t, ctx)
+	defer defra.Close(ctx)
+
+	testLoadSchema(t, ctx, defra)
+
+	col, err := defra.GetCollectionByName(ctx, "user")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := client.NewDocFromJSON([]byte(`{"name": "Bob", "age": 31}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = col.Create(ctx, doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := DataResponse{}
+	testRequest(testOptions{
+		Testing:        t,
+		DB:             defra,
+		Method:         "POST",
+		Path:           ReindexPath,
+		Body:           bytes.NewBuffer([]byte(`{"collection": "user"}`)),
+		ExpectedStatus: 200,
+		ResponseData:   &resp,
+	})
+
+	switch v := resp.Data.(type) {
+	case map[string]any:
+		assert.Equal(t, float64(1), v["count"])
+		assert.Equal(t, float64(3), v["replayedBlocks"])
+	default:
+		t.Fatalf("data should be of type map[string]any but got %T", resp.Data)
+	}
+
+	_, err = col.Get(ctx, doc.Key(), false)
+	assert.NoError(t, err)
+}
+
+func TestPurgeHandlerWithMissingDocKeys(t *testing.T) {
+	t.Cleanup(CleanupEnv)
+	env = "dev"
+
+	errResponse := ErrorResponse{}
+	testRequest(testOptions{
+		Testing:        t,
+		DB:             nil,
+		Method:         "POST",
+		Path:           PurgePath,
+		Body:           bytes.NewBuffer([]byte(`{"collection": "user"}`)),
+		ExpectedStatus: 400,
+		ResponseData:   &errResponse,
+	})
+
+	assert.Equal(t, http.StatusBadRequest, errResponse.Errors[0].Extensions.Status)
+	assert.Equal(t, "missing document keys", errResponse.Errors[0].Message)
+}
+
+func TestPurgeHandlerWithValidDocKey(t *testing.T) {
+	ctx := context.Background()
+	defra := testNewInMemoryDB(t, ctx)
+	defer defra.Close(ctx)
+
+	testLoadSchema(t, ctx, defra)
+
+	col, err := defra.GetCollectionByName(ctx, "user")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := client.NewDocFromJSON([]byte(`{"name": "Bob", "age": 31}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = col.Create(ctx, doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := DataResponse{}
+	testRequest(testOptions{
+		Testing:        t,
+		DB:             defra,
+		Method:         "POST",
+		Path:           PurgePath,
+		Body:           bytes.NewBuffer([]byte(`{"collection": "user", "dockeys": ["` + doc.Key().String() + `"]}`)),
+		ExpectedStatus: 200,
+		ResponseData:   &resp,
+	})
+
+	switch v := resp.Data.(type) {
+	case map[string]any:
+		assert.Equal(t, float64(1), v["count"])
+		assert.Equal(t, []any{doc.Key().String()}, v["dockeys"])
+	default:
+		t.Fatalf("data should be of type map[string]any but got %T", resp.Data)
+	}
+
+	_, err = col.Get(ctx, doc.Key(), false)
+	assert.ErrorIs(t, err, client.ErrDocumentNotFound)
+}
+
+func TestGCHandlerWithDryRun(t *testing.T) {
+	ctx := context.Background()
+	defra := testNewInMemoryDB(t, ctx)
+	defer defra.Close(ctx)
+
+	testLoadSchema(t, ctx, defra)
+
+	col, err := defra.GetCollectionByName(ctx, "user")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := client.NewDocFromJSON([]byte(`{"name": "Bob", "age": 31}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = col.Create(ctx, doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := DataResponse{}
+	testRequest(testOptions{
+		Testing:        t,
+		DB:             defra,
+		Method:         "POST",
+		Path:           GCPath,
+		Body:           bytes.NewBuffer([]byte(`{"dryRun": true}`)),
+		ExpectedStatus: 200,
+		ResponseData:   &resp,
+	})
+
+	switch v := resp.Data.(type) {
+	case map[string]any:
+		// one composite block, and one block for each field
+		assert.Equal(t, float64(3), v["reachable"])
+		assert.Equal(t, []any{}, v["unreachable"])
+		assert.Equal(t, float64(0), v["removedBlocks"])
+	default:
+		t.Fatalf("data should be of type map[string]any but got %T", resp.Data)
+	}
+}
+
+func TestExportBlocksHandlerWithMissingCollection(t *testing.T) {
+	t.Cleanup(CleanupEnv)
+	env = "dev"
+
+	errResponse := ErrorResponse{}
+	testRequest(testOptions{
+		Testing:        t,
+		DB:             nil,
+		Method:         "POST",
+		Path:           BlocksExportPath,
+		Body:           bytes.NewBuffer([]byte(`{}`)),
+		ExpectedStatus: 400,
+		ResponseData:   &errResponse,
+	})
+
+	assert.Equal(t, http.StatusBadRequest, errResponse.Errors[0].Extensions.Status)
+	assert.Equal(t, "missing collection name", errResponse.Errors[0].Message)
+}
+
+func TestImportBlocksHandlerWithExportedDocument(t *testing.T) {
+	ctx := context.Background()
+	source := testNewInMemoryDB(t, ctx)
+	defer source.Close(ctx)
+	testLoadSchema(t, ctx, source)
+
+	target := testNewInMemoryDB(t, ctx)
+	defer target.Close(ctx)
+	testLoadSchema(t, ctx, target)
+
+	col, err := source.GetCollectionByName(ctx, "user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc, err := client.NewDocFromJSON([]byte(`{"name": "Bob", "age": 31}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = col.Create(ctx, doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = doc.Set("age", 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = col.Update(ctx, doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	_, err = col.ExportDAG(ctx, &buf, client.ExportDAGOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := DataResponse{}
+	testRequest(testOptions{
+		Testing:        t,
+		DB:             target,
+		Method:         "POST",
+		Path:           BlocksImportPath,
+		Body:           bytes.NewBuffer(buf.Bytes()),
+		ExpectedStatus: 200,
+		ResponseData:   &resp,
+	})
+
+	switch v := resp.Data.(type) {
+	case map[string]any:
+		assert.Equal(t, float64(1), v["count"])
+		assert.Equal(t, []any{doc.Key().String()}, v["dockeys"])
+		// two composite blocks, two blocks for age and one for name
+		assert.Equal(t, float64(5), v["mergedBlocks"])
+	default:
+		t.Fatalf("data should be of type map[string]any but got %T", resp.Data)
+	}
+
+	targetCol, err := target.GetCollectionByName(ctx, "user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	imported, err := targetCol.Get(ctx, doc.Key(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	age, err := imported.Get("age")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint64(32), age)
+
+	// the heads are already known, importing the file again merges nothing
+	resp = DataResponse{}
+	testRequest(testOptions{
+		Testing:        t,
+		DB:             target,
+		Method:         "POST",
+		Path:           BlocksImportPath,
+		Body:           bytes.NewBuffer(buf.Bytes()),
+		ExpectedStatus: 200,
+		ResponseData:   &resp,
+	})
+
+	switch v := resp.Data.(type) {
+	case map[string]any:
+		assert.Equal(t, float64(0), v["count"])
+		assert.Equal(t, float64(0), v["mergedBlocks"])
+	default:
+		t.Fatalf("data should be of type map[string]any but got %T", resp.Data)
+	}
+}
+
+func testExportUserDocument(t *testing.T, ctx context.Context, defra client.DB) *bytes.Buffer {
+	col, err := defra.GetCollectionByName(ctx, "user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc, err := client.NewDocFromJSON([]byte(`{"name": "Bob", "age": 31}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = col.Create(ctx, doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	_, err = col.ExportDAG(ctx, &buf, client.ExportDAGOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &buf
+}
+
+func TestImportBlocksHandlerWithUnsignedBlocksAndRequireSignedBlocks(t *testing.T) {
+	ctx := context.Background()
+	source := testNewInMemoryDB(t, ctx)
+	defer source.Close(ctx)
+	testLoadSchema(t, ctx, source)
+
+	target := testNewInMemoryDB(t, ctx)
+	defer target.Close(ctx)
+	testLoadSchema(t, ctx, target)
+
+	errResponse := ErrorResponse{}
+	testRequest(testOptions{
+		Testing:        t,
+		DB:             target,
+		Method:         "POST",
+		Path:           BlocksImportPath,
+		Body:           testExportUserDocument(t, ctx, source),
+		ExpectedStatus: 400,
+		ResponseData:   &errResponse,
+		ServerOptions: serverOptions{
+			signaturePolicy: net.SignaturePolicy{RequireSigned: true},
+		},
+	})
+
+	assert.Equal(t, http.StatusBadRequest, errResponse.Errors[0].Extensions.Status)
+	assert.Contains(t, errResponse.Errors[0].Message, "block is not signed")
+}
+
+func TestImportBlocksHandlerWithSignedBlocksAndRequireSignedBlocks(t *testing.T) {
+	ctx := context.Background()
+	key, _, err := crypto.GenerateKeyPair(crypto.Ed25519, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	source := testNewInMemoryDB(t, ctx, db.WithSigningKey(key))
+	defer source.Close(ctx)
+	testLoadSchema(t, ctx, source)
+
+	target := testNewInMemoryDB(t, ctx)
+	defer target.Close(ctx)
+	testLoadSchema(t, ctx, target)
+
+	resp := DataResponse{}
+	testRequest(testOptions{
+		Testing:        t,
+		DB:             target,
+		Method:         "POST",
+		Path:           BlocksImportPath,
+		Body:           testExportUserDocument(t, ctx, source),
+		ExpectedStatus: 200,
+		ResponseData:   &resp,
+		ServerOptions: serverOptions{
+			signaturePolicy: net.SignaturePolicy{RequireSigned: true},
+		},
+	})
+
+	switch v := resp.Data.(type) {
+	case map[string]any:
+		assert.Equal(t, float64(1), v["count"])
+		// one composite block, and one block for each field
+		assert.Equal(t, float64(3), v["mergedBlocks"])
+	default:
+		t.Fatalf("data should be of type map[string]any but got %T", resp.Data)
+	}
+}
+
+func TestImportBlocksHandlerWithBlocksSignedByUntrustedPeer(t *testing.T) {
+	ctx := context.Background()
+	key, _, err := crypto.GenerateKeyPair(crypto.Ed25519, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	source := testNewInMemoryDB(t, ctx, db.WithSigningKey(key))
+	defer source.Close(ctx)
+	testLoadSchema(t, ctx, source)
+
+	target := testNewInMemoryDB(t, ctx)
+	defer target.Close(ctx)
+	testLoadSchema(t, ctx, target)
+
+	trustedKey, _, err := crypto.GenerateKeyPair(crypto.Ed25519, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	trusted, err := peer.IDFromPrivateKey(trustedKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errResponse := ErrorResponse{}
+	testRequest(testOptions{
+		Testing:        t,
+		DB:             target,
+		Method:         "POST",
+		Path:           BlocksImportPath,
+		Body:           testExportUserDocument(t, ctx, source),
+		ExpectedStatus: 400,
+		ResponseData:   &errResponse,
+		ServerOptions: serverOptions{
+			signaturePolicy: net.SignaturePolicy{TrustedSigners: []peer.ID{trusted}},
+		},
+	})
+
+	assert.Equal(t, http.StatusBadRequest, errResponse.Errors[0].Extensions.Status)
+	assert.Contains(t, errResponse.Errors[0].Message, "block is not signed by a trusted peer")
+}
+
+func TestImportBlocksHandlerWithBlocksSignedByTrustedPeer(t *testing.T) {
+	ctx := context.Background()
+	key, _, err := crypto.GenerateKeyPair(crypto.Ed25519, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	source := testNewInMemoryDB(t, ctx, db.WithSigningKey(key))
+	defer source.Close(ctx)
+	testLoadSchema(t, ctx, source)
+
+	target := testNewInMemoryDB(t, ctx)
+	defer target.Close(ctx)
+	testLoadSchema(t, ctx, target)
+
+	trusted, err := peer.IDFromPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := DataResponse{}
+	testRequest(testOptions{
+		Testing:        t,
+		DB:             target,
+		Method:         "POST",
+		Path:           BlocksImportPath,
+		Body:           testExportUserDocument(t, ctx, source),
+		ExpectedStatus: 200,
+		ResponseData:   &resp,
+		ServerOptions: serverOptions{
+			signaturePolicy: net.SignaturePolicy{TrustedSigners: []peer.ID{trusted}},
+		},
+	})
+
+	switch v := resp.Data.(type) {
+	case map[string]any:
+		assert.Equal(t, float64(1), v["count"])
+	default:
+		t.Fatalf("data should be of type map[string]any but got %T", resp.Data)
+	}
+}
+
 func TestPeerIDHandler(t *testing.T) {
 	resp := DataResponse{}
 	testRequest(testOptions{
@@ -996,7 +1650,7 @@ func testSubscriptionRequest(ctx context.Context, opt testOptions, ch chan []byt
 	ch <- respBody
 }
 
-func testNewInMemoryDB(t *testing.T, ctx context.Context) client.DB {
+func testNewInMemoryDB(t *testing.T, ctx context.Context, dbOptions ...db.Option) client.DB {
 	// init in memory DB
 	opts := badgerds.Options{Options: badger.DefaultOptions("").WithInMemory(true)}
 	rootstore, err := badgerds.NewDatastore("", &opts)
@@ -1007,6 +1661,7 @@ func testNewInMemoryDB(t *testing.T, ctx context.Context) client.DB {
 	options := []db.Option{
 		db.WithUpdateEvents(),
 	}
+	options = append(options, dbOptions...)
 
 	defra, err := db.NewDB(ctx, rootstore, options...)
 	if err != nil {
@@ -16,6 +16,8 @@ type GQLResult struct {
 	Errors []string `json:"errors,omitempty"`
 
 	Data any `json:"data"`
+
+	Extensions map[string]any `json:"extensions,omitempty"`
 }
 
 func newGQLResult(r client.GQLResult) *GQLResult {
@@ -25,7 +27,8 @@ func newGQLResult(r client.GQLResult) *GQLResult {
 	}
 
 	return &GQLResult{
-		Errors: errors,
-		Data:   r.Data,
+		Errors:     errors,
+		Data:       r.Data,
+		Extensions: r.Extensions,
 	}
 }
@@ -25,14 +25,22 @@ const (
 	Version          string = "v0"
 	versionedAPIPath string = "/api/" + Version
 
-	RootPath        string = versionedAPIPath + ""
-	PingPath        string = versionedAPIPath + "/ping"
-	DumpPath        string = versionedAPIPath + "/debug/dump"
-	BlocksPath      string = versionedAPIPath + "/blocks"
-	GraphQLPath     string = versionedAPIPath + "/graphql"
-	SchemaLoadPath  string = versionedAPIPath + "/schema/load"
-	SchemaPatchPath string = versionedAPIPath + "/schema/patch"
-	PeerIDPath      string = versionedAPIPath + "/peerid"
+	RootPath         string = versionedAPIPath + ""
+	PingPath         string = versionedAPIPath + "/ping"
+	DumpPath         string = versionedAPIPath + "/debug/dump"
+	BlocksPath       string = versionedAPIPath + "/blocks"
+	GraphQLPath      string = versionedAPIPath + "/graphql"
+	SchemaPath       string = versionedAPIPath + "/schema"
+	SchemaLoadPath   string = versionedAPIPath + "/schema/load"
+	SchemaPatchPath  string = versionedAPIPath + "/schema/patch"
+	PeerIDPath       string = versionedAPIPath + "/peerid"
+	CompactPath      string = versionedAPIPath + "/compact"
+	FsckPath         string = versionedAPIPath + "/fsck"
+	ReindexPath      string = versionedAPIPath + "/reindex"
+	PurgePath        string = versionedAPIPath + "/purge"
+	GCPath           string = versionedAPIPath + "/gc"
+	BlocksExportPath string = BlocksPath + "/export"
+	BlocksImportPath string = BlocksPath + "/import"
 )
 
 func setRoutes(h *handler) *handler {
@@ -58,9 +66,17 @@ func setRoutes(h *handler) *handler {
 	h.Get(BlocksPath+"/{cid}", h.handle(getBlockHandler))
 	h.Get(GraphQLPath, h.handle(execGQLHandler))
 	h.Post(GraphQLPath, h.handle(execGQLHandler))
+	h.Get(SchemaPath, h.handle(listSchemaHandler))
 	h.Post(SchemaLoadPath, h.handle(loadSchemaHandler))
 	h.Post(SchemaPatchPath, h.handle(patchSchemaHandler))
 	h.Get(PeerIDPath, h.handle(peerIDHandler))
+	h.Post(CompactPath, h.handle(compactHandler))
+	h.Post(FsckPath, h.handle(fsckHandler))
+	h.Post(ReindexPath, h.handle(reindexHandler))
+	h.Post(PurgePath, h.handle(purgeHandler))
+	h.Post(GCPath, h.handle(gcHandler))
+	h.Post(BlocksExportPath, h.handle(exportBlocksHandler))
+	h.Post(BlocksImportPath, h.handle(importBlocksHandler))
 
 	return h
 }
@@ -26,6 +26,7 @@ import (
 	"github.com/sourcenetwork/defradb/config"
 	"github.com/sourcenetwork/defradb/errors"
 	"github.com/sourcenetwork/defradb/logging"
+	defranet "github.com/sourcenetwork/defradb/net"
 )
 
 const (
@@ -61,6 +62,8 @@ type serverOptions struct {
 	allowedOrigins []string
 	// ID of the server node.
 	peerID string
+	// the signatures accepted on the imported blocks.
+	signaturePolicy defranet.SignaturePolicy
 	// when the value is present, the server will run with tls
 	tls immutable.Option[tlsOptions]
 	// root directory for the node config.
@@ -167,6 +170,13 @@ func WithPeerID(id string) func(*Server) {
 	}
 }
 
+// WithSignaturePolicy returns an option to set the signatures accepted on the imported blocks.
+func WithSignaturePolicy(policy defranet.SignaturePolicy) func(*Server) {
+	return func(s *Server) {
+		s.options.signaturePolicy = policy
+	}
+}
+
 // WithRootDir returns an option to set the root directory for the node config.
 func WithRootDir(rootDir string) func(*Server) {
 	return func(s *Server) {
@@ -0,0 +1,109 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	httpapi "github.com/sourcenetwork/defradb/api/http"
+	"github.com/sourcenetwork/defradb/config"
+)
+
+func MakeBlocksExportCommand(cfg *config.Config) *cobra.Command {
+	var dockeys []string
+	var output string
+
+	var cmd = &cobra.Command{
+		Use:   "export <collection>",
+		Short: "Export the DAG of documents as a CAR file",
+		Long: `Export the DAG of documents as a CAR file.
+
+The full Merkle DAG of the documents is written as a CARv1 file, whose roots are the
+composite heads of the documents. All the documents of the collection are exported if
+no document key is given. The file is written to stdout unless an output file is given.
+
+Example: export a single user to a file:
+  defradb client blocks export User --dockey bae-123 -o user.car`,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			if len(args) != 1 {
+				return NewErrMissingArg("collection")
+			}
+
+			body, err := json.Marshal(map[string]any{
+				"collection": args[0],
+				"dockeys":    dockeys,
+			})
+			if err != nil {
+				return err
+			}
+
+			endpoint, err := httpapi.JoinPaths(cfg.API.AddressToURL(), httpapi.BlocksExportPath)
+			if err != nil {
+				return NewErrFailedToJoinEndpoint(err)
+			}
+
+			res, err := http.Post(endpoint.String(), "application/json", bytes.NewBuffer(body))
+			if err != nil {
+				return NewErrFailedToSendRequest(err)
+			}
+
+			defer func() {
+				if e := res.Body.Close(); e != nil {
+					err = NewErrFailedToReadResponseBody(err)
+				}
+			}()
+
+			if res.StatusCode != http.StatusOK {
+				response, err := io.ReadAll(res.Body)
+				if err != nil {
+					return NewErrFailedToReadResponseBody(err)
+				}
+				indentedResult, err := indentJSON(response)
+				if err != nil {
+					return NewErrFailedToPrettyPrintResponse(err)
+				}
+				log.FeedbackError(cmd.Context(), indentedResult)
+				return nil
+			}
+
+			if output == "" {
+				if _, err := io.Copy(os.Stdout, res.Body); err != nil {
+					return NewErrFailedToReadResponseBody(err)
+				}
+				return nil
+			}
+
+			file, err := os.Create(output)
+			if err != nil {
+				return NewErrFailedToWriteFile(err)
+			}
+			defer func() {
+				if e := file.Close(); e != nil && err == nil {
+					err = NewErrFailedToWriteFile(e)
+				}
+			}()
+			if _, err := io.Copy(file, res.Body); err != nil {
+				return NewErrFailedToWriteFile(err)
+			}
+			log.FeedbackInfo(cmd.Context(), "Exported blocks to "+output)
+			return nil
+		},
+	}
+	cmd.Flags().StringArrayVar(&dockeys, "dockey", []string{}, "Document key to export")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "File to write the CAR file to")
+	return cmd
+}
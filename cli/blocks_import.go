@@ -0,0 +1,107 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	httpapi "github.com/sourcenetwork/defradb/api/http"
+	"github.com/sourcenetwork/defradb/config"
+)
+
+func MakeBlocksImportCommand(cfg *config.Config) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "import <file>",
+		Short: "Import the DAG of documents from a CAR file",
+		Long: `Import the DAG of documents from a CAR file.
+
+The blocks of a CAR file written by an export are merged in the same way as the blocks
+received from other peers, so that the documents converge as if they had been synced.
+Purged documents and heads that are already known are skipped.
+
+Example: import a file:
+  defradb client blocks import user.car
+
+Example: import from stdin:
+  cat user.car | defradb client blocks import -`,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			if len(args) != 1 {
+				return NewErrMissingArg("file")
+			}
+
+			var body []byte
+			if args[0] == "-" {
+				body, err = io.ReadAll(os.Stdin)
+				if err != nil {
+					return NewFailedToReadStdin(err)
+				}
+			} else {
+				body, err = os.ReadFile(args[0])
+				if err != nil {
+					return NewFailedToReadFile(err)
+				}
+			}
+			if len(body) == 0 {
+				return ErrEmptyFile
+			}
+
+			endpoint, err := httpapi.JoinPaths(cfg.API.AddressToURL(), httpapi.BlocksImportPath)
+			if err != nil {
+				return NewErrFailedToJoinEndpoint(err)
+			}
+
+			res, err := http.Post(endpoint.String(), "application/vnd.ipld.car", bytes.NewBuffer(body))
+			if err != nil {
+				return NewErrFailedToSendRequest(err)
+			}
+
+			defer func() {
+				if e := res.Body.Close(); e != nil {
+					err = NewErrFailedToReadResponseBody(err)
+				}
+			}()
+
+			response, err := io.ReadAll(res.Body)
+			if err != nil {
+				return NewErrFailedToReadResponseBody(err)
+			}
+
+			stdout, err := os.Stdout.Stat()
+			if err != nil {
+				return NewErrFailedToStatStdOut(err)
+			}
+			if isFileInfoPipe(stdout) {
+				cmd.Println(string(response))
+			} else {
+				graphlErr, err := hasGraphQLErrors(response)
+				if err != nil {
+					return NewErrFailedToHandleGQLErrors(err)
+				}
+				indentedResult, err := indentJSON(response)
+				if err != nil {
+					return NewErrFailedToPrettyPrintResponse(err)
+				}
+				if graphlErr {
+					log.FeedbackError(cmd.Context(), indentedResult)
+				} else {
+					log.FeedbackInfo(cmd.Context(), indentedResult)
+				}
+			}
+			return nil
+		},
+	}
+	return cmd
+}
@@ -76,16 +76,24 @@ func NewDefraCommand(cfg *config.Config) DefraCommand {
 	)
 	blocksCmd.AddCommand(
 		MakeBlocksGetCommand(cfg),
+		MakeBlocksExportCommand(cfg),
+		MakeBlocksImportCommand(cfg),
 	)
 	schemaCmd.AddCommand(
 		MakeSchemaAddCommand(cfg),
 		MakeSchemaPatchCommand(cfg),
+		MakeSchemaDescribeCommand(cfg),
 	)
 	clientCmd.AddCommand(
 		MakeDumpCommand(cfg),
 		MakePingCommand(cfg),
 		MakeRequestCommand(cfg),
 		MakePeerIDCommand(cfg),
+		MakeCompactCommand(cfg),
+		MakeFsckCommand(cfg),
+		MakeReindexCommand(cfg),
+		MakePurgeCommand(cfg),
+		MakeGCCommand(cfg),
 		schemaCmd,
 		rpcCmd,
 		blocksCmd,
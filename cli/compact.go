@@ -0,0 +1,111 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	httpapi "github.com/sourcenetwork/defradb/api/http"
+	"github.com/sourcenetwork/defradb/config"
+)
+
+func MakeCompactCommand(cfg *config.Config) *cobra.Command {
+	var dockeys []string
+	var height uint64
+	var depth uint64
+
+	var cmd = &cobra.Command{
+		Use:   "compact [collection]",
+		Short: "Compact the history of the documents in a collection",
+		Long: `Compact the history of the documents in a collection.
+
+Folds the commits at or below the given height into a single snapshot commit per document,
+and removes the folded blocks from the blockstore. The current heads of the documents are kept.
+
+Example: compact all commits up to height 100 of all users:
+  defradb client compact User --height 100
+
+Example: keep only the latest 10 commits of a single user:
+  defradb client compact User --depth 10 --dockey bae-123`,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			if len(args) != 1 {
+				return NewErrMissingArg("collection")
+			}
+			if height == 0 && depth == 0 {
+				return NewErrMissingArg("height or depth")
+			}
+
+			body, err := json.Marshal(map[string]any{
+				"collection": args[0],
+				"dockeys":    dockeys,
+				"height":     height,
+				"depth":      depth,
+			})
+			if err != nil {
+				return err
+			}
+
+			endpoint, err := httpapi.JoinPaths(cfg.API.AddressToURL(), httpapi.CompactPath)
+			if err != nil {
+				return NewErrFailedToJoinEndpoint(err)
+			}
+
+			res, err := http.Post(endpoint.String(), "application/json", bytes.NewBuffer(body))
+			if err != nil {
+				return NewErrFailedToSendRequest(err)
+			}
+
+			defer func() {
+				if e := res.Body.Close(); e != nil {
+					err = NewErrFailedToReadResponseBody(err)
+				}
+			}()
+
+			response, err := io.ReadAll(res.Body)
+			if err != nil {
+				return NewErrFailedToReadResponseBody(err)
+			}
+
+			stdout, err := os.Stdout.Stat()
+			if err != nil {
+				return NewErrFailedToStatStdOut(err)
+			}
+			if isFileInfoPipe(stdout) {
+				cmd.Println(string(response))
+			} else {
+				graphlErr, err := hasGraphQLErrors(response)
+				if err != nil {
+					return NewErrFailedToHandleGQLErrors(err)
+				}
+				indentedResult, err := indentJSON(response)
+				if err != nil {
+					return NewErrFailedToPrettyPrintResponse(err)
+				}
+				if graphlErr {
+					log.FeedbackError(cmd.Context(), indentedResult)
+				} else {
+					log.FeedbackInfo(cmd.Context(), indentedResult)
+				}
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringArrayVar(&dockeys, "dockey", []string{}, "Document key to compact, all documents if not set")
+	cmd.Flags().Uint64Var(&height, "height", 0, "Highest commit height to fold into the snapshot")
+	cmd.Flags().Uint64Var(&depth, "depth", 0, "Number of latest commits to keep if no height is given")
+	return cmd
+}
@@ -20,6 +20,7 @@ const (
 	errEmptyFile                   string = "empty file"
 	errFailedToReadFile            string = "failed to read file"
 	errFailedToReadStdin           string = "failed to read stdin"
+	errFailedToWriteFile           string = "failed to write file"
 	errFailedToCreateRPCClient     string = "failed to create RPC client"
 	errFailedToAddReplicator       string = "failed to add replicator, request failed"
 	errFailedToJoinEndpoint        string = "failed to join endpoint"
@@ -43,6 +44,7 @@ var (
 	ErrEmptyStdin                  = errors.New(errEmptyStdin)
 	ErrFailedToReadFile            = errors.New(errFailedToReadFile)
 	ErrFailedToReadStdin           = errors.New(errFailedToReadStdin)
+	ErrFailedToWriteFile           = errors.New(errFailedToWriteFile)
 	ErrFailToWrapRPCClient         = errors.New(errFailedToCreateRPCClient)
 	ErrFailedToAddReplicator       = errors.New(errFailedToAddReplicator)
 	ErrFailedToJoinEndpoint        = errors.New(errFailedToJoinEndpoint)
@@ -70,6 +72,10 @@ func NewFailedToReadStdin(inner error) error {
 	return errors.Wrap(errFailedToReadStdin, inner)
 }
 
+func NewErrFailedToWriteFile(inner error) error {
+	return errors.Wrap(errFailedToWriteFile, inner)
+}
+
 func NewErrFailedToCreateRPCClient(inner error) error {
 	return errors.Wrap(errFailedToCreateRPCClient, inner)
 }
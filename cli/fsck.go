@@ -0,0 +1,116 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	httpapi "github.com/sourcenetwork/defradb/api/http"
+	"github.com/sourcenetwork/defradb/config"
+)
+
+func MakeFsckCommand(cfg *config.Config) *cobra.Command {
+	var dockeys []string
+	var repair bool
+
+	var cmd = &cobra.Command{
+		Use:   "fsck [collection]",
+		Short: "Check the consistency of the stores of the documents",
+		Long: `Check the consistency of the stores of the documents.
+
+Checks that the heads of the documents point to blocks that exist in the blockstore, that
+their primary key entries match their composite heads, and that their field values match
+the tip of their field DAG. All the collections are checked if none is given.
+
+With --repair, the inconsistent documents are rebuilt by replaying their head blocks.
+Heads pointing to missing blocks cannot be repaired and are only reported.
+
+Example: check all the documents:
+  defradb client fsck
+
+Example: check and repair a single user:
+  defradb client fsck User --dockey bae-123 --repair`,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			if len(args) > 1 {
+				return ErrTooManyArgs
+			}
+			collection := ""
+			if len(args) == 1 {
+				collection = args[0]
+			}
+			if collection == "" && len(dockeys) > 0 {
+				return NewErrMissingArg("collection")
+			}
+
+			body, err := json.Marshal(map[string]any{
+				"collection": collection,
+				"dockeys":    dockeys,
+				"repair":     repair,
+			})
+			if err != nil {
+				return err
+			}
+
+			endpoint, err := httpapi.JoinPaths(cfg.API.AddressToURL(), httpapi.FsckPath)
+			if err != nil {
+				return NewErrFailedToJoinEndpoint(err)
+			}
+
+			res, err := http.Post(endpoint.String(), "application/json", bytes.NewBuffer(body))
+			if err != nil {
+				return NewErrFailedToSendRequest(err)
+			}
+
+			defer func() {
+				if e := res.Body.Close(); e != nil {
+					err = NewErrFailedToReadResponseBody(err)
+				}
+			}()
+
+			response, err := io.ReadAll(res.Body)
+			if err != nil {
+				return NewErrFailedToReadResponseBody(err)
+			}
+
+			stdout, err := os.Stdout.Stat()
+			if err != nil {
+				return NewErrFailedToStatStdOut(err)
+			}
+			if isFileInfoPipe(stdout) {
+				cmd.Println(string(response))
+			} else {
+				graphlErr, err := hasGraphQLErrors(response)
+				if err != nil {
+					return NewErrFailedToHandleGQLErrors(err)
+				}
+				indentedResult, err := indentJSON(response)
+				if err != nil {
+					return NewErrFailedToPrettyPrintResponse(err)
+				}
+				if graphlErr {
+					log.FeedbackError(cmd.Context(), indentedResult)
+				} else {
+					log.FeedbackInfo(cmd.Context(), indentedResult)
+				}
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringArrayVar(&dockeys, "dockey", []string{}, "Document key to check, all documents if not set")
+	cmd.Flags().BoolVar(&repair, "repair", false, "Repair the inconsistencies found")
+	return cmd
+}
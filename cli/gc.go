@@ -0,0 +1,121 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	httpapi "github.com/sourcenetwork/defradb/api/http"
+	"github.com/sourcenetwork/defradb/config"
+)
+
+func MakeGCCommand(cfg *config.Config) *cobra.Command {
+	var dryRun bool
+	var gracePeriod time.Duration
+
+	var cmd = &cobra.Command{
+		Use:   "gc",
+		Short: "Garbage collect the blocks that are not reachable from any head",
+		Long: `Garbage collect the blocks that are not reachable from any head.
+
+Failed transactions, dropped collections and compactions can leave blocks in the blockstore
+that no head reaches. The blocks reachable from all the current heads are marked, the others
+are reported and then deleted, once they have been found unreachable for the grace period.
+
+Blocks being synced from other peers are unreachable until their heads are committed, so they are
+kept for the grace period of the node, ten minutes by default, unless another one is given. A zero
+grace period deletes the unreachable blocks right away.
+
+Example: report the unreachable blocks without deleting them:
+  defradb client gc --dry-run
+
+Example: delete the blocks found unreachable for at least one hour:
+  defradb client gc --grace-period 1h
+
+Example: delete all the unreachable blocks right away:
+  defradb client gc --grace-period 0`,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			if len(args) != 0 {
+				return ErrTooManyArgs
+			}
+
+			gcReq := map[string]any{
+				"dryRun": dryRun,
+			}
+			if cmd.Flags().Changed("grace-period") {
+				gcReq["gracePeriod"] = gracePeriod.String()
+			}
+			body, err := json.Marshal(gcReq)
+			if err != nil {
+				return err
+			}
+
+			endpoint, err := httpapi.JoinPaths(cfg.API.AddressToURL(), httpapi.GCPath)
+			if err != nil {
+				return NewErrFailedToJoinEndpoint(err)
+			}
+
+			res, err := http.Post(endpoint.String(), "application/json", bytes.NewBuffer(body))
+			if err != nil {
+				return NewErrFailedToSendRequest(err)
+			}
+
+			defer func() {
+				if e := res.Body.Close(); e != nil {
+					err = NewErrFailedToReadResponseBody(err)
+				}
+			}()
+
+			response, err := io.ReadAll(res.Body)
+			if err != nil {
+				return NewErrFailedToReadResponseBody(err)
+			}
+
+			stdout, err := os.Stdout.Stat()
+			if err != nil {
+				return NewErrFailedToStatStdOut(err)
+			}
+			if isFileInfoPipe(stdout) {
+				cmd.Println(string(response))
+			} else {
+				graphlErr, err := hasGraphQLErrors(response)
+				if err != nil {
+					return NewErrFailedToHandleGQLErrors(err)
+				}
+				indentedResult, err := indentJSON(response)
+				if err != nil {
+					return NewErrFailedToPrettyPrintResponse(err)
+				}
+				if graphlErr {
+					log.FeedbackError(cmd.Context(), indentedResult)
+				} else {
+					log.FeedbackInfo(cmd.Context(), indentedResult)
+				}
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only report the unreachable blocks, without deleting them")
+	cmd.Flags().DurationVar(
+		&gracePeriod,
+		"grace-period",
+		0,
+		"Only delete the blocks found unreachable for at least this duration (default the grace period of the node)",
+	)
+	return cmd
+}
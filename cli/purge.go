@@ -0,0 +1,104 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	httpapi "github.com/sourcenetwork/defradb/api/http"
+	"github.com/sourcenetwork/defradb/config"
+)
+
+func MakePurgeCommand(cfg *config.Config) *cobra.Command {
+	var dockeys []string
+
+	var cmd = &cobra.Command{
+		Use:   "purge <collection>",
+		Short: "Permanently erase documents from a collection",
+		Long: `Permanently erase documents from a collection.
+
+Unlike a delete, which only marks a document as deleted, a purge removes the field values,
+the heads and every block of the history of the documents. A tombstone is kept so that the
+documents are not re-created from the blocks of other peers. The documents are only erased
+from this node.
+
+Example: purge a single user:
+  defradb client purge User --dockey bae-123`,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			if len(args) != 1 {
+				return NewErrMissingArg("collection")
+			}
+			if len(dockeys) == 0 {
+				return NewErrMissingArg("dockey")
+			}
+
+			body, err := json.Marshal(map[string]any{
+				"collection": args[0],
+				"dockeys":    dockeys,
+			})
+			if err != nil {
+				return err
+			}
+
+			endpoint, err := httpapi.JoinPaths(cfg.API.AddressToURL(), httpapi.PurgePath)
+			if err != nil {
+				return NewErrFailedToJoinEndpoint(err)
+			}
+
+			res, err := http.Post(endpoint.String(), "application/json", bytes.NewBuffer(body))
+			if err != nil {
+				return NewErrFailedToSendRequest(err)
+			}
+
+			defer func() {
+				if e := res.Body.Close(); e != nil {
+					err = NewErrFailedToReadResponseBody(err)
+				}
+			}()
+
+			response, err := io.ReadAll(res.Body)
+			if err != nil {
+				return NewErrFailedToReadResponseBody(err)
+			}
+
+			stdout, err := os.Stdout.Stat()
+			if err != nil {
+				return NewErrFailedToStatStdOut(err)
+			}
+			if isFileInfoPipe(stdout) {
+				cmd.Println(string(response))
+			} else {
+				graphlErr, err := hasGraphQLErrors(response)
+				if err != nil {
+					return NewErrFailedToHandleGQLErrors(err)
+				}
+				indentedResult, err := indentJSON(response)
+				if err != nil {
+					return NewErrFailedToPrettyPrintResponse(err)
+				}
+				if graphlErr {
+					log.FeedbackError(cmd.Context(), indentedResult)
+				} else {
+					log.FeedbackInfo(cmd.Context(), indentedResult)
+				}
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringArrayVar(&dockeys, "dockey", []string{}, "Document key to purge")
+	return cmd
+}
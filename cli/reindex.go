@@ -0,0 +1,112 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	httpapi "github.com/sourcenetwork/defradb/api/http"
+	"github.com/sourcenetwork/defradb/config"
+)
+
+func MakeReindexCommand(cfg *config.Config) *cobra.Command {
+	var dockeys []string
+
+	var cmd = &cobra.Command{
+		Use:   "reindex [collection]",
+		Short: "Rebuild the stored state of the documents from the blockstore",
+		Long: `Rebuild the stored state of the documents from the blockstore.
+
+Regenerates the field values, the priorities and the heads of the documents purely from
+their blocks, by replaying them through the CRDT merge path. This recovers from a corrupted
+datastore, or from a node where only the blockstore has been kept. The schema of the
+collections must have been added beforehand. All the collections are reindexed if none is
+given. The progress is reported in the logs of the node.
+
+Example: reindex all the documents:
+  defradb client reindex
+
+Example: reindex a single user:
+  defradb client reindex User --dockey bae-123`,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			if len(args) > 1 {
+				return ErrTooManyArgs
+			}
+			collection := ""
+			if len(args) == 1 {
+				collection = args[0]
+			}
+			if collection == "" && len(dockeys) > 0 {
+				return NewErrMissingArg("collection")
+			}
+
+			body, err := json.Marshal(map[string]any{
+				"collection": collection,
+				"dockeys":    dockeys,
+			})
+			if err != nil {
+				return err
+			}
+
+			endpoint, err := httpapi.JoinPaths(cfg.API.AddressToURL(), httpapi.ReindexPath)
+			if err != nil {
+				return NewErrFailedToJoinEndpoint(err)
+			}
+
+			res, err := http.Post(endpoint.String(), "application/json", bytes.NewBuffer(body))
+			if err != nil {
+				return NewErrFailedToSendRequest(err)
+			}
+
+			defer func() {
+				if e := res.Body.Close(); e != nil {
+					err = NewErrFailedToReadResponseBody(err)
+				}
+			}()
+
+			response, err := io.ReadAll(res.Body)
+			if err != nil {
+				return NewErrFailedToReadResponseBody(err)
+			}
+
+			stdout, err := os.Stdout.Stat()
+			if err != nil {
+				return NewErrFailedToStatStdOut(err)
+			}
+			if isFileInfoPipe(stdout) {
+				cmd.Println(string(response))
+			} else {
+				graphlErr, err := hasGraphQLErrors(response)
+				if err != nil {
+					return NewErrFailedToHandleGQLErrors(err)
+				}
+				indentedResult, err := indentJSON(response)
+				if err != nil {
+					return NewErrFailedToPrettyPrintResponse(err)
+				}
+				if graphlErr {
+					log.FeedbackError(cmd.Context(), indentedResult)
+				} else {
+					log.FeedbackInfo(cmd.Context(), indentedResult)
+				}
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringArrayVar(&dockeys, "dockey", []string{}, "Document key to reindex, all documents if not set")
+	return cmd
+}
@@ -25,6 +25,7 @@ import (
 )
 
 func MakeRequestCommand(cfg *config.Config) *cobra.Command {
+	var identity string
 	var cmd = &cobra.Command{
 		Use:   "query [query request]",
 		Short: "Send a DefraDB GraphQL query request",
@@ -94,7 +95,15 @@ To learn more about the DefraDB GraphQL Query Language, refer to https://docs.so
 			p.Add("query", request)
 			endpoint.RawQuery = p.Encode()
 
-			res, err := http.Get(endpoint.String())
+			req, err := http.NewRequest(http.MethodGet, endpoint.String(), nil)
+			if err != nil {
+				return errors.Wrap("failed to create request", err)
+			}
+			if identity != "" {
+				req.Header.Set(httpapi.IdentityHeader, identity)
+			}
+
+			res, err := http.DefaultClient.Do(req)
 			if err != nil {
 				return errors.Wrap("failed request", err)
 			}
@@ -136,5 +145,11 @@ To learn more about the DefraDB GraphQL Query Language, refer to https://docs.so
 		},
 	}
 
+	cmd.Flags().StringVar(
+		&identity,
+		"identity",
+		"",
+		"Identity of the user making the request, recorded as the author of the commits it creates",
+	)
 	return cmd
 }
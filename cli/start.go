@@ -243,9 +243,27 @@ func start(ctx context.Context, cfg *config.Config) (*defraInstance, error) {
 
 	options := []db.Option{
 		db.WithUpdateEvents(),
+		db.WithConflictEvents(),
 		db.WithMaxRetries(cfg.Datastore.MaxTxnRetries),
 	}
 
+	gcInterval, err := cfg.Datastore.GCIntervalDuration()
+	if err != nil {
+		return nil, err
+	}
+	if gcInterval > 0 {
+		options = append(options, db.WithGCInterval(gcInterval))
+	}
+
+	if !cfg.Net.P2PDisabled {
+		// blocks are signed with the key of the libp2p host of the node
+		hostKey, err := node.GetHostKey(cfg.Datastore.Badger.Path)
+		if err != nil {
+			return nil, errors.Wrap("failed to load host key", err)
+		}
+		options = append(options, db.WithSigningKey(hostKey))
+	}
+
 	db, err := db.NewDB(ctx, rootstore, options...)
 	if err != nil {
 		return nil, errors.Wrap("failed to create database", err)
@@ -334,6 +352,7 @@ func start(ctx context.Context, cfg *config.Config) (*defraInstance, error) {
 
 	if n != nil {
 		sOpt = append(sOpt, httpapi.WithPeerID(n.PeerID().String()))
+		sOpt = append(sOpt, httpapi.WithSignaturePolicy(n.SignaturePolicy()))
 	}
 
 	if cfg.API.TLS {
@@ -12,6 +12,9 @@ package client
 
 import (
 	"context"
+	"io"
+
+	"github.com/ipfs/go-cid"
 
 	"github.com/sourcenetwork/defradb/datastore"
 )
@@ -58,8 +61,15 @@ type Collection interface {
 	//
 	// Will return true if a deletion is successful, and return false along with an error
 	// if it cannot. If the document doesn't exist, then it will return false and a ErrDocumentNotFound error.
-	// This operation will hard-delete all state relating to the given DocKey. This includes data, block, and head storage.
+	// This operation only marks the document as deleted, its history is kept. Use Purge to erase it.
 	Delete(context.Context, DocKey) (bool, error)
+	// Purge will attempt to permanently erase a document by key, whether it has been deleted or not.
+	//
+	// All the state of the document is removed: its field values, priorities, heads, and every block
+	// of its DAG. A tombstone is left in its place so that the document is not re-created from blocks
+	// received from other peers. If the document doesn't exist, then it will return false and a
+	// ErrDocumentNotFound error.
+	Purge(context.Context, DocKey) (bool, error)
 	// Exists checks if a given document exists with supplied DocKey.
 	//
 	// Will return true if a matching document exists, otherwise will return false.
@@ -136,6 +146,201 @@ type Collection interface {
 
 	// GetAllDocKeys returns all the document keys that exist in the collection.
 	GetAllDocKeys(ctx context.Context) (<-chan DocKeysResult, error)
+
+	// Compact folds the history of documents in this collection older than the height
+	// given by the options into a single snapshot block per document.
+	//
+	// If no DocKeys are provided all documents in the collection will be compacted. The current
+	// heads of each document are never folded, so they remain valid for P2P sync.
+	Compact(ctx context.Context, opts CompactOptions) (*CompactResult, error)
+
+	// Diff returns the field-level changes of the document with the given DocKey between
+	// the two given versions.
+	//
+	// A version is either the CID of a composite commit of the document, or LatestVersion
+	// for its current state. Only the fields that differ between the two versions are
+	// returned, ordered by field name.
+	Diff(ctx context.Context, key DocKey, from string, to string) ([]FieldDiff, error)
+
+	// Revert restores the document with the given DocKey to its state at the given version.
+	//
+	// The version must be the CID of a composite commit of the document. The history is not
+	// rewritten, instead a new commit restoring the field values of that version is written on
+	// top of the current heads. The CID of this new commit is returned.
+	Revert(ctx context.Context, key DocKey, version string) (cid.Cid, error)
+
+	// Fsck checks that the headstore, the blockstore and the datastore of this collection
+	// are consistent with each other, and returns the inconsistencies found.
+	//
+	// If Repair is set in the options, the inconsistent documents are repaired by replaying
+	// their head blocks through the CRDT merge path. Inconsistencies that cannot be repaired
+	// this way, such as heads pointing to missing blocks, are reported but left untouched.
+	Fsck(ctx context.Context, opts FsckOptions) (*FsckResult, error)
+
+	// Reindex rebuilds the datastore entries and the heads of the documents in this collection
+	// purely from the blocks in the blockstore.
+	//
+	// If no DocKeys are provided all the documents with blocks in the blockstore are reindexed,
+	// and all the existing datastore entries of the collection are dropped beforehand.
+	Reindex(ctx context.Context, opts ReindexOptions) (*ReindexResult, error)
+
+	// ExportDAG writes the full Merkle DAG of the documents in this collection to the given writer
+	// as a CARv1 file.
+	//
+	// The roots of the file are the composite heads of the exported documents. If no DocKeys are
+	// provided all the documents of the collection are exported. The file may be imported on another
+	// node with the ImportDAG function of the net package.
+	ExportDAG(ctx context.Context, w io.Writer, opts ExportDAGOptions) (*ExportDAGResult, error)
+}
+
+// LatestVersion can be given in place of a commit CID to refer to the current
+// state of a document.
+const LatestVersion = "latest"
+
+// FieldDiffKind is the kind of change of a field between two versions of a document.
+type FieldDiffKind string
+
+const (
+	// FieldAdded is a field that has no value in the old version.
+	FieldAdded FieldDiffKind = "added"
+	// FieldRemoved is a field that has no value in the new version.
+	FieldRemoved FieldDiffKind = "removed"
+	// FieldChanged is a field that has a different value in each version.
+	FieldChanged FieldDiffKind = "changed"
+)
+
+// FieldDiff is the change of a single field between two versions of a document.
+type FieldDiff struct {
+	// FieldName is the name of the changed field.
+	FieldName string
+	// Kind is the kind of the change.
+	Kind FieldDiffKind
+	// OldValue is the value of the field in the old version, nil if it was added.
+	OldValue any
+	// NewValue is the value of the field in the new version, nil if it was removed.
+	NewValue any
+}
+
+// CompactOptions contains the parameters of a history compaction.
+type CompactOptions struct {
+	// DocKeys optionally limits the compaction to the given documents.
+	DocKeys []DocKey
+	// Height is the highest composite block height that will be folded into the snapshot.
+	Height uint64
+	// Depth is the number of most recent composite blocks to keep. It is only
+	// used if Height is not set.
+	Depth uint64
+}
+
+// CompactResult wraps the result of a compaction call.
+type CompactResult struct {
+	// Count contains the number of documents that had their history compacted.
+	Count int64
+	// DocKeys contains the DocKeys of all the documents compacted by the call.
+	DocKeys []string
+	// RemovedBlocks contains the number of blocks removed from the blockstore.
+	RemovedBlocks int64
+}
+
+// FsckOptions contains the parameters of a consistency check.
+type FsckOptions struct {
+	// DocKeys optionally limits the check to the given documents.
+	DocKeys []DocKey
+	// Repair the inconsistencies found by replaying the head blocks of the documents.
+	Repair bool
+}
+
+// InconsistencyKind is the kind of an inconsistency found by a consistency check.
+type InconsistencyKind string
+
+const (
+	// MissingHeadBlock is a head in the headstore pointing to a block that is not
+	// in the blockstore.
+	MissingHeadBlock InconsistencyKind = "missingHeadBlock"
+	// FieldValueMismatch is a field value or priority that does not match the
+	// tip of the field DAG.
+	FieldValueMismatch InconsistencyKind = "fieldValueMismatch"
+	// MissingPrimaryKey is a document with heads but without a primary key entry.
+	MissingPrimaryKey InconsistencyKind = "missingPrimaryKey"
+	// MissingCompositeHead is a document with data in the datastore but without
+	// any composite head.
+	MissingCompositeHead InconsistencyKind = "missingCompositeHead"
+	// DocumentStatusMismatch is a primary key entry whose deleted status does not
+	// match the composite heads of the document.
+	DocumentStatusMismatch InconsistencyKind = "documentStatusMismatch"
+)
+
+// Inconsistency is an inconsistency found by a consistency check.
+type Inconsistency struct {
+	// DocKey is the key of the inconsistent document.
+	DocKey string `json:"dockey"`
+	// FieldName is the name of the inconsistent field, empty if the inconsistency is
+	// about the document itself.
+	FieldName string `json:"fieldName,omitempty"`
+	// Kind is the kind of the inconsistency.
+	Kind InconsistencyKind `json:"kind"`
+	// Cid is the CID of the missing block, if any.
+	Cid string `json:"cid,omitempty"`
+	// Repaired is true if the inconsistency has been repaired.
+	Repaired bool `json:"repaired"`
+}
+
+// FsckResult wraps the result of a consistency check.
+type FsckResult struct {
+	// Count contains the number of documents checked.
+	Count int64
+	// Inconsistencies contains the inconsistencies found by the check.
+	Inconsistencies []Inconsistency
+}
+
+// ReindexOptions contains the parameters of a reindex.
+type ReindexOptions struct {
+	// DocKeys optionally limits the reindex to the given documents.
+	DocKeys []DocKey
+	// OnProgress is optionally called after each reindexed document.
+	OnProgress func(ReindexProgress)
+}
+
+// ReindexProgress reports the progress of a reindex.
+type ReindexProgress struct {
+	// DocKey is the key of the last reindexed document.
+	DocKey string
+	// Done is the number of documents reindexed so far.
+	Done int64
+	// Total is the number of documents to reindex.
+	Total int64
+}
+
+// ReindexResult wraps the result of a reindex call.
+type ReindexResult struct {
+	// Count contains the number of documents reindexed.
+	Count int64
+	// ReplayedBlocks contains the number of blocks replayed.
+	ReplayedBlocks int64
+}
+
+// ExportDAGOptions contains the parameters of a DAG export.
+type ExportDAGOptions struct {
+	// DocKeys optionally limits the export to the given documents.
+	DocKeys []DocKey
+}
+
+// ExportDAGResult wraps the result of a DAG export.
+type ExportDAGResult struct {
+	// Count contains the number of documents exported.
+	Count int64
+	// Blocks contains the number of blocks written to the file.
+	Blocks int64
+}
+
+// ImportDAGResult wraps the result of a DAG import.
+type ImportDAGResult struct {
+	// Count contains the number of documents imported.
+	Count int64
+	// DocKeys contains the DocKeys of all the documents imported.
+	DocKeys []string
+	// MergedBlocks contains the number of blocks merged into the stores.
+	MergedBlocks int64
 }
 
 // DocKeysResult wraps the result of an attempt at a DocKey retrieval operation.
@@ -23,3 +23,48 @@ const (
 	OBJECT
 	COMPOSITE
 )
+
+// FieldCTypeNames maps the names of the CRDT types that may be given to document fields to
+// their values.
+//
+// The names are used by the @crdt directive of the schema, and to describe the fields of the
+// collections. Other CRDT types can not be given to fields yet.
+var FieldCTypeNames = map[string]CType{
+	"lww": LWW_REGISTER,
+}
+
+// FieldName returns the name of the CRDT type as given to document fields, or an empty string
+// if it can not be given to fields.
+func (t CType) FieldName() string {
+	for name, cType := range FieldCTypeNames {
+		if cType == t {
+			return name
+		}
+	}
+	return ""
+}
+
+// IsSupportedFieldCType returns true if the type is supported as a document field type.
+func (t CType) IsSupportedFieldCType() bool {
+	switch t {
+	case NONE_CRDT, LWW_REGISTER:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsCompatibleWith returns true if the CRDT type may be used to store values of the
+// given field kind.
+//
+// Relation fields do not hold a value of their own and are only compatible with [NONE_CRDT].
+func (t CType) IsCompatibleWith(kind FieldKind) bool {
+	switch t {
+	case NONE_CRDT:
+		return true
+	case LWW_REGISTER:
+		return kind != FieldKind_FOREIGN_OBJECT && kind != FieldKind_FOREIGN_OBJECT_ARRAY
+	default:
+		return false
+	}
+}
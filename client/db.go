@@ -12,8 +12,10 @@ package client
 
 import (
 	"context"
+	"time"
 
 	blockstore "github.com/ipfs/boxo/blockstore"
+	"github.com/sourcenetwork/immutable"
 
 	"github.com/sourcenetwork/defradb/datastore"
 	"github.com/sourcenetwork/defradb/events"
@@ -76,6 +78,15 @@ type DB interface {
 	//
 	// It is likely unwise to call this on a large database instance.
 	PrintDump(ctx context.Context) error
+
+	// CollectGarbage removes the blocks of the blockstore that are not reachable from any of the
+	// heads in the headstore, such as those left behind by failed transactions, dropped collections
+	// or compactions.
+	//
+	// The reachable blocks are marked by walking the DAGs of all the current heads, the remaining
+	// blocks are then reported and, unless [GCOptions.DryRun] is set, deleted once they have been
+	// unreachable for the [GCOptions.GracePeriod].
+	CollectGarbage(ctx context.Context, opts GCOptions) (*GCResult, error)
 }
 
 // Store contains the core DefraDB read-write operations.
@@ -143,6 +154,40 @@ type GQLResult struct {
 	//
 	// It will be nil if any errors were raised during execution.
 	Data any `json:"data"`
+
+	// Extensions contains any additional information about the execution of the request,
+	// such as the [PageInfo] of a paginated request.
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
+
+// PageInfoExtension is the key of the [PageInfo] within the [GQLResult] extensions.
+//
+// Page info is only returned for the first top-level select of a request, so cursors can not
+// be given to the selects of related objects, nor to requests with more than one select.
+const PageInfoExtension = "pageInfo"
+
+// PageInfo describes the page of results returned by a request that has been given a
+// limit or a cursor.
+type PageInfo struct {
+	// HasNextPage is true if there are more results after this page.
+	HasNextPage bool `json:"hasNextPage"`
+
+	// HasPreviousPage is true if there are more results before this page.
+	//
+	// It is always true if the request was given an `after` cursor.
+	HasPreviousPage bool `json:"hasPreviousPage"`
+
+	// StartCursor is the cursor of the first result of this page, it may be given to
+	// the `before` argument of a request to fetch the previous page.
+	//
+	// It will be empty if the page has no results.
+	StartCursor string `json:"startCursor"`
+
+	// EndCursor is the cursor of the last result of this page, it may be given to
+	// the `after` argument of a request to fetch the next page.
+	//
+	// It will be empty if the page has no results.
+	EndCursor string `json:"endCursor"`
 }
 
 // RequestResult represents the results of a GQL request.
@@ -152,5 +197,28 @@ type RequestResult struct {
 
 	// Pub contains a pointer to an event stream which channels any subscription results
 	// if the request was a GQL subscription.
-	Pub *events.Publisher[events.Update]
+	Pub events.Streamer
+}
+
+// GCOptions contains the parameters of a garbage collection.
+type GCOptions struct {
+	// DryRun only reports the unreachable blocks, without deleting them.
+	DryRun bool
+	// GracePeriod is the time during which the unreachable blocks are kept, from the first
+	// collection that found them unreachable. The blocks being synced from other peers are
+	// written before their heads are committed, so they must not be deleted in the meantime.
+	//
+	// The grace period of the database is used if it has no value, and unreachable blocks are
+	// deleted right away if it is zero.
+	GracePeriod immutable.Option[time.Duration]
+}
+
+// GCResult wraps the result of a garbage collection.
+type GCResult struct {
+	// Reachable contains the number of blocks reachable from the heads.
+	Reachable int64
+	// Unreachable contains the Cids of all the blocks that are not reachable from any head.
+	Unreachable []string
+	// RemovedBlocks contains the number of blocks removed from the blockstore.
+	RemovedBlocks int64
 }
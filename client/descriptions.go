@@ -231,6 +231,12 @@ type FieldDescription struct {
 	// RelationType contains the relationship type if this field is a relation field. Otherwise this
 	// will be empty.
 	RelationType RelationType
+
+	// The type of index maintained on the values of this field. If no type has been provided
+	// the field will not be indexed.
+	//
+	// It is currently immutable.
+	Index IndexType `json:",omitempty"`
 }
 
 // IsObject returns true if this field is an object type.
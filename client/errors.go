@@ -45,6 +45,10 @@ var (
 	ErrMalformedDocKey       = errors.New("malformed DocKey, missing either version or cid")
 	ErrInvalidDocKeyVersion  = errors.New("invalid DocKey version")
 	ErrMaxTxnRetries         = errors.New(errMaxTxnRetries)
+	ErrDistinctWithoutFields = errors.New("distinct must be given at least one field")
+	ErrDistinctWithGroupBy   = errors.New("distinct can not be used together with groupBy")
+	ErrCursorWithOffset      = errors.New("cursors can not be used together with offset")
+	ErrCursorWithGroupBy     = errors.New("cursors can not be used together with groupBy")
 )
 
 // NewErrFieldNotExist returns an error indicating that the given field does not exist.
@@ -0,0 +1,37 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package client
+
+import (
+	"context"
+
+	"github.com/sourcenetwork/immutable"
+)
+
+type identityContextKey struct{}
+
+// WithIdentity returns a copy of the given context carrying the identity of the user
+// making the requests.
+//
+// The identity is recorded as the author of the commits created by the requests
+// executed with the returned context.
+func WithIdentity(ctx context.Context, identity string) context.Context {
+	return context.WithValue(ctx, identityContextKey{}, identity)
+}
+
+// GetIdentity returns the identity carried by the given context, if any.
+func GetIdentity(ctx context.Context) immutable.Option[string] {
+	identity, ok := ctx.Value(identityContextKey{}).(string)
+	if !ok || identity == "" {
+		return immutable.None[string]()
+	}
+	return immutable.Some(identity)
+}
@@ -0,0 +1,45 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package client
+
+// IndexType indicates the type of index maintained on the values of a field.
+type IndexType byte
+
+// Available index types.
+const (
+	NONE_INDEX = IndexType(iota) // reserved none type
+	FULLTEXT_INDEX
+)
+
+// IsSupported returns true if the type is supported as a field index type.
+func (t IndexType) IsSupported() bool {
+	switch t {
+	case NONE_INDEX, FULLTEXT_INDEX:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsCompatibleWith returns true if the index type may be used to index values of the
+// given field kind.
+//
+// Full-text indexes may only be declared on string fields.
+func (t IndexType) IsCompatibleWith(kind FieldKind) bool {
+	switch t {
+	case NONE_INDEX:
+		return true
+	case FULLTEXT_INDEX:
+		return kind == FieldKind_STRING
+	default:
+		return false
+	}
+}
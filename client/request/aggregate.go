@@ -16,6 +16,9 @@ type Aggregate struct {
 	Field
 
 	Targets []*AggregateTarget
+
+	// Percentile is the percentile, between 0 and 100, to be yielded by a percentile aggregate.
+	Percentile immutables.Option[float64]
 }
 
 type AggregateTarget struct {
@@ -26,4 +29,7 @@ type AggregateTarget struct {
 	Offset  immutables.Option[uint64]
 	OrderBy immutables.Option[OrderBy]
 	Filter  immutables.Option[Filter]
+
+	// Distinct de-duplicates the items before they are aggregated.
+	Distinct immutables.Option[Distinct]
 }
@@ -15,6 +15,7 @@ const (
 	// https://spec.graphql.org/October2021/#sec-Type-Name-Introspection
 	TypeNameFieldName = "__typename"
 
+	AsOf        = "asOf"
 	Cid         = "cid"
 	Data        = "data"
 	DocKey      = "dockey"
@@ -25,20 +26,31 @@ const (
 	Ids         = "ids"
 	ShowDeleted = "showDeleted"
 
-	FilterClause  = "filter"
-	GroupByClause = "groupBy"
-	LimitClause   = "limit"
-	OffsetClause  = "offset"
-	OrderClause   = "order"
-	DepthClause   = "depth"
-
-	AverageFieldName = "_avg"
-	CountFieldName   = "_count"
-	KeyFieldName     = "_key"
-	GroupFieldName   = "_group"
-	DeletedFieldName = "_deleted"
-	SumFieldName     = "_sum"
-	VersionFieldName = "_version"
+	FilterClause   = "filter"
+	GroupByClause  = "groupBy"
+	DistinctClause = "distinct"
+	LimitClause    = "limit"
+	OffsetClause   = "offset"
+	OrderClause    = "order"
+	AfterClause    = "after"
+	BeforeClause   = "before"
+	DepthClause    = "depth"
+
+	AverageFieldName    = "_avg"
+	CountFieldName      = "_count"
+	KeyFieldName        = "_key"
+	GroupFieldName      = "_group"
+	DeletedFieldName    = "_deleted"
+	MaxFieldName        = "_max"
+	MedianFieldName     = "_median"
+	MinFieldName        = "_min"
+	PercentileFieldName = "_percentile"
+	StdDevFieldName     = "_stddev"
+	SumFieldName        = "_sum"
+	VarianceFieldName   = "_variance"
+	VersionFieldName    = "_version"
+
+	PercentileArgName = "p"
 
 	ExplainLabel = "explain"
 
@@ -55,10 +67,29 @@ const (
 	FieldNameFieldName       = "fieldName"
 	FieldIDFieldName         = "fieldId"
 	DeltaFieldName           = "delta"
+	SignerFieldName          = "signer"
+	AuthorFieldName          = "author"
+	TimeFieldName            = "time"
 
 	LinksNameFieldName = "name"
 	LinksCidFieldName  = "cid"
 
+	ConflictsName             = "conflicts"
+	ConflictTypeName          = "Conflict"
+	ConflictCollectionArgName = "collection"
+	LosingCidFieldName        = "losingCid"
+	WinningCidFieldName       = "winningCid"
+	LosingValueFieldName      = "losingValue"
+	WinningValueFieldName     = "winningValue"
+
+	DiffName          = "diff"
+	DiffTypeName      = "FieldDiff"
+	FromArgName       = "from"
+	ToArgName         = "to"
+	KindFieldName     = "kind"
+	OldValueFieldName = "oldValue"
+	NewValueFieldName = "newValue"
+
 	ASC  = OrderDirection("ASC")
 	DESC = OrderDirection("DESC")
 )
@@ -70,20 +101,32 @@ var (
 	}
 
 	ReservedFields = map[string]bool{
-		TypeNameFieldName: true,
-		VersionFieldName:  true,
-		GroupFieldName:    true,
-		CountFieldName:    true,
-		SumFieldName:      true,
-		AverageFieldName:  true,
-		KeyFieldName:      true,
-		DeletedFieldName:  true,
+		TypeNameFieldName:   true,
+		VersionFieldName:    true,
+		GroupFieldName:      true,
+		CountFieldName:      true,
+		SumFieldName:        true,
+		AverageFieldName:    true,
+		MinFieldName:        true,
+		MaxFieldName:        true,
+		MedianFieldName:     true,
+		PercentileFieldName: true,
+		StdDevFieldName:     true,
+		VarianceFieldName:   true,
+		KeyFieldName:        true,
+		DeletedFieldName:    true,
 	}
 
 	Aggregates = map[string]struct{}{
-		CountFieldName:   {},
-		SumFieldName:     {},
-		AverageFieldName: {},
+		CountFieldName:      {},
+		SumFieldName:        {},
+		AverageFieldName:    {},
+		MinFieldName:        {},
+		MaxFieldName:        {},
+		MedianFieldName:     {},
+		PercentileFieldName: {},
+		StdDevFieldName:     {},
+		VarianceFieldName:   {},
 	}
 
 	CommitQueries = map[string]struct{}{
@@ -100,10 +143,29 @@ var (
 		FieldNameFieldName,
 		FieldIDFieldName,
 		DeltaFieldName,
+		SignerFieldName,
+		AuthorFieldName,
+		TimeFieldName,
 	}
 
 	LinksFields = []string{
 		LinksNameFieldName,
 		LinksCidFieldName,
 	}
+
+	ConflictFields = []string{
+		DockeyFieldName,
+		FieldNameFieldName,
+		LosingCidFieldName,
+		WinningCidFieldName,
+		LosingValueFieldName,
+		WinningValueFieldName,
+	}
+
+	DiffFields = []string{
+		FieldNameFieldName,
+		KindFieldName,
+		OldValueFieldName,
+		NewValueFieldName,
+	}
 )
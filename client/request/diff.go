@@ -0,0 +1,37 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package request
+
+var (
+	_ Selection = (*DiffSelect)(nil)
+)
+
+// DiffSelect is a request for the field-level changes of a document between two versions.
+type DiffSelect struct {
+	Field
+
+	DocKey string
+	From   string
+	To     string
+
+	Fields []Selection
+}
+
+func (d DiffSelect) ToSelect() *Select {
+	return &Select{
+		Field: Field{
+			Name:  d.Name,
+			Alias: d.Alias,
+		},
+		Fields: d.Fields,
+		Root:   DiffSelection,
+	}
+}
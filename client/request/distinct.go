@@ -0,0 +1,20 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package request
+
+// Distinct represents a de-duplication instruction on a request.
+type Distinct struct {
+	// The fields by whose values results should be de-duplicated.
+	//
+	// If empty, the items themselves are de-duplicated, this is only permitted
+	// when counting inline arrays.
+	Fields []string
+}
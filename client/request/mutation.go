@@ -19,6 +19,7 @@ const (
 	CreateObjects
 	UpdateObjects
 	DeleteObjects
+	RevertObjects
 )
 
 // ObjectMutation is a field on the `mutation` operation of a graphql request. It includes
@@ -35,6 +36,9 @@ type ObjectMutation struct {
 	Filter immutable.Option[Filter]
 	Data   string
 
+	// Cid is the version to restore the document to in a revert mutation.
+	Cid string
+
 	Fields []Selection
 }
 
@@ -11,6 +11,8 @@
 package request
 
 import (
+	"time"
+
 	"github.com/sourcenetwork/immutable"
 
 	"github.com/sourcenetwork/defradb/client"
@@ -22,6 +24,7 @@ type SelectionType int
 const (
 	ObjectSelection SelectionType = iota
 	CommitSelection
+	DiffSelection
 )
 
 // Select is a complex Field with strong typing.
@@ -33,6 +36,10 @@ type Select struct {
 	DocKeys immutable.Option[[]string]
 	CID     immutable.Option[string]
 
+	// AsOf is the time at which the documents should be read, each document is
+	// returned at its latest version committed at or before that time.
+	AsOf immutable.Option[time.Time]
+
 	// Root is the top level type of parsed request
 	Root SelectionType
 
@@ -42,6 +49,16 @@ type Select struct {
 	GroupBy immutable.Option[GroupBy]
 	Filter  immutable.Option[Filter]
 
+	// Distinct de-duplicates the results by the values of the given fields, keeping
+	// the first of each.
+	Distinct immutable.Option[Distinct]
+
+	// After and Before are opaque cursors, as yielded in the page info of a previous
+	// request, restricting the results to those after and/or before the document that
+	// the cursor was taken from.
+	After  immutable.Option[string]
+	Before immutable.Option[string]
+
 	Fields []Selection
 
 	ShowDeleted bool
@@ -69,6 +86,44 @@ func (s *Select) validateShallow() []error {
 	result := []error{}
 
 	result = append(result, s.validateGroupBy()...)
+	result = append(result, s.validateDistinct()...)
+	result = append(result, s.validateCursor()...)
+
+	return result
+}
+
+func (s *Select) validateCursor() []error {
+	result := []error{}
+
+	if !s.After.HasValue() && !s.Before.HasValue() {
+		return result
+	}
+
+	if s.Offset.HasValue() {
+		result = append(result, client.ErrCursorWithOffset)
+	}
+
+	if s.GroupBy.HasValue() {
+		result = append(result, client.ErrCursorWithGroupBy)
+	}
+
+	return result
+}
+
+func (s *Select) validateDistinct() []error {
+	result := []error{}
+
+	if !s.Distinct.HasValue() {
+		return result
+	}
+
+	if len(s.Distinct.Value().Fields) == 0 {
+		result = append(result, client.ErrDistinctWithoutFields)
+	}
+
+	if s.GroupBy.HasValue() {
+		result = append(result, client.ErrDistinctWithGroupBy)
+	}
 
 	return result
 }
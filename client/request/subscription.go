@@ -42,3 +42,17 @@ func (m ObjectSubscription) ToSelect(docKey, cid string) *Select {
 		Filter:  m.Filter,
 	}
 }
+
+// ConflictSubscription is a subscription to the conflicts that occur when remote updates
+// overwrite concurrent local updates.
+type ConflictSubscription struct {
+	Field
+
+	// Collection optionally limits the conflicts to the given collection name.
+	Collection immutable.Option[string]
+
+	// DocKey optionally limits the conflicts to the given document.
+	DocKey immutable.Option[string]
+
+	Fields []Field
+}
@@ -53,6 +53,7 @@ import (
 	"text/template"
 	"time"
 
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/mitchellh/mapstructure"
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/spf13/pflag"
@@ -248,6 +249,9 @@ type DatastoreConfig struct {
 	Memory        MemoryConfig
 	Badger        BadgerConfig
 	MaxTxnRetries int
+	// GCInterval is the interval at which the unreachable blocks are garbage collected,
+	// a zero duration disables the scheduled collection.
+	GCInterval string
 }
 
 // BadgerConfig configures Badger's on-disk / filesystem mode.
@@ -273,6 +277,7 @@ func defaultDatastoreConfig() *DatastoreConfig {
 			Options:          &opts,
 		},
 		MaxTxnRetries: 5,
+		GCInterval:    "0s",
 	}
 }
 
@@ -282,9 +287,22 @@ func (dbcfg DatastoreConfig) validate() error {
 	default:
 		return NewErrInvalidDatastoreType(dbcfg.Store)
 	}
+	_, err := time.ParseDuration(dbcfg.GCInterval)
+	if err != nil {
+		return NewErrInvalidGCInterval(err, dbcfg.GCInterval)
+	}
 	return nil
 }
 
+// GCIntervalDuration gives the garbage collection interval as a time.Duration.
+func (dbcfg DatastoreConfig) GCIntervalDuration() (time.Duration, error) {
+	d, err := time.ParseDuration(dbcfg.GCInterval)
+	if err != nil {
+		return d, NewErrInvalidGCInterval(err, dbcfg.GCInterval)
+	}
+	return d, nil
+}
+
 // APIConfig configures the API endpoints.
 type APIConfig struct {
 	Address        string
@@ -356,10 +374,12 @@ type NetConfig struct {
 	Peers                string
 	PubSubEnabled        bool `mapstructure:"pubsub"`
 	RelayEnabled         bool `mapstructure:"relay"`
+	RequireSignedBlocks  bool
 	RPCAddress           string
 	RPCMaxConnectionIdle string
 	RPCTimeout           string
 	TCPAddress           string
+	TrustedSigners       string
 }
 
 func defaultNetConfig() *NetConfig {
@@ -369,10 +389,12 @@ func defaultNetConfig() *NetConfig {
 		Peers:                "",
 		PubSubEnabled:        true,
 		RelayEnabled:         false,
+		RequireSignedBlocks:  false,
 		RPCAddress:           "0.0.0.0:9161",
 		RPCMaxConnectionIdle: "5m",
 		RPCTimeout:           "10s",
 		TCPAddress:           "/ip4/0.0.0.0/tcp/9161",
+		TrustedSigners:       "",
 	}
 }
 
@@ -403,9 +425,30 @@ func (netcfg *NetConfig) validate() error {
 			}
 		}
 	}
+	_, err = netcfg.TrustedSignerIDs()
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
+// TrustedSignerIDs gives the trusted signers as peer IDs.
+func (netcfg *NetConfig) TrustedSignerIDs() ([]peer.ID, error) {
+	if len(netcfg.TrustedSigners) == 0 {
+		return nil, nil
+	}
+	signers := strings.Split(netcfg.TrustedSigners, ",")
+	ids := make([]peer.ID, len(signers))
+	for i, signer := range signers {
+		id, err := peer.Decode(signer)
+		if err != nil {
+			return nil, NewErrInvalidTrustedSigners(err, netcfg.TrustedSigners)
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
 // RPCTimeoutDuration gives the RPC timeout as a time.Duration.
 func (netcfg *NetConfig) RPCTimeoutDuration() (time.Duration, error) {
 	d, err := time.ParseDuration(netcfg.RPCTimeout)
@@ -438,6 +481,11 @@ func (cfg *Config) NodeConfig() node.NodeOpt {
 		}
 		opt.EnableRelay = cfg.Net.RelayEnabled
 		opt.EnablePubSub = cfg.Net.PubSubEnabled
+		opt.RequireSignedBlocks = cfg.Net.RequireSignedBlocks
+		opt.TrustedSigners, err = cfg.Net.TrustedSignerIDs()
+		if err != nil {
+			return err
+		}
 		opt.DataPath = cfg.Datastore.Badger.Path
 		opt.ConnManager, err = node.NewConnManager(100, 400, time.Second*20)
 		if err != nil {
@@ -17,6 +17,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/libp2p/go-libp2p/core/peer"
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/stretchr/testify/assert"
 
@@ -26,6 +27,7 @@ import (
 var envVarsDifferent = map[string]string{
 	"DEFRA_DATASTORE_STORE":       "memory",
 	"DEFRA_DATASTORE_BADGER_PATH": "defra_data",
+	"DEFRA_DATASTORE_GCINTERVAL":  "24h",
 	"DEFRA_API_ADDRESS":           "localhost:9999",
 	"DEFRA_NET_P2PDISABLED":       "true",
 	"DEFRA_NET_P2PADDRESS":        "/ip4/0.0.0.0/tcp/9876",
@@ -179,6 +181,7 @@ func TestEnvVariablesAllConsidered(t *testing.T) {
 	assert.Equal(t, "localhost:9999", cfg.API.Address)
 	assert.Equal(t, filepath.Join(cfg.Rootdir, "defra_data"), cfg.Datastore.Badger.Path)
 	assert.Equal(t, "memory", cfg.Datastore.Store)
+	assert.Equal(t, "24h", cfg.Datastore.GCInterval)
 	assert.Equal(t, true, cfg.Net.P2PDisabled)
 	assert.Equal(t, "/ip4/0.0.0.0/tcp/9876", cfg.Net.P2PAddress)
 	assert.Equal(t, "localhost:7777", cfg.Net.RPCAddress)
@@ -232,6 +235,8 @@ func TestNodeConfig(t *testing.T) {
 	cfg.Net.RPCMaxConnectionIdle = "111s"
 	cfg.Net.RelayEnabled = true
 	cfg.Net.PubSubEnabled = true
+	cfg.Net.RequireSignedBlocks = true
+	cfg.Net.TrustedSigners = "QmYyQSo1c1Ym7orWxLYvCrM2EmxFTANf8wXmmE7DWjhx5N"
 	cfg.Datastore.Badger.Path = "/tmp/defra_cli/badger"
 
 	err := cfg.validate()
@@ -244,6 +249,7 @@ func TestNodeConfig(t *testing.T) {
 	p2pAddr, errP2P := ma.NewMultiaddr(cfg.Net.P2PAddress)
 	tcpAddr, errTCP := ma.NewMultiaddr(cfg.Net.TCPAddress)
 	connManager, errConnManager := node.NewConnManager(100, 400, time.Second*20)
+	trustedSigner, errSigner := peer.Decode(cfg.Net.TrustedSigners)
 	expectedOptions := node.Options{
 		ListenAddrs:  []ma.Multiaddr{p2pAddr},
 		TCPAddr:      tcpAddr,
@@ -251,11 +257,15 @@ func TestNodeConfig(t *testing.T) {
 		EnablePubSub: true,
 		EnableRelay:  true,
 		ConnManager:  connManager,
+
+		RequireSignedBlocks: true,
+		TrustedSigners:      []peer.ID{trustedSigner},
 	}
 	assert.NoError(t, errOptionsMerge)
 	assert.NoError(t, errP2P)
 	assert.NoError(t, errTCP)
 	assert.NoError(t, errConnManager)
+	assert.NoError(t, errSigner)
 	for k, v := range options.ListenAddrs {
 		assert.Equal(t, expectedOptions.ListenAddrs[k], v)
 	}
@@ -263,6 +273,8 @@ func TestNodeConfig(t *testing.T) {
 	assert.Equal(t, expectedOptions.DataPath, options.DataPath)
 	assert.Equal(t, expectedOptions.EnablePubSub, options.EnablePubSub)
 	assert.Equal(t, expectedOptions.EnableRelay, options.EnableRelay)
+	assert.Equal(t, expectedOptions.RequireSignedBlocks, options.RequireSignedBlocks)
+	assert.Equal(t, expectedOptions.TrustedSigners, options.TrustedSigners)
 }
 
 func TestCreateAndLoadCustomConfig(t *testing.T) {
@@ -434,6 +446,20 @@ func TestValidationInvalidNetConfigPeers(t *testing.T) {
 	assert.ErrorIs(t, err, ErrFailedToValidateConfig)
 }
 
+func TestValidationNetConfigTrustedSigners(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Net.TrustedSigners = "QmYyQSo1c1Ym7orWxLYvCrM2EmxFTANf8wXmmE7DWjhx5N,12D3KooWB1b3qZxWJanuhtseF3DmPggHCtG36KZ9ixkqHtdKH9fh"
+	err := cfg.validate()
+	assert.NoError(t, err)
+}
+
+func TestValidationInvalidNetConfigTrustedSigners(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Net.TrustedSigners = "QmYyQSo1c1Ym7orWxLYvCrM2EmxFTANf8wXmmE7DWjhx5N,mmmmh"
+	err := cfg.validate()
+	assert.ErrorIs(t, err, ErrFailedToValidateConfig)
+}
+
 func TestValidationInvalidRPCMaxConnectionIdle(t *testing.T) {
 	cfg := DefaultConfig()
 	cfg.Net.RPCMaxConnectionIdle = "123123"
@@ -462,6 +488,23 @@ func TestValidationInvalidRPCTimeoutDuration(t *testing.T) {
 	assert.ErrorIs(t, err, ErrInvalidRPCTimeout)
 }
 
+func TestValidationGCIntervalDuration(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Datastore.GCInterval = "1h"
+	err := cfg.validate()
+	assert.NoError(t, err)
+	d, err := cfg.Datastore.GCIntervalDuration()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Hour, d)
+}
+
+func TestValidationInvalidGCInterval(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Datastore.GCInterval = "123123"
+	err := cfg.validate()
+	assert.ErrorIs(t, err, ErrInvalidGCInterval)
+}
+
 func TestValidationRPCMaxConnectionIdleDuration(t *testing.T) {
 	cfg := DefaultConfig()
 	cfg.Net.RPCMaxConnectionIdle = "1s"
@@ -30,6 +30,7 @@ const (
 	errInvalidP2PAddress           string = "invalid P2P address"
 	errInvalidRPCAddress           string = "invalid RPC address"
 	errInvalidBootstrapPeers       string = "invalid bootstrap peers"
+	errInvalidTrustedSigners       string = "invalid trusted signers"
 	errInvalidLogLevel             string = "invalid log level"
 	errInvalidDatastoreType        string = "invalid store type"
 	errInvalidLogFormat            string = "invalid log format"
@@ -50,6 +51,7 @@ const (
 	errMissingPortNumber           string = "missing port number"
 	errNoPortWithDomain            string = "cannot provide port with domain name"
 	errInvalidRootDir              string = "invalid root directory"
+	errInvalidGCInterval           string = "invalid garbage collection interval"
 )
 
 var (
@@ -66,6 +68,7 @@ var (
 	ErrInvalidP2PAddress           = errors.New(errInvalidP2PAddress)
 	ErrInvalidRPCAddress           = errors.New(errInvalidRPCAddress)
 	ErrInvalidBootstrapPeers       = errors.New(errInvalidBootstrapPeers)
+	ErrInvalidTrustedSigners       = errors.New(errInvalidTrustedSigners)
 	ErrInvalidLogLevel             = errors.New(errInvalidLogLevel)
 	ErrInvalidDatastoreType        = errors.New(errInvalidDatastoreType)
 	ErrOverrideConfigConvertFailed = errors.New(errOverrideConfigConvertFailed)
@@ -88,6 +91,7 @@ var (
 	ErrMissingPortNumber           = errors.New(errMissingPortNumber)
 	ErrNoPortWithDomain            = errors.New(errNoPortWithDomain)
 	ErrorInvalidRootDir            = errors.New(errInvalidRootDir)
+	ErrInvalidGCInterval           = errors.New(errInvalidGCInterval)
 )
 
 func NewErrFailedToWriteFile(inner error, path string) error {
@@ -130,6 +134,10 @@ func NewErrInvalidRPCMaxConnectionIdle(inner error, timeout string) error {
 	return errors.Wrap(errInvalidRPCMaxConnectionIdle, inner, errors.NewKV("timeout", timeout))
 }
 
+func NewErrInvalidGCInterval(inner error, interval string) error {
+	return errors.Wrap(errInvalidGCInterval, inner, errors.NewKV("interval", interval))
+}
+
 func NewErrInvalidP2PAddress(inner error, address string) error {
 	return errors.Wrap(errInvalidP2PAddress, inner, errors.NewKV("address", address))
 }
@@ -142,6 +150,10 @@ func NewErrInvalidBootstrapPeers(inner error, peers string) error {
 	return errors.Wrap(errInvalidBootstrapPeers, inner, errors.NewKV("peers", peers))
 }
 
+func NewErrInvalidTrustedSigners(inner error, signers string) error {
+	return errors.Wrap(errInvalidTrustedSigners, inner, errors.NewKV("signers", signers))
+}
+
 func NewErrInvalidLogLevel(level string) error {
 	return errors.New(errInvalidLogLevel, errors.NewKV("level", level))
 }
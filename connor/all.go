@@ -0,0 +1,24 @@
+package connor
+
+// all is an operator which matches if all of the items of
+// the array data match the condition.
+//
+// Empty and null arrays always match.
+func all(condition, data any) (bool, error) {
+	items, err := arrayItems(data)
+	if err != nil {
+		return false, err
+	}
+
+	for _, item := range items {
+		m, err := eq(condition, item)
+		if err != nil {
+			return false, err
+		}
+
+		if !m {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,22 @@
+package connor
+
+// anyOp is an operator which matches if any of the items of
+// the array data match the condition.
+func anyOp(condition, data any) (bool, error) {
+	items, err := arrayItems(data)
+	if err != nil {
+		return false, err
+	}
+
+	for _, item := range items {
+		m, err := eq(condition, item)
+		if err != nil {
+			return false, err
+		}
+
+		if m {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,28 @@
+package connor
+
+import (
+	"reflect"
+
+	"github.com/sourcenetwork/defradb/client"
+)
+
+// arrayItems returns the items of the given array data, which may be a
+// slice of any type.
+//
+// Null arrays have no items.
+func arrayItems(data any) ([]any, error) {
+	if data == nil {
+		return []any{}, nil
+	}
+
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, client.NewErrUnhandledType("data", data)
+	}
+
+	items := make([]any, v.Len())
+	for i := range items {
+		items[i] = v.Index(i).Interface()
+	}
+	return items, nil
+}
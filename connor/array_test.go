@@ -0,0 +1,98 @@
+package connor
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/immutable"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/core"
+)
+
+func TestArrayOperators(t *testing.T) {
+	tags := []string{"urgent", "bug"}
+
+	result, err := contains([]any{"urgent", "bug"}, tags)
+	require.NoError(t, err)
+	require.True(t, result)
+
+	result, err = contains([]any{"urgent", "feature"}, tags)
+	require.NoError(t, err)
+	require.False(t, result)
+
+	result, err = overlaps([]any{"urgent", "feature"}, tags)
+	require.NoError(t, err)
+	require.True(t, result)
+
+	result, err = anyOp("bug", tags)
+	require.NoError(t, err)
+	require.True(t, result)
+
+	result, err = all("bug", tags)
+	require.NoError(t, err)
+	require.False(t, result)
+
+	result, err = none("feature", tags)
+	require.NoError(t, err)
+	require.True(t, result)
+
+	// null items
+	scores := []immutable.Option[int64]{immutable.Some[int64](80), immutable.None[int64]()}
+	result, err = contains([]any{nil}, scores)
+	require.NoError(t, err)
+	require.True(t, result)
+
+	// null arrays have no items
+	result, err = all("bug", nil)
+	require.NoError(t, err)
+	require.True(t, result)
+
+	result, err = anyOp("bug", nil)
+	require.NoError(t, err)
+	require.False(t, result)
+
+	_, err = anyOp("bug", "bug")
+	require.Error(t, err)
+}
+
+func TestRelationOperators(t *testing.T) {
+	key := &opKey{op: "_eq"}
+	docs := []core.Doc{{Fields: core.DocFields{"Painted House"}}, {Fields: core.DocFields{"Theif Lord"}}}
+
+	result, err := eq(map[FilterKey]any{&opKey{op: "_every"}: map[FilterKey]any{key: "Painted House"}}, docs)
+	require.NoError(t, err)
+	require.False(t, result)
+
+	result, err = eq(map[FilterKey]any{&opKey{op: "_some"}: map[FilterKey]any{key: "Painted House"}}, docs)
+	require.NoError(t, err)
+	require.True(t, result)
+
+	result, err = eq(map[FilterKey]any{&opKey{op: "_none"}: map[FilterKey]any{}}, []core.Doc{})
+	require.NoError(t, err)
+	require.True(t, result)
+
+	// without relation operators any of the documents must match
+	result, err = eq(map[FilterKey]any{}, []core.Doc{})
+	require.NoError(t, err)
+	require.False(t, result)
+}
+
+// opKey is a FilterKey on the data itself with the given operator.
+type opKey struct {
+	op string
+}
+
+func (k *opKey) GetProp(data any) any {
+	if doc, ok := data.(core.Doc); ok {
+		return doc.Fields[0]
+	}
+	return data
+}
+
+func (k *opKey) GetOperatorOrDefault(defaultOp string) string {
+	return k.op
+}
+
+func (k *opKey) Equal(other FilterKey) bool {
+	return false
+}
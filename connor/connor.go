@@ -16,14 +16,24 @@ func Match(conditions map[FilterKey]any, data any) (bool, error) {
 // if you wish to override the behavior of another operator.
 func matchWith(op string, conditions, data any) (bool, error) {
 	switch op {
+	case "_all":
+		return all(conditions, data)
 	case "_and":
 		return and(conditions, data)
+	case "_any":
+		return anyOp(conditions, data)
+	case "_contains":
+		return contains(conditions, data)
 	case "_eq":
 		return eq(conditions, data)
+	case "_every":
+		return all(conditions, data)
 	case "_ge":
 		return ge(conditions, data)
 	case "_gt":
 		return gt(conditions, data)
+	case "_ilike":
+		return ilike(conditions, data)
 	case "_in":
 		return in(conditions, data)
 	case "_le":
@@ -34,12 +44,28 @@ func matchWith(op string, conditions, data any) (bool, error) {
 		return ne(conditions, data)
 	case "_nin":
 		return nin(conditions, data)
+	case "_none":
+		return none(conditions, data)
+	case "_not":
+		return not(conditions, data)
 	case "_or":
 		return or(conditions, data)
+	case "_overlaps":
+		return overlaps(conditions, data)
+	case "_some":
+		return anyOp(conditions, data)
 	case "_like":
 		return like(conditions, data)
 	case "_nlike":
 		return nlike(conditions, data)
+	case "_nilike":
+		return nilike(conditions, data)
+	case "_regex":
+		return regex(conditions, data)
+	case "_nregex":
+		return nregex(conditions, data)
+	case "_search":
+		return search(conditions, data)
 	default:
 		return false, NewErrUnknownOperator(op)
 	}
@@ -16,41 +16,19 @@ import (
 func eq(condition, data any) (bool, error) {
 	switch arr := data.(type) {
 	case []core.Doc:
-		for _, item := range arr {
-			m, err := eq(condition, item)
-			if err != nil {
-				return false, err
-			}
-
-			if m {
-				return true, nil
-			}
-		}
-		return false, nil
+		return eqDocs(condition, arr)
 
 	case immutable.Option[bool]:
-		if !arr.HasValue() {
-			return condition == nil, nil
-		}
-		data = arr.Value()
+		data = optionValue(arr)
 
 	case immutable.Option[int64]:
-		if !arr.HasValue() {
-			return condition == nil, nil
-		}
-		data = arr.Value()
+		data = optionValue(arr)
 
 	case immutable.Option[float64]:
-		if !arr.HasValue() {
-			return condition == nil, nil
-		}
-		data = arr.Value()
+		data = optionValue(arr)
 
 	case immutable.Option[string]:
-		if !arr.HasValue() {
-			return condition == nil, nil
-		}
-		data = arr.Value()
+		data = optionValue(arr)
 	}
 
 	switch cn := condition.(type) {
@@ -87,3 +65,52 @@ func eq(condition, data any) (bool, error) {
 		return reflect.DeepEqual(condition, data), nil
 	}
 }
+
+// optionValue returns the value of the given option, or nil if it has none so
+// that null values are still matched against the conditions, e.g. the items of
+// an inline array with `_any: {_eq: null}`.
+func optionValue[T any](option immutable.Option[T]) any {
+	if !option.HasValue() {
+		return nil
+	}
+	return option.Value()
+}
+
+// eqDocs performs object equality tests against the related documents of a
+// one-to-many relation, which match if any of the documents match.
+//
+// The relation operators (`_some`, `_every` and `_none`) within the conditions
+// are matched against the documents as a whole.
+func eqDocs(condition any, docs []core.Doc) (bool, error) {
+	itemCondition := condition
+	if cn, ok := condition.(map[FilterKey]any); ok {
+		itemConditions := map[FilterKey]any{}
+		for prop, cond := range cn {
+			switch op := prop.GetOperatorOrDefault(""); op {
+			case "_some", "_every", "_none":
+				m, err := matchWith(op, cond, docs)
+				if err != nil || !m {
+					return false, err
+				}
+			default:
+				itemConditions[prop] = cond
+			}
+		}
+		if len(itemConditions) == 0 && len(cn) > 0 {
+			return true, nil
+		}
+		itemCondition = itemConditions
+	}
+
+	for _, item := range docs {
+		m, err := eq(itemCondition, item)
+		if err != nil {
+			return false, err
+		}
+
+		if m {
+			return true, nil
+		}
+	}
+	return false, nil
+}
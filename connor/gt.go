@@ -25,6 +25,9 @@ func gt(condition, data any) (bool, error) {
 				return false, err
 			}
 			return dt.After(c), nil
+		case nil:
+			// like for numbers, nil is not comparable to a time
+			return false, nil
 		default:
 			return false, client.NewErrUnhandledType("data", d)
 		}
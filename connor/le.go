@@ -26,6 +26,9 @@ func le(condition, data any) (bool, error) {
 				return false, err
 			}
 			return dt.Before(c) || dt.Equal(c), nil
+		case nil:
+			// like for numbers, nil is not comparable to a time
+			return false, nil
 		default:
 			return false, client.NewErrUnhandledType("data", d)
 		}
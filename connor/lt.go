@@ -26,6 +26,9 @@ func lt(condition, data any) (bool, error) {
 				return false, err
 			}
 			return dt.Before(c), nil
+		case nil:
+			// like for numbers, nil is not comparable to a time
+			return false, nil
 		default:
 			return false, client.NewErrUnhandledType("data", d)
 		}
@@ -0,0 +1,15 @@
+package connor
+
+// none is an operator which matches if none of the items of
+// the array data match the condition.
+//
+// Empty and null arrays always match.
+func none(condition, data any) (bool, error) {
+	m, err := anyOp(condition, data)
+
+	if err != nil {
+		return false, err
+	}
+
+	return !m, nil
+}
@@ -0,0 +1,16 @@
+package connor
+
+// not is an operator which inverts the result of the conditions
+// within it, matching if they do not all match.
+//
+// Fields that are null do not match the conditions within it, the
+// not operator matches them.
+func not(conditions, data any) (bool, error) {
+	m, err := eq(conditions, data)
+
+	if err != nil {
+		return false, err
+	}
+
+	return !m, nil
+}
@@ -0,0 +1,24 @@
+package connor
+
+import "github.com/sourcenetwork/defradb/client"
+
+// overlaps is an operator which matches if the array data
+// contains any of the values of the condition's array.
+func overlaps(conditions, data any) (bool, error) {
+	switch cn := conditions.(type) {
+	case []any:
+		for _, ce := range cn {
+			m, err := anyOp(ce, data)
+			if err != nil {
+				return false, err
+			}
+
+			if m {
+				return true, nil
+			}
+		}
+		return false, nil
+	default:
+		return false, client.NewErrUnhandledType("condition", cn)
+	}
+}
@@ -0,0 +1,53 @@
+package connor
+
+import (
+	"regexp"
+
+	"github.com/sourcenetwork/immutable"
+
+	"github.com/sourcenetwork/defradb/client"
+)
+
+// regex is an operator which tests whether the data matches
+// the RE2 regular expression of the condition.
+//
+// The condition may be given precompiled, so that it is not
+// compiled again for every document.
+func regex(condition, data any) (bool, error) {
+	switch arr := data.(type) {
+	case immutable.Option[string]:
+		if !arr.HasValue() {
+			return condition == nil, nil
+		}
+		data = arr.Value()
+	}
+
+	var re *regexp.Regexp
+	switch cn := condition.(type) {
+	case *regexp.Regexp:
+		re = cn
+	case string:
+		var err error
+		re, err = CompileRegex(cn)
+		if err != nil {
+			return false, err
+		}
+	default:
+		return false, client.NewErrUnhandledType("condition", cn)
+	}
+
+	if d, ok := data.(string); ok {
+		return re.MatchString(d), nil
+	}
+	return false, nil
+}
+
+// CompileRegex compiles the given RE2 regular expression so that
+// it may be given as the condition of the regex operators.
+func CompileRegex(pattern string) (*regexp.Regexp, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, NewErrInvalidRegex(pattern, err)
+	}
+	return re, nil
+}
@@ -0,0 +1,62 @@
+package connor
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/immutable"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegex(t *testing.T) {
+	const testString = "Source is the glue of web3"
+
+	// match
+	result, err := regex("^Source.*web[0-9]$", testString)
+	require.NoError(t, err)
+	require.True(t, result)
+
+	// mismatch
+	result, err = regex("^source", testString)
+	require.NoError(t, err)
+	require.False(t, result)
+
+	// precompiled match
+	re, err := CompileRegex("glue")
+	require.NoError(t, err)
+	result, err = regex(re, testString)
+	require.NoError(t, err)
+	require.True(t, result)
+
+	// null data
+	result, err = regex("glue", immutable.None[string]())
+	require.NoError(t, err)
+	require.False(t, result)
+
+	// invalid pattern
+	_, err = regex("(glue", testString)
+	require.ErrorIs(t, err, ErrInvalidRegex)
+}
+
+func TestILike(t *testing.T) {
+	const testString = "Source is the glue of web3"
+
+	// exact match
+	result, err := ilike("SOURCE IS THE GLUE OF WEB3", testString)
+	require.NoError(t, err)
+	require.True(t, result)
+
+	// match prefix
+	result, err = ilike("source%", testString)
+	require.NoError(t, err)
+	require.True(t, result)
+
+	// match contains
+	result, err = ilike("%GLUE%", testString)
+	require.NoError(t, err)
+	require.True(t, result)
+
+	// mismatch
+	result, err = ilike("%paste%", testString)
+	require.NoError(t, err)
+	require.False(t, result)
+}
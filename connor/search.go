@@ -0,0 +1,41 @@
+package connor
+
+import (
+	"github.com/sourcenetwork/immutable"
+
+	"github.com/sourcenetwork/defradb/client"
+)
+
+// SearchCondition is the condition of the search operator.
+//
+// The text is matched against the data with the given function, so that
+// the filter does not depend on how the text is tokenized and stemmed.
+type SearchCondition struct {
+	// Text is the text searched for.
+	Text string
+
+	// Match returns true if the given data contains any of the terms of the text.
+	Match func(data string, text string) bool
+}
+
+// search is an operator which performs full-text matching, passing
+// if the data contains any of the terms of the condition.
+func search(condition, data any) (bool, error) {
+	switch arr := data.(type) {
+	case immutable.Option[string]:
+		if !arr.HasValue() {
+			return false, nil
+		}
+		data = arr.Value()
+	}
+
+	switch cn := condition.(type) {
+	case *SearchCondition:
+		if d, ok := data.(string); ok {
+			return cn.Match(d, cn.Text), nil
+		}
+		return false, nil
+	default:
+		return false, client.NewErrUnhandledType("condition", cn)
+	}
+}
@@ -0,0 +1,46 @@
+package connor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sourcenetwork/immutable"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearch(t *testing.T) {
+	const testString = "Source is the glue of web3"
+
+	condition := &SearchCondition{
+		Text: "glue",
+		Match: func(data string, text string) bool {
+			return strings.Contains(data, text)
+		},
+	}
+
+	// match with the given function
+	result, err := search(condition, testString)
+	require.NoError(t, err)
+	require.True(t, result)
+
+	// no match with the given function
+	condition.Text = "paste"
+	result, err = search(condition, testString)
+	require.NoError(t, err)
+	require.False(t, result)
+
+	// match optional data
+	condition.Text = "glue"
+	result, err = search(condition, immutable.Some(testString))
+	require.NoError(t, err)
+	require.True(t, result)
+
+	// no match of missing optional data
+	result, err = search(condition, immutable.None[string]())
+	require.NoError(t, err)
+	require.False(t, result)
+
+	// unprepared condition error
+	_, err = search("glue", testString)
+	require.Error(t, err)
+}
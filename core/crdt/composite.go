@@ -49,6 +49,27 @@ type CompositeDAGDelta struct {
 	Status client.DocumentStatus
 
 	FieldName string
+
+	// CommitTime is the unix time, in nanoseconds, at which the commit was created.
+	//
+	// It is zero if the time of the commit has not been recorded.
+	CommitTime int64
+
+	// Author is the identity of the user that made the commit.
+	//
+	// It is empty if the commit was made without an identity.
+	Author string
+
+	// Signer is the marshalled public key of the node that signed the block, and Signature
+	// the signature of the block. Both are empty if the block has not been signed.
+	Signer    []byte
+	Signature []byte
+
+	// Compacted holds the Cids of the blocks folded into this block by a history
+	// compaction that are still linked to by the retained blocks of the document.
+	//
+	// It is only set on snapshot blocks.
+	Compacted [][]byte
 }
 
 // GetPriority gets the current priority for this delta.
@@ -73,13 +94,41 @@ func (delta *CompositeDAGDelta) Marshal() ([]byte, error) {
 		DocKey          []byte
 		Status          uint8
 		FieldName       string
-	}{delta.SchemaVersionID, delta.Priority, delta.Data, delta.DocKey, delta.Status.UInt8(), delta.FieldName})
+		CommitTime      int64    `codec:",omitempty"`
+		Author          string   `codec:",omitempty"`
+		Signer          []byte   `codec:",omitempty"`
+		Signature       []byte   `codec:",omitempty"`
+		Compacted       [][]byte `codec:",omitempty"`
+	}{
+		delta.SchemaVersionID,
+		delta.Priority,
+		delta.Data,
+		delta.DocKey,
+		delta.Status.UInt8(),
+		delta.FieldName,
+		delta.CommitTime,
+		delta.Author,
+		delta.Signer,
+		delta.Signature,
+		delta.Compacted,
+	})
 	if err != nil {
 		return nil, err
 	}
 	return buf.Bytes(), nil
 }
 
+// GetSignature returns the signer and the signature of the block of this delta.
+func (delta *CompositeDAGDelta) GetSignature() ([]byte, []byte) {
+	return delta.Signer, delta.Signature
+}
+
+// SetSignature sets the signer and the signature of the block of this delta.
+func (delta *CompositeDAGDelta) SetSignature(signer []byte, signature []byte) {
+	delta.Signer = signer
+	delta.Signature = signature
+}
+
 // Value returns the value of this delta.
 func (delta *CompositeDAGDelta) Value() any {
 	return delta.Data
@@ -40,6 +40,16 @@ type LWWRegDelta struct {
 	Data            []byte
 	DocKey          []byte
 	FieldName       string
+
+	// CommitTime is the unix time, in nanoseconds, at which the commit was created.
+	//
+	// It is zero if the time of the commit has not been recorded.
+	CommitTime int64
+
+	// Signer is the marshalled public key of the node that signed the block, and Signature
+	// the signature of the block. Both are empty if the block has not been signed.
+	Signer    []byte
+	Signature []byte
 }
 
 // GetPriority gets the current priority for this delta.
@@ -64,13 +74,36 @@ func (delta *LWWRegDelta) Marshal() ([]byte, error) {
 		Data            []byte
 		DocKey          []byte
 		FieldName       string
-	}{delta.SchemaVersionID, delta.Priority, delta.Data, delta.DocKey, delta.FieldName})
+		CommitTime      int64  `codec:",omitempty"`
+		Signer          []byte `codec:",omitempty"`
+		Signature       []byte `codec:",omitempty"`
+	}{
+		delta.SchemaVersionID,
+		delta.Priority,
+		delta.Data,
+		delta.DocKey,
+		delta.FieldName,
+		delta.CommitTime,
+		delta.Signer,
+		delta.Signature,
+	})
 	if err != nil {
 		return nil, err
 	}
 	return buf.Bytes(), nil
 }
 
+// GetSignature returns the signer and the signature of the block of this delta.
+func (delta *LWWRegDelta) GetSignature() ([]byte, []byte) {
+	return delta.Signer, delta.Signature
+}
+
+// SetSignature sets the signer and the signature of the block of this delta.
+func (delta *LWWRegDelta) SetSignature(signer []byte, signature []byte) {
+	delta.Signer = signer
+	delta.Signature = signature
+}
+
 func (delta *LWWRegDelta) Value() any {
 	return delta.Data
 }
@@ -29,6 +29,16 @@ type CompositeDelta interface {
 	Links() []DAGLink
 }
 
+// SignedDelta represents a delta-state update that can carry the signature of the
+// block it is stored in.
+type SignedDelta interface {
+	Delta
+	// GetSignature returns the marshalled public key of the signer of the block, and
+	// the signature of the block.
+	GetSignature() (signer []byte, signature []byte)
+	SetSignature(signer []byte, signature []byte)
+}
+
 type NetDelta interface {
 	Delta
 	GetSchemaID() string
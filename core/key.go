@@ -48,6 +48,11 @@ const (
 	PRIMARY_KEY               = "/pk"
 	REPLICATOR                = "/replicator/id"
 	P2P_COLLECTION            = "/p2p/collection"
+	COMPACTED_BLOCK           = "/compacted"
+	DOC_SNAPSHOT              = "/snapshot"
+	UNREACHABLE_BLOCK         = "/unreachable"
+	PURGED_DOC                = "/purged"
+	FULLTEXT_INDEX            = "/fulltext"
 )
 
 // Key is an interface that represents a key in the database.
@@ -124,6 +129,61 @@ type ReplicatorKey struct {
 
 var _ Key = (*ReplicatorKey)(nil)
 
+// CompactedBlockKey points to the snapshot block that replaced the
+// block of the given Cid when its history was compacted.
+type CompactedBlockKey struct {
+	Cid cid.Cid
+}
+
+var _ Key = (*CompactedBlockKey)(nil)
+
+// UnreachableBlockKey points to the time at which the block of the given Cid has first
+// been found unreachable by the garbage collection.
+type UnreachableBlockKey struct {
+	Cid cid.Cid
+}
+
+var _ Key = (*UnreachableBlockKey)(nil)
+
+// DocSnapshotKey points to the latest snapshot block of a document whose history
+// has been compacted.
+type DocSnapshotKey struct {
+	DocKey string
+}
+
+var _ Key = (*DocSnapshotKey)(nil)
+
+// PurgedDocKey is the tombstone of a document that has been purged.
+type PurgedDocKey struct {
+	DocKey string
+}
+
+var _ Key = (*PurgedDocKey)(nil)
+
+// FullTextTokenKey points to the number of times a token occurs in the value of a
+// full-text indexed field of a document.
+//
+// Keys of the same token are adjacent, so the documents containing a token are found
+// with a prefix scan.
+type FullTextTokenKey struct {
+	CollectionID string
+	FieldID      string
+	Token        string
+	DocKey       string
+}
+
+var _ Key = (*FullTextTokenKey)(nil)
+
+// FullTextDocumentKey points to the tokens of the value of a full-text indexed field
+// of a document, as they were last indexed.
+type FullTextDocumentKey struct {
+	CollectionID string
+	FieldID      string
+	DocKey       string
+}
+
+var _ Key = (*FullTextDocumentKey)(nil)
+
 // Creates a new DataStoreKey from a string as best as it can,
 // splitting the input using '/' as a field deliminator.  It assumes
 // that the input string is in the following format:
@@ -472,6 +532,147 @@ func (k ReplicatorKey) ToDS() ds.Key {
 	return ds.NewKey(k.ToString())
 }
 
+func NewCompactedBlockKey(c cid.Cid) CompactedBlockKey {
+	return CompactedBlockKey{Cid: c}
+}
+
+func (k CompactedBlockKey) ToString() string {
+	result := COMPACTED_BLOCK
+
+	if k.Cid.Defined() {
+		result = result + "/" + k.Cid.String()
+	}
+
+	return result
+}
+
+func (k CompactedBlockKey) Bytes() []byte {
+	return []byte(k.ToString())
+}
+
+func (k CompactedBlockKey) ToDS() ds.Key {
+	return ds.NewKey(k.ToString())
+}
+
+func NewUnreachableBlockKey(c cid.Cid) UnreachableBlockKey {
+	return UnreachableBlockKey{Cid: c}
+}
+
+func (k UnreachableBlockKey) ToString() string {
+	result := UNREACHABLE_BLOCK
+
+	if k.Cid.Defined() {
+		result = result + "/" + k.Cid.String()
+	}
+
+	return result
+}
+
+func (k UnreachableBlockKey) Bytes() []byte {
+	return []byte(k.ToString())
+}
+
+func (k UnreachableBlockKey) ToDS() ds.Key {
+	return ds.NewKey(k.ToString())
+}
+
+func NewDocSnapshotKey(docKey string) DocSnapshotKey {
+	return DocSnapshotKey{DocKey: docKey}
+}
+
+func (k DocSnapshotKey) ToString() string {
+	result := DOC_SNAPSHOT
+
+	if k.DocKey != "" {
+		result = result + "/" + k.DocKey
+	}
+
+	return result
+}
+
+func (k DocSnapshotKey) Bytes() []byte {
+	return []byte(k.ToString())
+}
+
+func (k DocSnapshotKey) ToDS() ds.Key {
+	return ds.NewKey(k.ToString())
+}
+
+func NewPurgedDocKey(docKey string) PurgedDocKey {
+	return PurgedDocKey{DocKey: docKey}
+}
+
+func (k PurgedDocKey) ToString() string {
+	result := PURGED_DOC
+
+	if k.DocKey != "" {
+		result = result + "/" + k.DocKey
+	}
+
+	return result
+}
+
+func (k PurgedDocKey) Bytes() []byte {
+	return []byte(k.ToString())
+}
+
+func (k PurgedDocKey) ToDS() ds.Key {
+	return ds.NewKey(k.ToString())
+}
+
+func (k FullTextTokenKey) ToString() string {
+	result := FULLTEXT_INDEX
+
+	if k.CollectionID != "" {
+		result = result + "/" + k.CollectionID
+	}
+	if k.FieldID != "" {
+		result = result + "/" + k.FieldID
+	}
+	result = result + "/t"
+	if k.Token != "" {
+		result = result + "/" + k.Token
+	}
+	if k.DocKey != "" {
+		result = result + "/" + k.DocKey
+	}
+
+	return result
+}
+
+func (k FullTextTokenKey) Bytes() []byte {
+	return []byte(k.ToString())
+}
+
+func (k FullTextTokenKey) ToDS() ds.Key {
+	return ds.NewKey(k.ToString())
+}
+
+func (k FullTextDocumentKey) ToString() string {
+	result := FULLTEXT_INDEX
+
+	if k.CollectionID != "" {
+		result = result + "/" + k.CollectionID
+	}
+	if k.FieldID != "" {
+		result = result + "/" + k.FieldID
+	}
+	result = result + "/d"
+	if k.DocKey != "" {
+		result = result + "/" + k.DocKey
+	}
+
+	return result
+}
+
+func (k FullTextDocumentKey) Bytes() []byte {
+	return []byte(k.ToString())
+}
+
+func (k FullTextDocumentKey) ToDS() ds.Key {
+	return ds.NewKey(k.ToString())
+}
+
 func (k HeadStoreKey) ToString() string {
 	var result string
 
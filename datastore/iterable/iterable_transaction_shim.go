@@ -12,6 +12,7 @@ package iterable
 
 import (
 	"context"
+	"strings"
 
 	ds "github.com/ipfs/go-datastore"
 	dsq "github.com/ipfs/go-datastore/query"
@@ -76,6 +77,12 @@ func (shim *iteratorShim) IteratePrefix(
 			}
 			lastSharedIndex += 1
 		}
+		// Query prefixes only match whole key segments, so the shared prefix must
+		// be trimmed back to the end of the last segment the keys share.
+		lastSharedIndex = strings.LastIndex(string(startBytes[:lastSharedIndex]), "/")
+		if lastSharedIndex < 0 {
+			lastSharedIndex = 0
+		}
 		query.Prefix = string(startBytes[:lastSharedIndex])
 		query.Filters = append(query.Filters, betweenFilter{
 			start: startPrefix.String(),
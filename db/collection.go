@@ -30,9 +30,11 @@ import (
 	"github.com/sourcenetwork/defradb/core"
 	"github.com/sourcenetwork/defradb/datastore"
 	"github.com/sourcenetwork/defradb/db/base"
+	"github.com/sourcenetwork/defradb/db/fulltext"
 	"github.com/sourcenetwork/defradb/errors"
 	"github.com/sourcenetwork/defradb/events"
 	"github.com/sourcenetwork/defradb/logging"
+	"github.com/sourcenetwork/defradb/merkle/clock"
 	"github.com/sourcenetwork/defradb/merkle/crdt"
 )
 
@@ -343,6 +345,14 @@ func (db *db) validateUpdateCollection(
 			return false, NewErrDuplicateField(proposedField.Name)
 		}
 
+		if fieldAlreadyExists && proposedField.Typ != client.NONE_CRDT && proposedField.Typ != existingField.Typ {
+			return false, NewErrCannotMutateFieldCRDTType(
+				proposedField.Name,
+				existingField.Typ,
+				proposedField.Typ,
+			)
+		}
+
 		if fieldAlreadyExists && proposedField != existingField {
 			return false, NewErrCannotMutateField(proposedField.ID, proposedField.Name)
 		}
@@ -352,10 +362,22 @@ func (db *db) validateUpdateCollection(
 			return false, NewErrCannotMoveField(proposedField.Name, proposedIndex, existingIndex)
 		}
 
-		if proposedField.Typ != client.NONE_CRDT && proposedField.Typ != client.LWW_REGISTER {
+		if !proposedField.Typ.IsSupportedFieldCType() {
 			return false, NewErrInvalidCRDTType(proposedField.Name, proposedField.Typ)
 		}
 
+		if !fieldAlreadyExists && !proposedField.Typ.IsCompatibleWith(proposedField.Kind) {
+			return false, NewErrCRDTKindMismatch(proposedField.Name, proposedField.Typ, proposedField.Kind)
+		}
+
+		if !proposedField.Index.IsSupported() {
+			return false, NewErrInvalidIndexType(proposedField.Name, proposedField.Index)
+		}
+
+		if !fieldAlreadyExists && !proposedField.Index.IsCompatibleWith(proposedField.Kind) {
+			return false, NewErrIndexKindMismatch(proposedField.Name, proposedField.Index, proposedField.Kind)
+		}
+
 		newFieldNames[proposedField.Name] = struct{}{}
 		newFieldIds[proposedField.ID] = struct{}{}
 	}
@@ -807,6 +829,17 @@ func (c *collection) save(
 			if err != nil {
 				return cid.Undef, err
 			}
+
+			if fieldDescription.Index == client.FULLTEXT_INDEX {
+				text := immutable.None[string]()
+				if value, isString := val.Value().(string); isString && !val.IsDelete() {
+					text = immutable.Some(value)
+				}
+				err = fulltext.Update(ctx, txn.Datastore(), c.desc, fieldDescription, primaryKey.DocKey, text)
+				if err != nil {
+					return cid.Undef, err
+				}
+			}
 			if val.IsDelete() {
 				docProperties[k] = nil
 			} else {
@@ -965,6 +998,10 @@ func (c *collection) saveValueToMerkleCRDT(
 	key core.DataStoreKey,
 	ctype client.CType,
 	args ...any) (ipld.Node, uint64, error) {
+	if c.db.signingKey != nil {
+		ctx = clock.ContextWithSigner(ctx, c.db.signingKey)
+	}
+
 	switch ctype {
 	case client.LWW_REGISTER:
 		field, _ := c.Description().GetFieldByID(key.FieldId)
@@ -991,7 +1028,7 @@ func (c *collection) saveValueToMerkleCRDT(
 			return nil, 0, ErrUnknownCRDTArgument
 		}
 		lwwreg := merkleCRDT.(*crdt.MerkleLWWRegister)
-		return lwwreg.Set(ctx, bytes)
+		return lwwreg.Set(ctx, bytes, c.db.commitClock())
 	case client.COMPOSITE:
 		key = key.WithFieldId(core.COMPOSITE_NAMESPACE)
 		merkleCRDT, err := c.db.crdtFactory.InstanceWithStores(
@@ -1019,16 +1056,17 @@ func (c *collection) saveValueToMerkleCRDT(
 			return nil, 0, ErrUnknownCRDTArgument
 		}
 		comp := merkleCRDT.(*crdt.MerkleCompositeDAG)
+		author := client.GetIdentity(ctx).Value()
 		if len(args) > 2 {
 			status, ok := args[2].(client.DocumentStatus)
 			if !ok {
 				return nil, 0, ErrUnknownCRDTArgument
 			}
 			if status.IsDeleted() {
-				return comp.Delete(ctx, links)
+				return comp.Delete(ctx, links, c.db.commitClock(), author)
 			}
 		}
-		return comp.Set(ctx, bytes, links)
+		return comp.Set(ctx, bytes, links, c.db.commitClock(), author)
 	}
 	return nil, 0, ErrUnknownCRDT
 }
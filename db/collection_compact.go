@@ -0,0 +1,495 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fxamacker/cbor/v2"
+	dag "github.com/ipfs/boxo/ipld/merkledag"
+	"github.com/ipfs/go-cid"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/core"
+	corecrdt "github.com/sourcenetwork/defradb/core/crdt"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/merkle/clock"
+)
+
+// compactedBlock is a composite block that is folded into the snapshot of a compaction.
+type compactedBlock struct {
+	cid   cid.Cid
+	node  *dag.ProtoNode
+	delta *corecrdt.CompositeDAGDelta
+}
+
+// boundaryBlock is the latest field block that is folded into the snapshot of a compaction.
+type boundaryBlock struct {
+	cid   cid.Cid
+	delta *corecrdt.LWWRegDelta
+}
+
+// Compact folds the history of documents in this collection older than the height
+// given by the options into a single snapshot block per document.
+//
+// The snapshot is a composite block that holds the full state of the document at its
+// height, linking to the latest field block of each field at that height. All older
+// blocks are removed from the blockstore and a record of the snapshot they have been
+// folded into is kept so that they remain known to the VersionedFetcher, the commit
+// queries and P2P sync.
+func (c *collection) Compact(
+	ctx context.Context,
+	opts client.CompactOptions,
+) (*client.CompactResult, error) {
+	txn, err := c.getTxn(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	defer c.discardImplicitTxn(ctx, txn)
+
+	res, err := c.compact(ctx, txn, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, c.commitImplicitTxn(ctx, txn)
+}
+
+func (c *collection) compact(
+	ctx context.Context,
+	txn datastore.Txn,
+	opts client.CompactOptions,
+) (*client.CompactResult, error) {
+	if opts.Height == 0 && opts.Depth == 0 {
+		return nil, ErrCompactionHeightMissing
+	}
+
+	dockeys := make([]string, 0, len(opts.DocKeys))
+	for _, key := range opts.DocKeys {
+		dockeys = append(dockeys, key.String())
+	}
+	if len(dockeys) == 0 {
+		var err error
+		dockeys, err = c.getAllDocKeysWithTxn(ctx, txn)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	results := &client.CompactResult{
+		DocKeys: make([]string, 0),
+	}
+	for _, dockey := range dockeys {
+		removed, err := c.compactDocument(ctx, txn, dockey, opts)
+		if err != nil {
+			return nil, NewErrFailedToCompactDocument(dockey, err)
+		}
+		if removed == 0 {
+			continue
+		}
+
+		results.Count++
+		results.DocKeys = append(results.DocKeys, dockey)
+		results.RemovedBlocks += removed
+	}
+
+	return results, nil
+}
+
+// getAllDocKeysWithTxn returns the keys of all the documents in the collection,
+// including deleted ones, using the given transaction.
+func (c *collection) getAllDocKeysWithTxn(ctx context.Context, txn datastore.Txn) ([]string, error) {
+	prefix := core.PrimaryDataStoreKey{
+		CollectionId: fmt.Sprint(c.colID),
+	}
+	q, err := txn.Datastore().Query(ctx, query.Query{
+		Prefix:   prefix.ToString(),
+		KeysOnly: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dockeys := []string{}
+	for res := range q.Next() {
+		if res.Error != nil {
+			_ = q.Close()
+			return nil, res.Error
+		}
+		dockeys = append(dockeys, ds.NewKey(res.Key).BaseNamespace())
+	}
+
+	return dockeys, q.Close()
+}
+
+// compactDocument folds the history of the given document and returns the number
+// of blocks that have been removed from the blockstore.
+func (c *collection) compactDocument(
+	ctx context.Context,
+	txn datastore.Txn,
+	dockey string,
+	opts client.CompactOptions,
+) (int64, error) {
+	headset := clock.NewHeadSet(
+		txn.Headstore(),
+		core.HeadStoreKey{DocKey: dockey, FieldId: core.COMPOSITE_NAMESPACE},
+	)
+	heads, maxHeight, err := headset.List(ctx)
+	if err != nil {
+		return 0, NewErrFailedToGetHeads(err)
+	}
+	if len(heads) == 0 {
+		return 0, client.ErrDocumentNotFound
+	}
+
+	height := opts.Height
+	if height == 0 {
+		if opts.Depth >= maxHeight {
+			return 0, nil
+		}
+		height = maxHeight - opts.Depth
+	}
+
+	folded, err := c.getFoldedBlocks(ctx, txn, heads, height)
+	if err != nil {
+		return 0, err
+	}
+	// A single block without parents is either the first block of the document,
+	// or a snapshot from a previous compaction. Either way there is nothing to fold.
+	if len(folded) < 2 {
+		return 0, nil
+	}
+
+	boundaries, fieldCids, err := c.getBoundaryFieldBlocks(ctx, txn, dockey, folded)
+	if err != nil {
+		return 0, err
+	}
+
+	toRemove := fieldCids
+	for _, block := range folded {
+		toRemove = append(toRemove, block.cid)
+	}
+
+	// the retained blocks still link to some of the removed blocks, peers that never had the
+	// history of the document learn from the snapshot that these blocks are folded into it.
+	roots := append([]cid.Cid{}, heads...)
+	for _, block := range boundaries {
+		roots = append(roots, block.cid)
+	}
+	frontier, err := getCompactedFrontier(ctx, txn, roots, toRemove)
+	if err != nil {
+		return 0, err
+	}
+
+	snapshot, err := c.putSnapshot(ctx, txn, folded, boundaries, frontier)
+	if err != nil {
+		return 0, err
+	}
+
+	removed := int64(0)
+	for _, blockCid := range toRemove {
+		if blockCid.Equals(snapshot) {
+			continue
+		}
+		if err := txn.DAGstore().DeleteBlock(ctx, blockCid); err != nil {
+			return 0, err
+		}
+		if err := clock.MarkCompacted(ctx, txn.Systemstore(), blockCid, snapshot); err != nil {
+			return 0, err
+		}
+		removed++
+	}
+
+	// the snapshot is sent along the updates of the document to the peers that sync it
+	if err := clock.SetDocSnapshot(ctx, txn.Systemstore(), dockey, snapshot); err != nil {
+		return 0, err
+	}
+
+	return removed, nil
+}
+
+// getFoldedBlocks walks the composite DAG from the given heads and returns the blocks
+// at or below the given height, ordered by priority. Heads are never folded.
+func (c *collection) getFoldedBlocks(
+	ctx context.Context,
+	txn datastore.Txn,
+	heads []cid.Cid,
+	height uint64,
+) ([]compactedBlock, error) {
+	isHead := make(map[cid.Cid]struct{}, len(heads))
+	for _, head := range heads {
+		isHead[head] = struct{}{}
+	}
+
+	folded := []compactedBlock{}
+	visited := map[cid.Cid]struct{}{}
+	queue := append([]cid.Cid{}, heads...)
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if _, ok := visited[current]; ok {
+			continue
+		}
+		visited[current] = struct{}{}
+
+		hasBlock, err := txn.DAGstore().Has(ctx, current)
+		if err != nil {
+			return nil, err
+		}
+		if !hasBlock {
+			snapshot, isCompacted, err := clock.GetCompactedSnapshot(ctx, txn.Systemstore(), current)
+			if err != nil {
+				return nil, err
+			}
+			if isCompacted {
+				queue = append(queue, snapshot)
+				continue
+			}
+		}
+
+		block, err := txn.DAGstore().Get(ctx, current)
+		if err != nil {
+			return nil, err
+		}
+		nd, err := dag.DecodeProtobuf(block.RawData())
+		if err != nil {
+			return nil, err
+		}
+		delta, err := corecrdt.CompositeDAG{}.DeltaDecode(nd)
+		if err != nil {
+			return nil, err
+		}
+		compositeDelta := delta.(*corecrdt.CompositeDAGDelta)
+
+		if _, ok := isHead[current]; !ok && compositeDelta.Priority <= height {
+			folded = append(folded, compactedBlock{cid: current, node: nd, delta: compositeDelta})
+		}
+
+		for _, l := range nd.Links() {
+			if l.Name == core.HEAD {
+				queue = append(queue, l.Cid)
+			}
+		}
+	}
+
+	sort.Slice(folded, func(i, j int) bool {
+		return folded[i].delta.Priority < folded[j].delta.Priority
+	})
+	return folded, nil
+}
+
+// getBoundaryFieldBlocks returns the latest field block of each field linked to by
+// the folded composite blocks, and the Cids of the other field blocks that can be
+// removed.
+func (c *collection) getBoundaryFieldBlocks(
+	ctx context.Context,
+	txn datastore.Txn,
+	dockey string,
+	folded []compactedBlock,
+) (map[string]boundaryBlock, []cid.Cid, error) {
+	candidates := map[string][]boundaryBlock{}
+	for _, block := range folded {
+		for _, l := range block.node.Links() {
+			if l.Name == core.HEAD {
+				continue
+			}
+
+			fieldBlock, err := txn.DAGstore().Get(ctx, l.Cid)
+			if err != nil {
+				return nil, nil, err
+			}
+			nd, err := dag.DecodeProtobuf(fieldBlock.RawData())
+			if err != nil {
+				return nil, nil, err
+			}
+			delta, err := corecrdt.LWWRegister{}.DeltaDecode(nd)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			candidates[l.Name] = append(
+				candidates[l.Name],
+				boundaryBlock{cid: l.Cid, delta: delta.(*corecrdt.LWWRegDelta)},
+			)
+		}
+	}
+
+	boundaries := map[string]boundaryBlock{}
+	removable := []cid.Cid{}
+	for name, blocks := range candidates {
+		// the same rules as the LWW register merge apply: the highest priority wins,
+		// and on a tie the highest value.
+		sort.Slice(blocks, func(i, j int) bool {
+			if blocks[i].delta.Priority != blocks[j].delta.Priority {
+				return blocks[i].delta.Priority > blocks[j].delta.Priority
+			}
+			return bytes.Compare(blocks[i].delta.Data, blocks[j].delta.Data) > 0
+		})
+		boundaries[name] = blocks[0]
+
+		fieldID := c.Description().Schema.GetFieldKey(name)
+		if fieldID == 0 {
+			return nil, nil, client.NewErrFieldNotExist(name)
+		}
+		fieldHeadset := clock.NewHeadSet(
+			txn.Headstore(),
+			core.HeadStoreKey{DocKey: dockey, FieldId: fmt.Sprint(fieldID)},
+		)
+		for _, block := range blocks[1:] {
+			// concurrent field updates may have left more than one head
+			isHead, err := fieldHeadset.IsHead(ctx, block.cid)
+			if err != nil {
+				return nil, nil, err
+			}
+			if isHead {
+				continue
+			}
+			removable = append(removable, block.cid)
+		}
+	}
+
+	return boundaries, removable, nil
+}
+
+// getCompactedFrontier walks the DAG from the given roots and returns the Cids of the removed
+// blocks, and of the blocks folded by previous compactions, that are linked to by the blocks
+// that are retained.
+func getCompactedFrontier(
+	ctx context.Context,
+	txn datastore.Txn,
+	roots []cid.Cid,
+	toRemove []cid.Cid,
+) ([]cid.Cid, error) {
+	isRemoved := make(map[cid.Cid]struct{}, len(toRemove))
+	for _, blockCid := range toRemove {
+		isRemoved[blockCid] = struct{}{}
+	}
+
+	frontier := []cid.Cid{}
+	visited := map[cid.Cid]struct{}{}
+	queue := append([]cid.Cid{}, roots...)
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if _, ok := visited[current]; ok {
+			continue
+		}
+		visited[current] = struct{}{}
+
+		if _, ok := isRemoved[current]; ok {
+			frontier = append(frontier, current)
+			continue
+		}
+		hasBlock, err := txn.DAGstore().Has(ctx, current)
+		if err != nil {
+			return nil, err
+		}
+		if !hasBlock {
+			_, isCompacted, err := clock.GetCompactedSnapshot(ctx, txn.Systemstore(), current)
+			if err != nil {
+				return nil, err
+			}
+			if isCompacted {
+				frontier = append(frontier, current)
+			}
+			continue
+		}
+
+		block, err := txn.DAGstore().Get(ctx, current)
+		if err != nil {
+			return nil, err
+		}
+		nd, err := dag.DecodeProtobuf(block.RawData())
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range nd.Links() {
+			queue = append(queue, l.Cid)
+		}
+	}
+
+	sort.Slice(frontier, func(i, j int) bool {
+		return strings.Compare(frontier[i].String(), frontier[j].String()) < 0
+	})
+	return frontier, nil
+}
+
+// putSnapshot writes the snapshot block of the given folded blocks to the blockstore
+// and returns its Cid.
+func (c *collection) putSnapshot(
+	ctx context.Context,
+	txn datastore.Txn,
+	folded []compactedBlock,
+	boundaries map[string]boundaryBlock,
+	frontier []cid.Cid,
+) (cid.Cid, error) {
+	latest := folded[len(folded)-1].delta
+
+	docProperties := make(map[string]any, len(boundaries))
+	links := make([]core.DAGLink, 0, len(boundaries))
+	for name, block := range boundaries {
+		var value any
+		if len(block.delta.Data) > 0 {
+			if err := cbor.Unmarshal(block.delta.Data, &value); err != nil {
+				return cid.Undef, err
+			}
+		}
+		docProperties[name] = value
+		links = append(links, core.DAGLink{Name: name, Cid: block.cid})
+	}
+	// make sure the links are sorted lexicographically by CID, like any other composite block
+	sort.Slice(links, func(i, j int) bool {
+		return strings.Compare(links[i].Cid.String(), links[j].Cid.String()) < 0
+	})
+
+	em, err := cbor.CanonicalEncOptions().EncMode()
+	if err != nil {
+		return cid.Undef, err
+	}
+	data, err := em.Marshal(docProperties)
+	if err != nil {
+		return cid.Undef, err
+	}
+
+	delta := &corecrdt.CompositeDAGDelta{
+		SchemaVersionID: latest.SchemaVersionID,
+		Priority:        latest.Priority,
+		Data:            data,
+		DocKey:          latest.DocKey,
+		SubDAGs:         links,
+		Status:          latest.Status,
+		CommitTime:      latest.CommitTime,
+	}
+	for _, blockCid := range frontier {
+		delta.Compacted = append(delta.Compacted, blockCid.Bytes())
+	}
+	if c.db.signingKey != nil {
+		ctx = clock.ContextWithSigner(ctx, c.db.signingKey)
+	}
+	nd, err := clock.NewSnapshotNode(ctx, delta)
+	if err != nil {
+		return cid.Undef, err
+	}
+	if err := txn.DAGstore().Put(ctx, nd); err != nil {
+		return cid.Undef, err
+	}
+
+	return nd.Cid(), nil
+}
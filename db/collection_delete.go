@@ -18,6 +18,7 @@ import (
 	"github.com/sourcenetwork/defradb/client/request"
 	"github.com/sourcenetwork/defradb/core"
 	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/db/fulltext"
 	"github.com/sourcenetwork/defradb/events"
 	"github.com/sourcenetwork/defradb/merkle/clock"
 )
@@ -274,6 +275,11 @@ func (c *collection) applyDelete(
 		return err
 	}
 
+	err = fulltext.Remove(ctx, txn.Datastore(), c.Description(), key.DocKey)
+	if err != nil {
+		return err
+	}
+
 	if c.db.events.Updates.HasValue() {
 		txn.OnSuccess(
 			func() {
@@ -0,0 +1,205 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"sort"
+
+	dag "github.com/ipfs/boxo/ipld/merkledag"
+	"github.com/ipfs/go-cid"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/core"
+	corecrdt "github.com/sourcenetwork/defradb/core/crdt"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/db/fetcher"
+	"github.com/sourcenetwork/defradb/merkle/clock"
+)
+
+// Diff returns the field-level changes of the document with the given DocKey between
+// the two given versions.
+//
+// A version is either the CID of a composite commit of the document, or LatestVersion
+// for its current state. Only the fields that differ between the two versions are
+// returned, ordered by field name.
+func (c *collection) Diff(
+	ctx context.Context,
+	key client.DocKey,
+	from string,
+	to string,
+) ([]client.FieldDiff, error) {
+	txn, err := c.getTxn(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	defer c.discardImplicitTxn(ctx, txn)
+
+	diff, err := c.diff(ctx, txn, key, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return diff, c.commitImplicitTxn(ctx, txn)
+}
+
+func (c *collection) diff(
+	ctx context.Context,
+	txn datastore.Txn,
+	key client.DocKey,
+	from string,
+	to string,
+) ([]client.FieldDiff, error) {
+	oldValues, err := c.getVersionValues(ctx, txn, key, from)
+	if err != nil {
+		return nil, err
+	}
+	newValues, err := c.getVersionValues(ctx, txn, key, to)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := []client.FieldDiff{}
+	for name, oldValue := range oldValues {
+		newValue, ok := newValues[name]
+		if !ok {
+			diff = append(diff, client.FieldDiff{
+				FieldName: name,
+				Kind:      client.FieldRemoved,
+				OldValue:  oldValue,
+			})
+			continue
+		}
+		if !reflect.DeepEqual(oldValue, newValue) {
+			diff = append(diff, client.FieldDiff{
+				FieldName: name,
+				Kind:      client.FieldChanged,
+				OldValue:  oldValue,
+				NewValue:  newValue,
+			})
+		}
+	}
+	for name, newValue := range newValues {
+		if _, ok := oldValues[name]; !ok {
+			diff = append(diff, client.FieldDiff{
+				FieldName: name,
+				Kind:      client.FieldAdded,
+				NewValue:  newValue,
+			})
+		}
+	}
+
+	sort.Slice(diff, func(i, j int) bool {
+		return diff[i].FieldName < diff[j].FieldName
+	})
+	return diff, nil
+}
+
+// getVersionValues returns the non-nil field values of the document at the given version,
+// keyed by field name.
+func (c *collection) getVersionValues(
+	ctx context.Context,
+	txn datastore.Txn,
+	key client.DocKey,
+	version string,
+) (map[string]any, error) {
+	var doc *client.Document
+	if version == client.LatestVersion {
+		var err error
+		doc, err = c.get(ctx, txn, c.getPrimaryKeyFromDocKey(key), true)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		doc, err = c.getDocumentAtVersion(ctx, txn, key, version)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if doc == nil {
+		return nil, client.ErrDocumentNotFound
+	}
+
+	values := map[string]any{}
+	for field, value := range doc.Values() {
+		if value.Value() == nil {
+			continue
+		}
+		values[field.Name()] = value.Value()
+	}
+	return values, nil
+}
+
+// getDocumentAtVersion returns the document at the state of the given composite commit.
+func (c *collection) getDocumentAtVersion(
+	ctx context.Context,
+	txn datastore.Txn,
+	key client.DocKey,
+	version string,
+) (*client.Document, error) {
+	versionCid, err := cid.Decode(version)
+	if err != nil {
+		return nil, err
+	}
+
+	// like the VersionedFetcher, a version folded into a snapshot by a history compaction
+	// can not be reconstructed
+	hasBlock, err := txn.DAGstore().Has(ctx, versionCid)
+	if err != nil {
+		return nil, err
+	}
+	if !hasBlock {
+		snapshot, isCompacted, err := clock.GetCompactedSnapshot(ctx, txn.Systemstore(), versionCid)
+		if err != nil {
+			return nil, err
+		}
+		if isCompacted {
+			return nil, fetcher.NewErrVersionCompacted(versionCid, snapshot)
+		}
+	}
+
+	block, err := txn.DAGstore().Get(ctx, versionCid)
+	if err != nil {
+		return nil, err
+	}
+	nd, err := dag.DecodeProtobuf(block.RawData())
+	if err != nil {
+		return nil, err
+	}
+	delta, err := corecrdt.CompositeDAG{}.DeltaDecode(nd)
+	if err != nil {
+		return nil, err
+	}
+	// the VersionedFetcher would otherwise happily merge the commits of another document
+	if !bytes.Equal(delta.(*corecrdt.CompositeDAGDelta).DocKey, []byte(key.String())) {
+		return nil, NewErrVersionNotOfDocument(version, key.String())
+	}
+
+	vf := new(fetcher.VersionedFetcher)
+	if err := vf.Init(&c.desc, nil, false, true); err != nil {
+		return nil, err
+	}
+	err = vf.Start(ctx, txn, fetcher.NewVersionedSpan(core.DataStoreKey{DocKey: key.String()}, versionCid))
+	if err != nil {
+		_ = vf.Close()
+		return nil, err
+	}
+
+	doc, err := vf.FetchNextDecoded(ctx)
+	if err != nil {
+		_ = vf.Close()
+		return nil, err
+	}
+
+	return doc, vf.Close()
+}
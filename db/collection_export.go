@@ -0,0 +1,120 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"context"
+	"io"
+
+	"github.com/ipfs/boxo/ipld/car"
+	carutil "github.com/ipfs/boxo/ipld/car/util"
+	"github.com/ipfs/go-cid"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/db/base"
+	"github.com/sourcenetwork/defradb/merkle/clock"
+)
+
+// carVersion is the version of the CAR files written by an export.
+const carVersion = 1
+
+// ExportDAG writes the full Merkle DAG of the documents in this collection to the given writer
+// as a CARv1 file.
+//
+// The roots of the file are the composite heads of the exported documents, and its blocks are
+// all the composite and field blocks reachable from them. The snapshots of compacted histories
+// are exported in place of the blocks they replaced.
+func (c *collection) ExportDAG(
+	ctx context.Context,
+	w io.Writer,
+	opts client.ExportDAGOptions,
+) (*client.ExportDAGResult, error) {
+	txn, err := c.getTxn(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	defer c.discardImplicitTxn(ctx, txn)
+
+	return c.exportDAG(ctx, txn, w, opts)
+}
+
+func (c *collection) exportDAG(
+	ctx context.Context,
+	txn datastore.Txn,
+	w io.Writer,
+	opts client.ExportDAGOptions,
+) (*client.ExportDAGResult, error) {
+	dockeys := make([]string, 0, len(opts.DocKeys))
+	for _, key := range opts.DocKeys {
+		dockeys = append(dockeys, key.String())
+	}
+	if len(dockeys) == 0 {
+		var err error
+		dockeys, err = c.getAllStoredDocKeys(ctx, txn)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	results := &client.ExportDAGResult{}
+	roots := []cid.Cid{}
+	blockCids := []cid.Cid{}
+	seen := map[cid.Cid]struct{}{}
+	for _, dockey := range dockeys {
+		key := base.MakeDocKey(c.Description(), dockey)
+		headset := clock.NewHeadSet(txn.Headstore(), key.WithFieldId(core.COMPOSITE_NAMESPACE).ToHeadStoreKey())
+		heads, _, err := headset.List(ctx)
+		if err != nil {
+			return nil, NewErrFailedToGetHeads(err)
+		}
+		if len(heads) == 0 {
+			if len(opts.DocKeys) > 0 {
+				return nil, client.ErrDocumentNotFound
+			}
+			// the document only has datastore entries, there is no DAG to export
+			continue
+		}
+
+		docBlocks, err := getDAGBlockCids(ctx, txn, heads, false)
+		if err != nil {
+			return nil, err
+		}
+		for _, blockCid := range docBlocks {
+			if _, ok := seen[blockCid]; ok {
+				continue
+			}
+			seen[blockCid] = struct{}{}
+			blockCids = append(blockCids, blockCid)
+		}
+		roots = append(roots, heads...)
+		results.Count++
+	}
+
+	err := car.WriteHeader(&car.CarHeader{Roots: roots, Version: carVersion}, w)
+	if err != nil {
+		return nil, err
+	}
+	for _, blockCid := range blockCids {
+		block, err := txn.DAGstore().Get(ctx, blockCid)
+		if err != nil {
+			return nil, err
+		}
+		if err := carutil.LdWrite(w, blockCid.Bytes(), block.RawData()); err != nil {
+			return nil, err
+		}
+		results.Blocks++
+	}
+
+	return results, nil
+}
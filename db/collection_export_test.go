@@ -0,0 +1,131 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	badger "github.com/dgraph-io/badger/v3"
+	"github.com/ipfs/boxo/ipld/car"
+	dag "github.com/ipfs/boxo/ipld/merkledag"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/core"
+	corecrdt "github.com/sourcenetwork/defradb/core/crdt"
+	badgerds "github.com/sourcenetwork/defradb/datastore/badger/v3"
+	"github.com/sourcenetwork/defradb/merkle/clock"
+)
+
+func TestExportDAG(t *testing.T) {
+	ctx := context.Background()
+	db, col, doc := newTestFsckDocument(t, ctx)
+
+	var buf bytes.Buffer
+	res, err := col.ExportDAG(ctx, &buf, client.ExportDAGOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), res.Count)
+	// two composite blocks, two blocks for Age and one for Name
+	assert.Equal(t, int64(5), res.Blocks)
+
+	cr, err := car.NewCarReader(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), cr.Header.Version)
+	assert.Equal(t, getCompositeHeads(t, ctx, db, doc.Key().String()), cr.Header.Roots)
+
+	blocks := 0
+	for {
+		block, err := cr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		assert.True(t, hasBlock(t, ctx, db, block.Cid()))
+		blocks++
+	}
+	assert.Equal(t, 5, blocks)
+}
+
+func TestExportDAGWithDocKeys(t *testing.T) {
+	ctx := context.Background()
+	_, col, doc := newTestFsckDocument(t, ctx)
+
+	other, err := client.NewDocFromJSON([]byte(`{"Name": "Islam", "Age": 33}`))
+	require.NoError(t, err)
+	require.NoError(t, col.Create(ctx, other))
+
+	var buf bytes.Buffer
+	res, err := col.ExportDAG(ctx, &buf, client.ExportDAGOptions{DocKeys: []client.DocKey{other.Key()}})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), res.Count)
+	// one composite block, and one block for each field
+	assert.Equal(t, int64(3), res.Blocks)
+
+	_, err = col.Purge(ctx, doc.Key())
+	require.NoError(t, err)
+	_, err = col.ExportDAG(ctx, &buf, client.ExportDAGOptions{DocKeys: []client.DocKey{doc.Key()}})
+	require.ErrorIs(t, err, client.ErrDocumentNotFound)
+}
+
+func TestExportDAGWithSignedBlocks(t *testing.T) {
+	ctx := context.Background()
+	key, _, err := crypto.GenerateKeyPair(crypto.Ed25519, 0)
+	require.NoError(t, err)
+	opts := badgerds.Options{Options: badger.DefaultOptions("").WithInMemory(true)}
+	rootstore, err := badgerds.NewDatastore("", &opts)
+	require.NoError(t, err)
+	db, err := newDB(ctx, rootstore, WithSigningKey(key))
+	require.NoError(t, err)
+	col, err := newTestCollectionWithSchema(t, ctx, db)
+	require.NoError(t, err)
+
+	doc, err := client.NewDocFromJSON([]byte(`{"Name": "John", "Age": 21}`))
+	require.NoError(t, err)
+	require.NoError(t, col.Create(ctx, doc))
+
+	var buf bytes.Buffer
+	res, err := col.ExportDAG(ctx, &buf, client.ExportDAGOptions{})
+	require.NoError(t, err)
+	// one composite block, and one block for each field
+	assert.Equal(t, int64(3), res.Blocks)
+
+	// the signatures are part of the blocks
+	cr, err := car.NewCarReader(&buf)
+	require.NoError(t, err)
+	blocks := 0
+	for {
+		block, err := cr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		nd, err := dag.DecodeProtobuf(block.RawData())
+		require.NoError(t, err)
+		var delta core.Delta
+		if block.Cid().Equals(cr.Header.Roots[0]) {
+			delta, err = corecrdt.CompositeDAG{}.DeltaDecode(nd)
+		} else {
+			delta, err = corecrdt.LWWRegister{}.DeltaDecode(nd)
+		}
+		require.NoError(t, err)
+		signer, err := clock.VerifySignature(nd, delta)
+		require.NoError(t, err)
+		require.NotNil(t, signer)
+		assert.True(t, signer.Equals(key.GetPublic()))
+		blocks++
+	}
+	assert.Equal(t, 3, blocks)
+}
@@ -0,0 +1,495 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"sort"
+
+	dag "github.com/ipfs/boxo/ipld/merkledag"
+	"github.com/ipfs/go-cid"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/core"
+	corecrdt "github.com/sourcenetwork/defradb/core/crdt"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/db/base"
+	"github.com/sourcenetwork/defradb/db/fulltext"
+	"github.com/sourcenetwork/defradb/errors"
+	"github.com/sourcenetwork/defradb/events"
+	"github.com/sourcenetwork/defradb/merkle/clock"
+)
+
+// dagBlock is a decoded block of a document DAG.
+type dagBlock struct {
+	cid   cid.Cid
+	node  *dag.ProtoNode
+	delta core.Delta
+}
+
+// Fsck checks that the headstore, the blockstore and the datastore of this collection
+// are consistent with each other, and returns the inconsistencies found.
+//
+// For each document it checks that its heads point to blocks that exist in the blockstore,
+// that its primary key entry matches its composite heads, and that its field values and
+// priorities match the tip of their field DAG. If Repair is set in the options, the
+// inconsistent entries are removed and rebuilt by replaying the head blocks through the
+// merge path of their merkle CRDT, as done when syncing blocks from a peer. The full-text
+// indexes of the repaired documents are then refreshed from their rebuilt values.
+func (c *collection) Fsck(ctx context.Context, opts client.FsckOptions) (*client.FsckResult, error) {
+	txn, err := c.getTxn(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	defer c.discardImplicitTxn(ctx, txn)
+
+	res, err := c.fsck(ctx, txn, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, c.commitImplicitTxn(ctx, txn)
+}
+
+func (c *collection) fsck(
+	ctx context.Context,
+	txn datastore.Txn,
+	opts client.FsckOptions,
+) (*client.FsckResult, error) {
+	dockeys := make([]string, 0, len(opts.DocKeys))
+	for _, key := range opts.DocKeys {
+		dockeys = append(dockeys, key.String())
+	}
+	if len(dockeys) == 0 {
+		var err error
+		dockeys, err = c.getAllStoredDocKeys(ctx, txn)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	results := &client.FsckResult{
+		Inconsistencies: []client.Inconsistency{},
+	}
+	for _, dockey := range dockeys {
+		inconsistencies, err := c.fsckDocument(ctx, txn, dockey, opts.Repair)
+		if err != nil {
+			return nil, NewErrFailedToCheckDocument(dockey, err)
+		}
+		results.Count++
+		results.Inconsistencies = append(results.Inconsistencies, inconsistencies...)
+	}
+
+	return results, nil
+}
+
+// getAllStoredDocKeys returns the keys of all the documents of the collection that have
+// an entry in the datastore, or a composite head in the headstore.
+//
+// Unlike getAllDocKeysWithTxn it does not rely on the primary key entries, as these may
+// be missing.
+func (c *collection) getAllStoredDocKeys(ctx context.Context, txn datastore.Txn) ([]string, error) {
+	dockeys := []string{}
+	seen := map[string]struct{}{}
+
+	q, err := txn.Datastore().Query(ctx, query.Query{
+		Prefix:   base.MakeCollectionKey(c.Description()).ToString(),
+		KeysOnly: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for res := range q.Next() {
+		if res.Error != nil {
+			_ = q.Close()
+			return nil, res.Error
+		}
+		key, err := core.NewDataStoreKey(res.Key)
+		if err != nil {
+			_ = q.Close()
+			return nil, err
+		}
+		if key.CollectionID != c.Description().IDString() {
+			continue
+		}
+		if _, ok := seen[key.DocKey]; !ok {
+			seen[key.DocKey] = struct{}{}
+			dockeys = append(dockeys, key.DocKey)
+		}
+	}
+	if err := q.Close(); err != nil {
+		return nil, err
+	}
+
+	// Documents may have lost all their datastore entries. These can only be found through
+	// the schema version of their composite heads.
+	q, err = txn.Headstore().Query(ctx, query.Query{KeysOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	isOfCollection := map[string]bool{}
+	for res := range q.Next() {
+		if res.Error != nil {
+			_ = q.Close()
+			return nil, res.Error
+		}
+		key, err := core.NewHeadStoreKey(res.Key)
+		if err != nil || key.FieldId != core.COMPOSITE_NAMESPACE {
+			continue
+		}
+		if _, ok := seen[key.DocKey]; ok {
+			continue
+		}
+
+		head, hasBlock, err := getDAGBlock(ctx, txn, key.Cid, corecrdt.CompositeDAG{})
+		if err != nil {
+			_ = q.Close()
+			return nil, err
+		}
+		if !hasBlock {
+			continue
+		}
+		versionID := head.delta.(*corecrdt.CompositeDAGDelta).SchemaVersionID
+		isOfThisCollection, err := c.isOfCollection(ctx, txn, versionID, isOfCollection)
+		if err != nil {
+			_ = q.Close()
+			return nil, err
+		}
+		if isOfThisCollection {
+			seen[key.DocKey] = struct{}{}
+			dockeys = append(dockeys, key.DocKey)
+		}
+	}
+
+	return dockeys, q.Close()
+}
+
+// fsckDocument checks the given document, repairing it if requested, and returns the
+// inconsistencies found.
+func (c *collection) fsckDocument(
+	ctx context.Context,
+	txn datastore.Txn,
+	dockey string,
+	repair bool,
+) ([]client.Inconsistency, error) {
+	inconsistencies := []client.Inconsistency{}
+	key := base.MakeDocKey(c.Description(), dockey)
+
+	compositeKey := key.WithFieldId(core.COMPOSITE_NAMESPACE)
+	heads, missing, err := getHeadBlocks(ctx, txn, compositeKey, corecrdt.CompositeDAG{})
+	if err != nil {
+		return nil, err
+	}
+	for _, head := range missing {
+		inconsistencies = append(inconsistencies, client.Inconsistency{
+			DocKey: dockey,
+			Kind:   client.MissingHeadBlock,
+			Cid:    head.String(),
+		})
+	}
+
+	inconsistency, err := c.fsckPrimaryKey(ctx, txn, compositeKey, heads, len(missing) > 0, repair)
+	if err != nil {
+		return nil, err
+	}
+	if inconsistency != nil {
+		inconsistencies = append(inconsistencies, *inconsistency)
+	}
+
+	for _, field := range c.Schema().Fields {
+		if field.ID == 0 {
+			// the _key field has no DAG
+			continue
+		}
+
+		fieldKey := key.WithFieldId(field.ID.String())
+		heads, missing, err := getHeadBlocks(ctx, txn, fieldKey, corecrdt.LWWRegister{})
+		if err != nil {
+			return nil, err
+		}
+		for _, head := range missing {
+			inconsistencies = append(inconsistencies, client.Inconsistency{
+				DocKey:    dockey,
+				FieldName: field.Name,
+				Kind:      client.MissingHeadBlock,
+				Cid:       head.String(),
+			})
+		}
+
+		inconsistency, err := c.fsckFieldValue(ctx, txn, fieldKey, field.Name, heads, repair)
+		if err != nil {
+			return nil, err
+		}
+		if inconsistency != nil {
+			inconsistencies = append(inconsistencies, *inconsistency)
+		}
+	}
+
+	for _, inconsistency := range inconsistencies {
+		if !inconsistency.Repaired {
+			continue
+		}
+		// the replayed values must be searchable, as if they had been written by an update
+		if err := fulltext.Refresh(ctx, txn.Datastore(), c.Description(), dockey); err != nil {
+			return nil, err
+		}
+		break
+	}
+
+	return inconsistencies, nil
+}
+
+// fsckPrimaryKey checks that the primary key entry of the document exists and that its
+// deleted status matches the given composite heads. A document is deleted if any of its
+// composite heads is a deletion.
+func (c *collection) fsckPrimaryKey(
+	ctx context.Context,
+	txn datastore.Txn,
+	key core.DataStoreKey,
+	heads []dagBlock,
+	hasMissingHeads bool,
+	repair bool,
+) (*client.Inconsistency, error) {
+	primaryKey := key.ToPrimaryDataStoreKey()
+	marker, err := txn.Datastore().Get(ctx, primaryKey.ToDS())
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return nil, err
+	}
+	exists := err == nil
+
+	if len(heads) == 0 {
+		if exists && !hasMissingHeads {
+			return &client.Inconsistency{DocKey: key.DocKey, Kind: client.MissingCompositeHead}, nil
+		}
+		return nil, nil
+	}
+
+	isDeleted := false
+	for _, head := range heads {
+		if head.delta.(*corecrdt.CompositeDAGDelta).Status.IsDeleted() {
+			isDeleted = true
+		}
+	}
+
+	var inconsistency *client.Inconsistency
+	switch {
+	case !exists:
+		inconsistency = &client.Inconsistency{DocKey: key.DocKey, Kind: client.MissingPrimaryKey}
+	case isDeleted != bytes.Equal(marker, []byte{base.DeletedObjectMarker}):
+		inconsistency = &client.Inconsistency{DocKey: key.DocKey, Kind: client.DocumentStatusMismatch}
+	default:
+		return nil, nil
+	}
+	if !repair {
+		return inconsistency, nil
+	}
+
+	if err := txn.Datastore().Delete(ctx, primaryKey.ToDS()); err != nil {
+		return nil, err
+	}
+	if err := c.replayBlocks(ctx, txn, key, client.COMPOSITE, "", heads); err != nil {
+		return nil, err
+	}
+	inconsistency.Repaired = true
+	return inconsistency, nil
+}
+
+// fsckFieldValue checks that the stored value and priority of the field match the
+// winner of its heads, using the same rules as the LWW register merge.
+func (c *collection) fsckFieldValue(
+	ctx context.Context,
+	txn datastore.Txn,
+	key core.DataStoreKey,
+	fieldName string,
+	heads []dagBlock,
+	repair bool,
+) (*client.Inconsistency, error) {
+	if len(heads) == 0 {
+		return nil, nil
+	}
+
+	var winner *corecrdt.LWWRegDelta
+	for _, head := range heads {
+		delta := head.delta.(*corecrdt.LWWRegDelta)
+		if winner == nil ||
+			delta.Priority > winner.Priority ||
+			(delta.Priority == winner.Priority && bytes.Compare(delta.Data, winner.Data) > 0) {
+			winner = delta
+		}
+	}
+
+	valueKey := key.WithValueFlag()
+	marker, err := txn.Datastore().Get(ctx, key.ToPrimaryDataStoreKey().ToDS())
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return nil, err
+	}
+	if bytes.Equal(marker, []byte{base.DeletedObjectMarker}) {
+		valueKey = valueKey.WithDeletedFlag()
+	}
+
+	value, err := txn.Datastore().Get(ctx, valueKey.ToDS())
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return nil, err
+	}
+	isValueValid := err == nil && len(value) > 0 && bytes.Equal(value[1:], winner.Data)
+
+	priority, err := txn.Datastore().Get(ctx, key.WithPriorityFlag().ToDS())
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return nil, err
+	}
+	storedPriority, n := binary.Uvarint(priority)
+	isPriorityValid := n > 0 && storedPriority == winner.Priority
+
+	if isValueValid && isPriorityValid {
+		return nil, nil
+	}
+
+	inconsistency := &client.Inconsistency{
+		DocKey:    key.DocKey,
+		FieldName: fieldName,
+		Kind:      client.FieldValueMismatch,
+	}
+	if !repair {
+		return inconsistency, nil
+	}
+
+	staleKeys := []core.DataStoreKey{
+		key.WithValueFlag(),
+		key.WithValueFlag().WithDeletedFlag(),
+		key.WithPriorityFlag(),
+	}
+	for _, staleKey := range staleKeys {
+		if err := txn.Datastore().Delete(ctx, staleKey.ToDS()); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.replayBlocks(ctx, txn, key, client.LWW_REGISTER, fieldName, heads); err != nil {
+		return nil, err
+	}
+	inconsistency.Repaired = true
+	return inconsistency, nil
+}
+
+// replayBlocks merges the given blocks, in order of priority, into the state of the
+// merkle CRDT at the given key.
+func (c *collection) replayBlocks(
+	ctx context.Context,
+	txn datastore.Txn,
+	key core.DataStoreKey,
+	ctype client.CType,
+	fieldName string,
+	blocks []dagBlock,
+) error {
+	merkleCRDT, err := c.db.crdtFactory.InstanceWithStores(
+		txn,
+		core.NewCollectionSchemaVersionKey(c.Schema().VersionID),
+		events.EmptyUpdateChannel,
+		ctype,
+		key,
+		fieldName,
+	)
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(blocks, func(i, j int) bool {
+		return blocks[i].delta.GetPriority() < blocks[j].delta.GetPriority()
+	})
+	for _, block := range blocks {
+		if err := merkleCRDT.Merge(ctx, block.delta, block.cid.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getHeadBlocks returns the heads of the DAG at the given key that are in the blockstore,
+// and the Cids of the ones that are missing from it.
+func getHeadBlocks(
+	ctx context.Context,
+	txn datastore.Txn,
+	key core.DataStoreKey,
+	decoder core.ReplicatedData,
+) ([]dagBlock, []cid.Cid, error) {
+	headset := clock.NewHeadSet(txn.Headstore(), key.ToHeadStoreKey())
+	cids, _, err := headset.List(ctx)
+	if err != nil {
+		return nil, nil, NewErrFailedToGetHeads(err)
+	}
+
+	heads := []dagBlock{}
+	missing := []cid.Cid{}
+	for _, headCid := range cids {
+		head, hasBlock, err := getDAGBlock(ctx, txn, headCid, decoder)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !hasBlock {
+			missing = append(missing, headCid)
+			continue
+		}
+		heads = append(heads, head)
+	}
+	return heads, missing, nil
+}
+
+// isOfCollection returns true if the given schema version is a version of this collection.
+//
+// The results are cached in the given map, as many blocks share the same schema version.
+func (c *collection) isOfCollection(
+	ctx context.Context,
+	txn datastore.Txn,
+	versionID string,
+	cache map[string]bool,
+) (bool, error) {
+	if isOfCollection, ok := cache[versionID]; ok {
+		return isOfCollection, nil
+	}
+	col, err := c.db.getCollectionByVersionID(ctx, txn, versionID)
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return false, err
+	}
+	cache[versionID] = col != nil && col.ID() == c.ID()
+	return cache[versionID], nil
+}
+
+// getDAGBlock returns the decoded block of the given Cid, and false if it is not
+// in the blockstore.
+func getDAGBlock(
+	ctx context.Context,
+	txn datastore.Txn,
+	blockCid cid.Cid,
+	decoder core.ReplicatedData,
+) (dagBlock, bool, error) {
+	hasBlock, err := txn.DAGstore().Has(ctx, blockCid)
+	if err != nil || !hasBlock {
+		return dagBlock{}, false, err
+	}
+
+	block, err := txn.DAGstore().Get(ctx, blockCid)
+	if err != nil {
+		return dagBlock{}, false, err
+	}
+	nd, err := dag.DecodeProtobuf(block.RawData())
+	if err != nil {
+		return dagBlock{}, false, err
+	}
+	delta, err := decoder.DeltaDecode(nd)
+	if err != nil {
+		return dagBlock{}, false, err
+	}
+	return dagBlock{cid: blockCid, node: nd, delta: delta}, true, nil
+}
@@ -0,0 +1,203 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/db/base"
+	"github.com/sourcenetwork/defradb/db/fulltext"
+	"github.com/sourcenetwork/defradb/merkle/clock"
+)
+
+func newTestFsckDocument(
+	t *testing.T,
+	ctx context.Context,
+) (*implicitTxnDB, client.Collection, *client.Document) {
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	col, err := newTestCollectionWithSchema(t, ctx, db)
+	require.NoError(t, err)
+
+	doc, err := client.NewDocFromJSON([]byte(`{"Name": "John", "Age": 21}`))
+	require.NoError(t, err)
+	require.NoError(t, col.Create(ctx, doc))
+	require.NoError(t, doc.Set("Age", 22))
+	require.NoError(t, col.Update(ctx, doc))
+
+	return db, col, doc
+}
+
+func TestFsckWithConsistentDocument(t *testing.T) {
+	ctx := context.Background()
+	_, col, _ := newTestFsckDocument(t, ctx)
+
+	res, err := col.Fsck(ctx, client.FsckOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(1), res.Count)
+	assert.Empty(t, res.Inconsistencies)
+}
+
+func TestFsckWithFieldValueMismatchAndRepair(t *testing.T) {
+	ctx := context.Background()
+	db, col, doc := newTestFsckDocument(t, ctx)
+
+	key := base.MakeDocKey(col.Description(), doc.Key().String()).
+		WithFieldId(fmt.Sprint(col.Description().Schema.GetFieldKey("Age"))).
+		WithValueFlag()
+	txn, err := db.NewTxn(ctx, false)
+	require.NoError(t, err)
+	// overwrite the value with the one of the first commit
+	require.NoError(t, txn.Datastore().Put(ctx, key.ToDS(), []byte{byte(client.LWW_REGISTER), 0x15}))
+	require.NoError(t, txn.Commit(ctx))
+
+	res, err := col.Fsck(ctx, client.FsckOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []client.Inconsistency{
+		{DocKey: doc.Key().String(), FieldName: "Age", Kind: client.FieldValueMismatch},
+	}, res.Inconsistencies)
+
+	res, err = col.Fsck(ctx, client.FsckOptions{Repair: true})
+	require.NoError(t, err)
+	assert.Equal(t, []client.Inconsistency{
+		{DocKey: doc.Key().String(), FieldName: "Age", Kind: client.FieldValueMismatch, Repaired: true},
+	}, res.Inconsistencies)
+
+	res, err = col.Fsck(ctx, client.FsckOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, res.Inconsistencies)
+
+	repaired, err := col.Get(ctx, doc.Key(), false)
+	require.NoError(t, err)
+	age, err := repaired.Get("Age")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(22), age)
+}
+
+func TestFsckWithFieldValueMismatchAndRepairRefreshesFullTextIndex(t *testing.T) {
+	ctx := context.Background()
+	db, err := newMemoryDB(ctx)
+	require.NoError(t, err)
+	txn, err := db.NewTxn(ctx, false)
+	require.NoError(t, err)
+	col, err := db.createCollection(ctx, txn, client.CollectionDescription{
+		Name: "users",
+		Schema: client.SchemaDescription{
+			Fields: []client.FieldDescription{
+				{
+					Name: "_key",
+					Kind: client.FieldKind_DocKey,
+				},
+				{
+					Name:  "Bio",
+					Kind:  client.FieldKind_STRING,
+					Typ:   client.LWW_REGISTER,
+					Index: client.FULLTEXT_INDEX,
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, txn.Commit(ctx))
+
+	doc, err := client.NewDocFromJSON([]byte(`{"Bio": "Collects stamps"}`))
+	require.NoError(t, err)
+	require.NoError(t, col.Create(ctx, doc))
+	require.NoError(t, doc.Set("Bio", "Runs marathons"))
+	require.NoError(t, col.Update(ctx, doc))
+
+	key := base.MakeDocKey(col.Description(), doc.Key().String()).
+		WithFieldId(fmt.Sprint(col.Description().Schema.GetFieldKey("Bio"))).
+		WithValueFlag()
+	buf, err := cbor.Marshal("Collects stamps")
+	require.NoError(t, err)
+	txn, err = db.NewTxn(ctx, false)
+	require.NoError(t, err)
+	// overwrite the value, and its index, with the ones of the first commit
+	require.NoError(t, txn.Datastore().Put(ctx, key.ToDS(), append([]byte{byte(client.LWW_REGISTER)}, buf...)))
+	require.NoError(t, fulltext.Refresh(ctx, txn.Datastore(), col.Description(), doc.Key().String()))
+	require.NoError(t, txn.Commit(ctx))
+
+	res, err := col.Fsck(ctx, client.FsckOptions{Repair: true})
+	require.NoError(t, err)
+	assert.Equal(t, []client.Inconsistency{
+		{DocKey: doc.Key().String(), FieldName: "Bio", Kind: client.FieldValueMismatch, Repaired: true},
+	}, res.Inconsistencies)
+
+	field, ok := col.Description().GetField("Bio")
+	require.True(t, ok)
+	txn, err = db.NewTxn(ctx, true)
+	require.NoError(t, err)
+	defer txn.Discard(ctx)
+	matches, err := fulltext.Search(ctx, txn.Datastore(), col.Description(), field, "marathons")
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, doc.Key().String(), matches[0].DocKey)
+	matches, err = fulltext.Search(ctx, txn.Datastore(), col.Description(), field, "stamps")
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+}
+
+func TestFsckWithMissingPrimaryKeyAndRepair(t *testing.T) {
+	ctx := context.Background()
+	db, col, doc := newTestFsckDocument(t, ctx)
+
+	key := base.MakeDocKey(col.Description(), doc.Key().String()).ToPrimaryDataStoreKey()
+	txn, err := db.NewTxn(ctx, false)
+	require.NoError(t, err)
+	require.NoError(t, txn.Datastore().Delete(ctx, key.ToDS()))
+	require.NoError(t, txn.Commit(ctx))
+
+	_, err = col.Get(ctx, doc.Key(), false)
+	require.ErrorIs(t, err, client.ErrDocumentNotFound)
+
+	res, err := col.Fsck(ctx, client.FsckOptions{Repair: true})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), res.Count)
+	assert.Equal(t, []client.Inconsistency{
+		{DocKey: doc.Key().String(), Kind: client.MissingPrimaryKey, Repaired: true},
+	}, res.Inconsistencies)
+
+	_, err = col.Get(ctx, doc.Key(), false)
+	require.NoError(t, err)
+}
+
+func TestFsckWithMissingHeadBlock(t *testing.T) {
+	ctx := context.Background()
+	db, col, doc := newTestFsckDocument(t, ctx)
+
+	txn, err := db.NewTxn(ctx, false)
+	require.NoError(t, err)
+	headset := clock.NewHeadSet(
+		txn.Headstore(),
+		core.HeadStoreKey{DocKey: doc.Key().String(), FieldId: core.COMPOSITE_NAMESPACE},
+	)
+	heads, _, err := headset.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, heads, 1)
+	require.NoError(t, txn.DAGstore().DeleteBlock(ctx, heads[0]))
+	require.NoError(t, txn.Commit(ctx))
+
+	res, err := col.Fsck(ctx, client.FsckOptions{Repair: true})
+	require.NoError(t, err)
+	assert.Equal(t, []client.Inconsistency{
+		{DocKey: doc.Key().String(), Kind: client.MissingHeadBlock, Cid: heads[0].String()},
+	}, res.Inconsistencies)
+}
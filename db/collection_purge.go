@@ -0,0 +1,148 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"context"
+
+	dag "github.com/ipfs/boxo/ipld/merkledag"
+	"github.com/ipfs/go-cid"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/db/base"
+	"github.com/sourcenetwork/defradb/db/fulltext"
+	"github.com/sourcenetwork/defradb/merkle/clock"
+)
+
+// Purge will attempt to permanently erase a document by key, whether it has been deleted or not.
+//
+// All the state of the document is removed: its field values, priorities, heads, and every block
+// of its DAG. A tombstone is left in its place so that the document is not re-created from blocks
+// received from other peers. If the document doesn't exist, then it will return false and a
+// ErrDocumentNotFound error.
+func (c *collection) Purge(ctx context.Context, key client.DocKey) (bool, error) {
+	txn, err := c.getTxn(ctx, false)
+	if err != nil {
+		return false, err
+	}
+	defer c.discardImplicitTxn(ctx, txn)
+
+	err = c.purge(ctx, txn, key.String())
+	if err != nil {
+		return false, err
+	}
+	return true, c.commitImplicitTxn(ctx, txn)
+}
+
+func (c *collection) purge(ctx context.Context, txn datastore.Txn, dockey string) error {
+	key := base.MakeDocKey(c.Description(), dockey)
+
+	headset := clock.NewHeadSet(txn.Headstore(), key.WithFieldId(core.COMPOSITE_NAMESPACE).ToHeadStoreKey())
+	heads, _, err := headset.List(ctx)
+	if err != nil {
+		return NewErrFailedToGetHeads(err)
+	}
+	exists, _, err := c.exists(ctx, txn, key.ToPrimaryDataStoreKey())
+	if err != nil {
+		return err
+	}
+	if len(heads) == 0 && !exists {
+		return client.ErrDocumentNotFound
+	}
+
+	blocks, err := getDAGBlockCids(ctx, txn, heads, true)
+	if err != nil {
+		return err
+	}
+	for _, blockCid := range blocks {
+		if err := txn.DAGstore().DeleteBlock(ctx, blockCid); err != nil {
+			return err
+		}
+	}
+
+	if err := c.clearDocumentState(ctx, txn, key); err != nil {
+		return err
+	}
+	if err := fulltext.Remove(ctx, txn.Datastore(), c.Description(), dockey); err != nil {
+		return err
+	}
+	if err := txn.Systemstore().Delete(ctx, core.NewDocSnapshotKey(dockey).ToDS()); err != nil {
+		return err
+	}
+
+	return txn.Systemstore().Put(ctx, core.NewPurgedDocKey(dockey).ToDS(), []byte(c.Description().IDString()))
+}
+
+// getDAGBlockCids walks the DAGs from the given heads and returns the Cids of all the composite
+// and field blocks reachable from them that are in the blockstore.
+//
+// The blocks that have been folded into a snapshot by a compaction are walked through their
+// snapshot. If removeCompacted is true, the records of these blocks are removed on the way, as
+// they are not needed anymore once the snapshot is gone.
+func getDAGBlockCids(
+	ctx context.Context,
+	txn datastore.Txn,
+	heads []cid.Cid,
+	removeCompacted bool,
+) ([]cid.Cid, error) {
+	blocks := []cid.Cid{}
+	visited := map[cid.Cid]struct{}{}
+	queue := append([]cid.Cid{}, heads...)
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if _, ok := visited[current]; ok {
+			continue
+		}
+		visited[current] = struct{}{}
+
+		hasBlock, err := txn.DAGstore().Has(ctx, current)
+		if err != nil {
+			return nil, err
+		}
+		if !hasBlock {
+			snapshot, isCompacted, err := clock.GetCompactedSnapshot(ctx, txn.Systemstore(), current)
+			if err != nil {
+				return nil, err
+			}
+			if !isCompacted {
+				continue
+			}
+			if removeCompacted {
+				if err := txn.Systemstore().Delete(ctx, core.NewCompactedBlockKey(current).ToDS()); err != nil {
+					return nil, err
+				}
+			}
+			queue = append(queue, snapshot)
+			continue
+		}
+
+		block, err := txn.DAGstore().Get(ctx, current)
+		if err != nil {
+			return nil, err
+		}
+		nd, err := dag.DecodeProtobuf(block.RawData())
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, current)
+
+		// both the parents and the field blocks of a composite block are part of the DAG,
+		// field blocks only link to their parents.
+		for _, l := range nd.Links() {
+			queue = append(queue, l.Cid)
+		}
+	}
+	return blocks, nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ipfs/go-datastore/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/core"
+)
+
+func TestPurgeDocument(t *testing.T) {
+	ctx := context.Background()
+	db, col, doc := newTestFsckDocument(t, ctx)
+
+	purged, err := col.Purge(ctx, doc.Key())
+	require.NoError(t, err)
+	assert.True(t, purged)
+
+	_, err = col.Get(ctx, doc.Key(), false)
+	require.ErrorIs(t, err, client.ErrDocumentNotFound)
+
+	txn, err := db.NewTxn(ctx, true)
+	require.NoError(t, err)
+	defer txn.Discard(ctx)
+
+	keys, err := txn.DAGstore().AllKeysChan(ctx)
+	require.NoError(t, err)
+	blocks := 0
+	for range keys {
+		blocks++
+	}
+	assert.Equal(t, 0, blocks)
+
+	q, err := txn.Headstore().Query(ctx, query.Query{KeysOnly: true})
+	require.NoError(t, err)
+	heads, err := q.Rest()
+	require.NoError(t, err)
+	assert.Empty(t, heads)
+
+	isPurged, err := txn.Systemstore().Has(ctx, core.NewPurgedDocKey(doc.Key().String()).ToDS())
+	require.NoError(t, err)
+	assert.True(t, isPurged)
+}
+
+func TestPurgeDeletedDocument(t *testing.T) {
+	ctx := context.Background()
+	_, col, doc := newTestFsckDocument(t, ctx)
+
+	_, err := col.Delete(ctx, doc.Key())
+	require.NoError(t, err)
+
+	purged, err := col.Purge(ctx, doc.Key())
+	require.NoError(t, err)
+	assert.True(t, purged)
+
+	fsck, err := col.Fsck(ctx, client.FsckOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), fsck.Count)
+}
+
+func TestPurgeNonExistingDocument(t *testing.T) {
+	ctx := context.Background()
+	_, col, doc := newTestFsckDocument(t, ctx)
+
+	_, err := col.Purge(ctx, doc.Key())
+	require.NoError(t, err)
+
+	purged, err := col.Purge(ctx, doc.Key())
+	require.ErrorIs(t, err, client.ErrDocumentNotFound)
+	assert.False(t, purged)
+}
@@ -0,0 +1,374 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/ipfs/go-cid"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/core"
+	corecrdt "github.com/sourcenetwork/defradb/core/crdt"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/db/base"
+	"github.com/sourcenetwork/defradb/db/fulltext"
+	"github.com/sourcenetwork/defradb/merkle/clock"
+)
+
+// Reindex rebuilds the datastore entries and the heads of the documents in this collection
+// purely from the blocks in the blockstore.
+//
+// The composite blocks of the collection are found by scanning the blockstore. The heads of
+// each document are the composite blocks that are not the parent of any other, and its
+// composite DAG is walked from these heads. The walked blocks, and the field blocks they link
+// to, are then replayed through the merge path of their merkle CRDT into the cleared stores.
+func (c *collection) Reindex(
+	ctx context.Context,
+	opts client.ReindexOptions,
+) (*client.ReindexResult, error) {
+	txn, err := c.getTxn(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	defer c.discardImplicitTxn(ctx, txn)
+
+	res, err := c.reindex(ctx, txn, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, c.commitImplicitTxn(ctx, txn)
+}
+
+func (c *collection) reindex(
+	ctx context.Context,
+	txn datastore.Txn,
+	opts client.ReindexOptions,
+) (*client.ReindexResult, error) {
+	filter := make(map[string]struct{}, len(opts.DocKeys))
+	for _, key := range opts.DocKeys {
+		filter[key.String()] = struct{}{}
+	}
+
+	docs, err := c.getCompositeBlocksByDocument(ctx, txn, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(opts.DocKeys) == 0 {
+		err := deleteDatastoreEntries(ctx, txn, base.MakeCollectionKey(c.Description()))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	dockeys := make([]string, 0, len(docs))
+	for dockey := range docs {
+		dockeys = append(dockeys, dockey)
+	}
+	sort.Strings(dockeys)
+
+	results := &client.ReindexResult{}
+	for _, dockey := range dockeys {
+		replayed, err := c.reindexDocument(ctx, txn, dockey, docs[dockey])
+		if err != nil {
+			return nil, NewErrFailedToReindexDocument(dockey, err)
+		}
+		results.Count++
+		results.ReplayedBlocks += replayed
+
+		if opts.OnProgress != nil {
+			opts.OnProgress(client.ReindexProgress{
+				DocKey: dockey,
+				Done:   results.Count,
+				Total:  int64(len(dockeys)),
+			})
+		}
+	}
+
+	return results, nil
+}
+
+// getCompositeBlocksByDocument scans the blockstore and returns the composite blocks of the
+// documents of this collection, grouped by document.
+//
+// If the given filter is not empty, only the blocks of the documents it contains are returned.
+func (c *collection) getCompositeBlocksByDocument(
+	ctx context.Context,
+	txn datastore.Txn,
+	filter map[string]struct{},
+) (map[string]map[cid.Cid]dagBlock, error) {
+	// the blocks are listed first so that they are not read while the store is iterated
+	blockCids, err := getAllBlockCids(ctx, txn)
+	if err != nil {
+		return nil, err
+	}
+
+	isOfCollection := map[string]bool{}
+	docs := map[string]map[cid.Cid]dagBlock{}
+	for _, blockCid := range blockCids {
+		block, hasBlock, err := getDAGBlock(ctx, txn, blockCid, corecrdt.CompositeDAG{})
+		if err != nil {
+			return nil, err
+		}
+		if !hasBlock {
+			continue
+		}
+
+		delta := block.delta.(*corecrdt.CompositeDAGDelta)
+		if delta.FieldName != "" {
+			// field blocks are found through the links of the composite blocks
+			continue
+		}
+		dockey := string(delta.DocKey)
+		if _, ok := filter[dockey]; len(filter) > 0 && !ok {
+			continue
+		}
+		isOfThisCollection, err := c.isOfCollection(ctx, txn, delta.SchemaVersionID, isOfCollection)
+		if err != nil {
+			return nil, err
+		}
+		if !isOfThisCollection {
+			continue
+		}
+
+		if _, ok := docs[dockey]; !ok {
+			docs[dockey] = map[cid.Cid]dagBlock{}
+		}
+		docs[dockey][blockCid] = block
+	}
+
+	return docs, nil
+}
+
+// reindexDocument rebuilds the state of the given document from the given composite blocks
+// and returns the number of blocks replayed.
+func (c *collection) reindexDocument(
+	ctx context.Context,
+	txn datastore.Txn,
+	dockey string,
+	blocks map[cid.Cid]dagBlock,
+) (int64, error) {
+	key := base.MakeDocKey(c.Description(), dockey)
+	if err := c.clearDocumentState(ctx, txn, key); err != nil {
+		return 0, err
+	}
+
+	heads := getDAGHeads(blocks)
+
+	// walk the composite DAG from its heads, collecting the field blocks on the way
+	composites := []dagBlock{}
+	fields := map[string]map[cid.Cid]dagBlock{}
+	visited := map[cid.Cid]struct{}{}
+	queue := append([]cid.Cid{}, heads...)
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if _, ok := visited[current]; ok {
+			continue
+		}
+		visited[current] = struct{}{}
+
+		block, ok := blocks[current]
+		if !ok {
+			// the parent has been folded into a snapshot by a compaction
+			continue
+		}
+		composites = append(composites, block)
+
+		for _, l := range block.node.Links() {
+			if l.Name == core.HEAD {
+				queue = append(queue, l.Cid)
+				continue
+			}
+
+			fieldBlock, hasBlock, err := getDAGBlock(ctx, txn, l.Cid, corecrdt.LWWRegister{})
+			if err != nil {
+				return 0, err
+			}
+			if !hasBlock {
+				return 0, NewErrMissingBlock(l.Cid)
+			}
+			if _, ok := fields[l.Name]; !ok {
+				fields[l.Name] = map[cid.Cid]dagBlock{}
+			}
+			fields[l.Name][l.Cid] = fieldBlock
+		}
+	}
+
+	// the composite blocks are replayed first, so that the field values are
+	// written according to the deleted status of the document.
+	compositeKey := key.WithFieldId(core.COMPOSITE_NAMESPACE)
+	if err := c.replayBlocks(ctx, txn, compositeKey, client.COMPOSITE, "", composites); err != nil {
+		return 0, err
+	}
+	if err := writeDAGHeads(ctx, txn, compositeKey, blocks, heads); err != nil {
+		return 0, err
+	}
+
+	replayed := int64(len(composites))
+	for name, fieldBlocks := range fields {
+		fieldID := c.Description().Schema.GetFieldKey(name)
+		if fieldID == 0 {
+			return 0, client.NewErrFieldNotExist(name)
+		}
+		fieldKey := key.WithFieldId(fmt.Sprint(fieldID))
+
+		toReplay := make([]dagBlock, 0, len(fieldBlocks))
+		for _, block := range fieldBlocks {
+			toReplay = append(toReplay, block)
+		}
+		if err := c.replayBlocks(ctx, txn, fieldKey, client.LWW_REGISTER, name, toReplay); err != nil {
+			return 0, err
+		}
+		if err := writeDAGHeads(ctx, txn, fieldKey, fieldBlocks, getDAGHeads(fieldBlocks)); err != nil {
+			return 0, err
+		}
+		replayed += int64(len(toReplay))
+	}
+
+	if err := fulltext.Refresh(ctx, txn.Datastore(), c.Description(), dockey); err != nil {
+		return 0, err
+	}
+
+	return replayed, nil
+}
+
+// clearDocumentState removes the datastore entries and the heads of the given document.
+func (c *collection) clearDocumentState(ctx context.Context, txn datastore.Txn, key core.DataStoreKey) error {
+	err := txn.Datastore().Delete(ctx, key.ToPrimaryDataStoreKey().ToDS())
+	if err != nil {
+		return err
+	}
+	for _, instanceKey := range []core.DataStoreKey{
+		key.WithValueFlag(),
+		key.WithPriorityFlag(),
+		key.WithDeletedFlag(),
+	} {
+		if err := deleteDatastoreEntries(ctx, txn, instanceKey); err != nil {
+			return err
+		}
+	}
+
+	q, err := txn.Headstore().Query(ctx, query.Query{
+		Prefix:   core.HeadStoreKey{DocKey: key.DocKey}.ToString(),
+		KeysOnly: true,
+	})
+	if err != nil {
+		return err
+	}
+	toDelete := []ds.Key{}
+	for res := range q.Next() {
+		if res.Error != nil {
+			_ = q.Close()
+			return res.Error
+		}
+		toDelete = append(toDelete, ds.NewKey(res.Key))
+	}
+	if err := q.Close(); err != nil {
+		return err
+	}
+
+	for _, key := range toDelete {
+		if err := txn.Headstore().Delete(ctx, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteDatastoreEntries removes all the datastore entries under the given key.
+func deleteDatastoreEntries(ctx context.Context, txn datastore.Txn, prefix core.DataStoreKey) error {
+	q, err := txn.Datastore().Query(ctx, query.Query{
+		Prefix:   prefix.ToString(),
+		KeysOnly: true,
+	})
+	if err != nil {
+		return err
+	}
+	toDelete := []ds.Key{}
+	for res := range q.Next() {
+		if res.Error != nil {
+			_ = q.Close()
+			return res.Error
+		}
+		key, err := core.NewDataStoreKey(res.Key)
+		if err != nil {
+			_ = q.Close()
+			return err
+		}
+		// the query prefix may match the keys of other collections or documents
+		if key.CollectionID != prefix.CollectionID ||
+			(prefix.InstanceType != "" && key.InstanceType != prefix.InstanceType) ||
+			(prefix.DocKey != "" && key.DocKey != prefix.DocKey) {
+			continue
+		}
+		toDelete = append(toDelete, ds.NewKey(res.Key))
+	}
+	if err := q.Close(); err != nil {
+		return err
+	}
+
+	for _, key := range toDelete {
+		if err := txn.Datastore().Delete(ctx, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getDAGHeads returns the Cids of the given blocks that are not the parent of any other,
+// sorted lexicographically.
+func getDAGHeads(blocks map[cid.Cid]dagBlock) []cid.Cid {
+	parents := map[cid.Cid]struct{}{}
+	for _, block := range blocks {
+		for _, l := range block.node.Links() {
+			if l.Name == core.HEAD {
+				parents[l.Cid] = struct{}{}
+			}
+		}
+	}
+
+	heads := []cid.Cid{}
+	for blockCid := range blocks {
+		if _, ok := parents[blockCid]; !ok {
+			heads = append(heads, blockCid)
+		}
+	}
+	sort.Slice(heads, func(i, j int) bool {
+		return heads[i].String() < heads[j].String()
+	})
+	return heads
+}
+
+// writeDAGHeads writes the given heads of the DAG at the given key to the headstore.
+func writeDAGHeads(
+	ctx context.Context,
+	txn datastore.Txn,
+	key core.DataStoreKey,
+	blocks map[cid.Cid]dagBlock,
+	heads []cid.Cid,
+) error {
+	headset := clock.NewHeadSet(txn.Headstore(), key.ToHeadStoreKey())
+	for _, head := range heads {
+		if err := headset.Write(ctx, head, blocks[head].delta.GetPriority()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/db/base"
+	"github.com/sourcenetwork/defradb/merkle/clock"
+)
+
+// clearStores removes all the entries of the datastore and the headstore, keeping
+// only the blockstore.
+func clearStores(t *testing.T, ctx context.Context, db *implicitTxnDB) {
+	txn, err := db.NewTxn(ctx, false)
+	require.NoError(t, err)
+
+	for _, store := range []datastore.DSReaderWriter{txn.Datastore(), txn.Headstore()} {
+		q, err := store.Query(ctx, query.Query{KeysOnly: true})
+		require.NoError(t, err)
+		entries, err := q.Rest()
+		require.NoError(t, err)
+		for _, entry := range entries {
+			require.NoError(t, store.Delete(ctx, ds.NewKey(entry.Key)))
+		}
+	}
+
+	require.NoError(t, txn.Commit(ctx))
+}
+
+func getCompositeHeads(t *testing.T, ctx context.Context, db *implicitTxnDB, dockey string) []cid.Cid {
+	txn, err := db.NewTxn(ctx, true)
+	require.NoError(t, err)
+	defer txn.Discard(ctx)
+
+	headset := clock.NewHeadSet(
+		txn.Headstore(),
+		core.HeadStoreKey{DocKey: dockey, FieldId: core.COMPOSITE_NAMESPACE},
+	)
+	heads, _, err := headset.List(ctx)
+	require.NoError(t, err)
+	return heads
+}
+
+func TestReindexWithOnlyBlockstore(t *testing.T) {
+	ctx := context.Background()
+	db, col, doc := newTestFsckDocument(t, ctx)
+	heads := getCompositeHeads(t, ctx, db, doc.Key().String())
+
+	clearStores(t, ctx, db)
+	_, err := col.Get(ctx, doc.Key(), false)
+	require.ErrorIs(t, err, client.ErrDocumentNotFound)
+
+	progress := []client.ReindexProgress{}
+	res, err := col.Reindex(ctx, client.ReindexOptions{
+		OnProgress: func(p client.ReindexProgress) {
+			progress = append(progress, p)
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), res.Count)
+	// two composite blocks, two blocks for Age and one for Name
+	assert.Equal(t, int64(5), res.ReplayedBlocks)
+	assert.Equal(t, []client.ReindexProgress{{DocKey: doc.Key().String(), Done: 1, Total: 1}}, progress)
+
+	assert.Equal(t, heads, getCompositeHeads(t, ctx, db, doc.Key().String()))
+
+	reindexed, err := col.Get(ctx, doc.Key(), false)
+	require.NoError(t, err)
+	name, err := reindexed.Get("Name")
+	require.NoError(t, err)
+	assert.Equal(t, "John", name)
+	age, err := reindexed.Get("Age")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(22), age)
+
+	fsck, err := col.Fsck(ctx, client.FsckOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, fsck.Inconsistencies)
+}
+
+func TestReindexWithDeletedDocument(t *testing.T) {
+	ctx := context.Background()
+	db, col, doc := newTestFsckDocument(t, ctx)
+
+	deleted, err := col.Delete(ctx, doc.Key())
+	require.NoError(t, err)
+	require.True(t, deleted)
+
+	clearStores(t, ctx, db)
+
+	res, err := col.Reindex(ctx, client.ReindexOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), res.Count)
+
+	_, err = col.Get(ctx, doc.Key(), false)
+	require.ErrorIs(t, err, client.ErrDocumentNotFound)
+
+	txn, err := db.NewTxn(ctx, true)
+	require.NoError(t, err)
+	defer txn.Discard(ctx)
+	key := base.MakeDocKey(col.Description(), doc.Key().String())
+	marker, err := txn.Datastore().Get(ctx, key.ToPrimaryDataStoreKey().ToDS())
+	require.NoError(t, err)
+	assert.Equal(t, []byte{base.DeletedObjectMarker}, marker)
+
+	fsck, err := col.Fsck(ctx, client.FsckOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, fsck.Inconsistencies)
+}
+
+func TestReindexWithDocKeys(t *testing.T) {
+	ctx := context.Background()
+	_, col, doc := newTestFsckDocument(t, ctx)
+
+	other, err := client.NewDocFromJSON([]byte(`{"Name": "Islam", "Age": 33}`))
+	require.NoError(t, err)
+	require.NoError(t, col.Create(ctx, other))
+
+	res, err := col.Reindex(ctx, client.ReindexOptions{DocKeys: []client.DocKey{doc.Key()}})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), res.Count)
+
+	_, err = col.Get(ctx, other.Key(), false)
+	require.NoError(t, err)
+}
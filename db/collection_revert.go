@@ -0,0 +1,100 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/ipfs/go-cid"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/datastore"
+)
+
+// Revert restores the document with the given DocKey to its state at the given version.
+//
+// The version must be the CID of a composite commit of the document. The history is not
+// rewritten, instead a new commit restoring the field values of that version is written on
+// top of the current heads. The CID of this new commit is returned.
+func (c *collection) Revert(ctx context.Context, key client.DocKey, version string) (cid.Cid, error) {
+	txn, err := c.getTxn(ctx, false)
+	if err != nil {
+		return cid.Undef, err
+	}
+	defer c.discardImplicitTxn(ctx, txn)
+
+	head, err := c.revert(ctx, txn, key, version)
+	if err != nil {
+		return cid.Undef, err
+	}
+	return head, c.commitImplicitTxn(ctx, txn)
+}
+
+func (c *collection) revert(
+	ctx context.Context,
+	txn datastore.Txn,
+	key client.DocKey,
+	version string,
+) (cid.Cid, error) {
+	primaryKey := c.getPrimaryKeyFromDocKey(key)
+	exists, isDeleted, err := c.exists(ctx, txn, primaryKey)
+	if err != nil {
+		return cid.Undef, err
+	}
+	if !exists {
+		return cid.Undef, client.ErrDocumentNotFound
+	}
+	if isDeleted {
+		return cid.Undef, ErrDocumentDeleted
+	}
+
+	target, err := c.getDocumentAtVersion(ctx, txn, key, version)
+	if err != nil {
+		return cid.Undef, err
+	}
+	if target == nil {
+		return cid.Undef, client.ErrDocumentNotFound
+	}
+
+	doc, err := c.get(ctx, txn, primaryKey, false)
+	if err != nil {
+		return cid.Undef, err
+	}
+	// only the fields changed below must be written
+	doc.Clean()
+
+	targetValues := map[string]client.Value{}
+	for field, value := range target.Values() {
+		if value.Value() == nil {
+			continue
+		}
+		targetValues[field.Name()] = value
+
+		current, err := doc.Get(field.Name())
+		if err == nil && reflect.DeepEqual(current, value.Value()) {
+			continue
+		}
+		if err := doc.SetAs(field.Name(), value.Value(), field.Type()); err != nil {
+			return cid.Undef, err
+		}
+	}
+	for field, value := range doc.Values() {
+		if _, ok := targetValues[field.Name()]; ok || value.Value() == nil {
+			continue
+		}
+		if err := doc.Delete(field.Name()); err != nil {
+			return cid.Undef, err
+		}
+	}
+
+	return c.save(ctx, txn, doc, false)
+}
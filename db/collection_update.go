@@ -23,6 +23,7 @@ import (
 	"github.com/sourcenetwork/defradb/client/request"
 	"github.com/sourcenetwork/defradb/core"
 	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/db/fulltext"
 	"github.com/sourcenetwork/defradb/events"
 	"github.com/sourcenetwork/defradb/planner"
 )
@@ -339,6 +340,17 @@ func (c *collection) applyMerge(
 			return client.NewErrFieldNotExist(mfield)
 		}
 
+		if fd.Index == client.FULLTEXT_INDEX {
+			text := immutable.None[string]()
+			if value, isString := cborVal.(string); isString {
+				text = immutable.Some(value)
+			}
+			err = fulltext.Update(ctx, txn.Datastore(), c.desc, fd, keyStr, text)
+			if err != nil {
+				return err
+			}
+		}
+
 		c, _, err := c.saveDocValue(ctx, txn, fieldKey, val)
 		if err != nil {
 			return err
@@ -17,10 +17,12 @@ package db
 import (
 	"context"
 	"sync"
+	"time"
 
 	blockstore "github.com/ipfs/boxo/blockstore"
 	ds "github.com/ipfs/go-datastore"
 	dsq "github.com/ipfs/go-datastore/query"
+	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/sourcenetwork/immutable"
 
 	"github.com/sourcenetwork/defradb/client"
@@ -44,6 +46,10 @@ var (
 
 const (
 	defaultMaxTxnRetries = 5
+
+	// DefaultGCGracePeriod is the default grace period of the garbage collections,
+	// long enough for the blocks being synced from other peers to have their heads committed.
+	DefaultGCGracePeriod = 10 * time.Minute
 )
 
 // DB is the main interface for interacting with the
@@ -63,6 +69,19 @@ type db struct {
 	// The maximum number of retries per transaction.
 	maxTxnRetries immutable.Option[int]
 
+	// The clock used to record the time of commits.
+	commitClock func() time.Time
+
+	// The private key with which the blocks are signed, if any.
+	signingKey crypto.PrivKey
+
+	// The interval at which the unreachable blocks are garbage collected, if any.
+	gcInterval time.Duration
+	// The grace period of the garbage collections that do not give one.
+	gcGracePeriod time.Duration
+	// Stops the scheduled garbage collection and waits for it to return.
+	stopGC func()
+
 	// The options used to init the database
 	options any
 }
@@ -70,14 +89,22 @@ type db struct {
 // Functional option type.
 type Option func(*db)
 
-const updateEventBufferSize = 100
+const (
+	updateEventBufferSize   = 100
+	conflictEventBufferSize = 100
+)
 
 // WithUpdateEvents enables the update events channel.
 func WithUpdateEvents() Option {
 	return func(db *db) {
-		db.events = events.Events{
-			Updates: immutable.Some(events.New[events.Update](0, updateEventBufferSize)),
-		}
+		db.events.Updates = immutable.Some(events.New[events.Update](0, updateEventBufferSize))
+	}
+}
+
+// WithConflictEvents enables the conflict events channel.
+func WithConflictEvents() Option {
+	return func(db *db) {
+		db.events.Conflicts = immutable.Some(events.New[events.Conflict](0, conflictEventBufferSize))
 	}
 }
 
@@ -88,6 +115,40 @@ func WithMaxRetries(num int) Option {
 	}
 }
 
+// WithCommitClock sets the clock used to record the time of commits, which defaults
+// to the system clock. The time of a commit is not recorded if the clock returns the
+// zero time.
+func WithCommitClock(clock func() time.Time) Option {
+	return func(db *db) {
+		db.commitClock = clock
+	}
+}
+
+// WithSigningKey sets the private key with which the blocks written by this database
+// are signed, usually the key of the libp2p host of the node. Blocks are not signed
+// if no key is given.
+func WithSigningKey(key crypto.PrivKey) Option {
+	return func(db *db) {
+		db.signingKey = key
+	}
+}
+
+// WithGCInterval schedules the garbage collection of the blocks that are not reachable from
+// any head at the given interval. The garbage is only collected on demand if the interval is zero.
+func WithGCInterval(interval time.Duration) Option {
+	return func(db *db) {
+		db.gcInterval = interval
+	}
+}
+
+// WithGCGracePeriod sets the time during which the garbage collections keep the blocks they
+// found unreachable, unless they give their own, which defaults to [DefaultGCGracePeriod].
+func WithGCGracePeriod(gracePeriod time.Duration) Option {
+	return func(db *db) {
+		db.gcGracePeriod = gracePeriod
+	}
+}
+
 // NewDB creates a new instance of the DB using the given options.
 func NewDB(ctx context.Context, rootstore datastore.RootStore, options ...Option) (client.DB, error) {
 	return newDB(ctx, rootstore, options...)
@@ -110,8 +171,10 @@ func newDB(ctx context.Context, rootstore datastore.RootStore, options ...Option
 
 		crdtFactory: &crdtFactory,
 
-		parser:  parser,
-		options: options,
+		parser:        parser,
+		options:       options,
+		commitClock:   time.Now,
+		gcGracePeriod: DefaultGCGracePeriod,
 	}
 
 	// apply options
@@ -127,6 +190,10 @@ func newDB(ctx context.Context, rootstore datastore.RootStore, options ...Option
 		return nil, err
 	}
 
+	if db.gcInterval > 0 {
+		db.scheduleGC()
+	}
+
 	return &implicitTxnDB{db}, nil
 }
 
@@ -233,9 +300,15 @@ func (db *db) PrintDump(ctx context.Context) error {
 // This is the place for any last minute cleanup or releasing of resources (i.e.: Badger instance).
 func (db *db) Close(ctx context.Context) {
 	log.Info(ctx, "Closing DefraDB process...")
+	if db.stopGC != nil {
+		db.stopGC()
+	}
 	if db.events.Updates.HasValue() {
 		db.events.Updates.Value().Close()
 	}
+	if db.events.Conflicts.HasValue() {
+		db.events.Conflicts.Value().Close()
+	}
 
 	err := db.rootstore.Close()
 	if err != nil {
@@ -11,6 +11,8 @@
 package db
 
 import (
+	"github.com/ipfs/go-cid"
+
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/errors"
 )
@@ -31,10 +33,19 @@ const (
 	errCannotAddRelationalField      string = "the adding of new relation fields is not yet supported"
 	errDuplicateField                string = "duplicate field"
 	errCannotMutateField             string = "mutating an existing field is not supported"
+	errCannotMutateFieldCRDTType     string = "the CRDT type of an existing field cannot be changed"
 	errCannotMoveField               string = "moving fields is not currently supported"
 	errInvalidCRDTType               string = "only default or LWW (last writer wins) CRDT types are supported"
+	errCRDTKindMismatch              string = "CRDT type is not valid for the field kind"
+	errInvalidIndexType              string = "only full-text indexes are supported"
+	errIndexKindMismatch             string = "index type is not valid for the field kind"
 	errCannotDeleteField             string = "deleting an existing field is not supported"
 	errFieldKindNotFound             string = "no type found for given name"
+	errFailedToCompactDocument       string = "failed to compact document history"
+	errVersionNotOfDocument          string = "the given version is not a commit of the document"
+	errFailedToCheckDocument         string = "failed to check document consistency"
+	errFailedToReindexDocument       string = "failed to reindex document"
+	errMissingBlock                  string = "block is missing from the blockstore"
 )
 
 var (
@@ -52,36 +63,46 @@ var (
 	ErrInvalidMergeValueType   = errors.New(
 		"the type of value in the merge patch doesn't match the schema",
 	)
-	ErrMissingDocFieldToUpdate  = errors.New("missing document field to update")
-	ErrDocMissingKey            = errors.New("document is missing key")
-	ErrMergeSubTypeNotSupported = errors.New("merge doesn't support sub types yet")
-	ErrInvalidFilter            = errors.New("invalid filter")
-	ErrInvalidOpPath            = errors.New("invalid patch op path")
-	ErrDocumentAlreadyExists    = errors.New("a document with the given dockey already exists")
-	ErrDocumentDeleted          = errors.New("a document with the given dockey has been deleted")
-	ErrUnknownCRDTArgument      = errors.New("invalid CRDT arguments")
-	ErrUnknownCRDT              = errors.New("unknown crdt")
-	ErrSchemaFirstFieldDocKey   = errors.New("collection schema first field must be a DocKey")
-	ErrCollectionAlreadyExists  = errors.New("collection already exists")
-	ErrCollectionNameEmpty      = errors.New("collection name can't be empty")
-	ErrSchemaIdEmpty            = errors.New("schema ID can't be empty")
-	ErrSchemaVersionIdEmpty     = errors.New("schema version ID can't be empty")
-	ErrKeyEmpty                 = errors.New("key cannot be empty")
-	ErrAddingP2PCollection      = errors.New(errAddingP2PCollection)
-	ErrRemovingP2PCollection    = errors.New(errRemovingP2PCollection)
-	ErrAddCollectionWithPatch   = errors.New(errAddCollectionWithPatch)
-	ErrCollectionIDDoesntMatch  = errors.New(errCollectionIDDoesntMatch)
-	ErrSchemaIDDoesntMatch      = errors.New(errSchemaIDDoesntMatch)
-	ErrCannotModifySchemaName   = errors.New(errCannotModifySchemaName)
-	ErrCannotSetVersionID       = errors.New(errCannotSetVersionID)
-	ErrCannotSetFieldID         = errors.New(errCannotSetFieldID)
-	ErrCannotAddRelationalField = errors.New(errCannotAddRelationalField)
-	ErrDuplicateField           = errors.New(errDuplicateField)
-	ErrCannotMutateField        = errors.New(errCannotMutateField)
-	ErrCannotMoveField          = errors.New(errCannotMoveField)
-	ErrInvalidCRDTType          = errors.New(errInvalidCRDTType)
-	ErrCannotDeleteField        = errors.New(errCannotDeleteField)
-	ErrFieldKindNotFound        = errors.New(errFieldKindNotFound)
+	ErrMissingDocFieldToUpdate   = errors.New("missing document field to update")
+	ErrDocMissingKey             = errors.New("document is missing key")
+	ErrMergeSubTypeNotSupported  = errors.New("merge doesn't support sub types yet")
+	ErrInvalidFilter             = errors.New("invalid filter")
+	ErrInvalidOpPath             = errors.New("invalid patch op path")
+	ErrDocumentAlreadyExists     = errors.New("a document with the given dockey already exists")
+	ErrDocumentDeleted           = errors.New("a document with the given dockey has been deleted")
+	ErrUnknownCRDTArgument       = errors.New("invalid CRDT arguments")
+	ErrUnknownCRDT               = errors.New("unknown crdt")
+	ErrSchemaFirstFieldDocKey    = errors.New("collection schema first field must be a DocKey")
+	ErrCollectionAlreadyExists   = errors.New("collection already exists")
+	ErrCollectionNameEmpty       = errors.New("collection name can't be empty")
+	ErrSchemaIdEmpty             = errors.New("schema ID can't be empty")
+	ErrSchemaVersionIdEmpty      = errors.New("schema version ID can't be empty")
+	ErrKeyEmpty                  = errors.New("key cannot be empty")
+	ErrAddingP2PCollection       = errors.New(errAddingP2PCollection)
+	ErrRemovingP2PCollection     = errors.New(errRemovingP2PCollection)
+	ErrAddCollectionWithPatch    = errors.New(errAddCollectionWithPatch)
+	ErrCollectionIDDoesntMatch   = errors.New(errCollectionIDDoesntMatch)
+	ErrSchemaIDDoesntMatch       = errors.New(errSchemaIDDoesntMatch)
+	ErrCannotModifySchemaName    = errors.New(errCannotModifySchemaName)
+	ErrCannotSetVersionID        = errors.New(errCannotSetVersionID)
+	ErrCannotSetFieldID          = errors.New(errCannotSetFieldID)
+	ErrCannotAddRelationalField  = errors.New(errCannotAddRelationalField)
+	ErrDuplicateField            = errors.New(errDuplicateField)
+	ErrCannotMutateField         = errors.New(errCannotMutateField)
+	ErrCannotMutateFieldCRDTType = errors.New(errCannotMutateFieldCRDTType)
+	ErrCannotMoveField           = errors.New(errCannotMoveField)
+	ErrInvalidCRDTType           = errors.New(errInvalidCRDTType)
+	ErrCRDTKindMismatch          = errors.New(errCRDTKindMismatch)
+	ErrInvalidIndexType          = errors.New(errInvalidIndexType)
+	ErrIndexKindMismatch         = errors.New(errIndexKindMismatch)
+	ErrCannotDeleteField         = errors.New(errCannotDeleteField)
+	ErrFieldKindNotFound         = errors.New(errFieldKindNotFound)
+	ErrFailedToCompactDocument   = errors.New(errFailedToCompactDocument)
+	ErrCompactionHeightMissing   = errors.New("a compaction height or depth is required")
+	ErrVersionNotOfDocument      = errors.New(errVersionNotOfDocument)
+	ErrFailedToCheckDocument     = errors.New(errFailedToCheckDocument)
+	ErrFailedToReindexDocument   = errors.New(errFailedToReindexDocument)
+	ErrMissingBlock              = errors.New(errMissingBlock)
 )
 
 // NewErrFailedToGetHeads returns a new error indicating that the heads of a document
@@ -190,6 +211,15 @@ func NewErrCannotMutateField(id client.FieldID, name string) error {
 	)
 }
 
+func NewErrCannotMutateFieldCRDTType(name string, existingType, proposedType client.CType) error {
+	return errors.New(
+		errCannotMutateFieldCRDTType,
+		errors.NewKV("Name", name),
+		errors.NewKV("ExistingCRDTType", existingType),
+		errors.NewKV("ProposedCRDTType", proposedType),
+	)
+}
+
 func NewErrCannotMoveField(name string, proposedIndex, existingIndex int) error {
 	return errors.New(
 		errCannotMoveField,
@@ -207,6 +237,32 @@ func NewErrInvalidCRDTType(name string, crdtType client.CType) error {
 	)
 }
 
+func NewErrCRDTKindMismatch(name string, crdtType client.CType, kind client.FieldKind) error {
+	return errors.New(
+		errCRDTKindMismatch,
+		errors.NewKV("Name", name),
+		errors.NewKV("CRDTType", crdtType),
+		errors.NewKV("Kind", kind),
+	)
+}
+
+func NewErrInvalidIndexType(name string, indexType client.IndexType) error {
+	return errors.New(
+		errInvalidIndexType,
+		errors.NewKV("Name", name),
+		errors.NewKV("IndexType", indexType),
+	)
+}
+
+func NewErrIndexKindMismatch(name string, indexType client.IndexType, kind client.FieldKind) error {
+	return errors.New(
+		errIndexKindMismatch,
+		errors.NewKV("Name", name),
+		errors.NewKV("IndexType", indexType),
+		errors.NewKV("Kind", kind),
+	)
+}
+
 func NewErrCannotDeleteField(name string, id client.FieldID) error {
 	return errors.New(
 		errCannotDeleteField,
@@ -214,3 +270,37 @@ func NewErrCannotDeleteField(name string, id client.FieldID) error {
 		errors.NewKV("ID", id),
 	)
 }
+
+// NewErrFailedToCompactDocument returns a new error indicating that the history of the
+// given document could not be compacted.
+func NewErrFailedToCompactDocument(dockey string, inner error) error {
+	return errors.Wrap(errFailedToCompactDocument, inner, errors.NewKV("DocKey", dockey))
+}
+
+// NewErrFailedToCheckDocument returns a new error indicating that the consistency of the
+// given document could not be checked.
+func NewErrFailedToCheckDocument(dockey string, inner error) error {
+	return errors.Wrap(errFailedToCheckDocument, inner, errors.NewKV("DocKey", dockey))
+}
+
+// NewErrFailedToReindexDocument returns a new error indicating that the given document
+// could not be rebuilt from its blocks.
+func NewErrFailedToReindexDocument(dockey string, inner error) error {
+	return errors.Wrap(errFailedToReindexDocument, inner, errors.NewKV("DocKey", dockey))
+}
+
+// NewErrMissingBlock returns a new error indicating that the block of the given Cid
+// is missing from the blockstore.
+func NewErrMissingBlock(blockCid cid.Cid) error {
+	return errors.New(errMissingBlock, errors.NewKV("Cid", blockCid))
+}
+
+// NewErrVersionNotOfDocument returns a new error indicating that the given version is not
+// a composite commit of the given document.
+func NewErrVersionNotOfDocument(version string, dockey string) error {
+	return errors.New(
+		errVersionNotOfDocument,
+		errors.NewKV("Version", version),
+		errors.NewKV("DocKey", dockey),
+	)
+}
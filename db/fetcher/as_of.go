@@ -0,0 +1,298 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package fetcher
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	dag "github.com/ipfs/boxo/ipld/merkledag"
+	"github.com/ipfs/go-cid"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/core"
+	corecrdt "github.com/sourcenetwork/defradb/core/crdt"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/merkle/clock"
+)
+
+var (
+	// interface check
+	_ Fetcher = (*AsOfFetcher)(nil)
+)
+
+// AsOfFetcher is like the normal DocumentFetcher, except it returns each document of the
+// given spans at its latest version committed at or before a given time.
+//
+// The documents are listed by a DocumentFetcher, including the deleted ones as they may
+// not have been deleted at that time. The version of each document is then resolved from
+// the commit times recorded in its composite DAG, and the document state at that version
+// is reconstructed by a VersionedFetcher. Documents without any commit at or before the
+// given time are skipped, so are the commits without a recorded time.
+type AsOfFetcher struct {
+	asOf int64
+
+	col         *client.CollectionDescription
+	fields      []*client.FieldDescription
+	reverse     bool
+	showDeleted bool
+
+	txn datastore.Txn
+
+	// keys lists the documents of the spans, regardless of their current status.
+	keys *DocumentFetcher
+}
+
+// NewAsOfFetcher returns a new AsOfFetcher reading documents as of the given time.
+func NewAsOfFetcher(asOf time.Time) *AsOfFetcher {
+	return &AsOfFetcher{
+		asOf: asOf.UnixNano(),
+	}
+}
+
+// Init initializes the AsOfFetcher.
+func (af *AsOfFetcher) Init(
+	col *client.CollectionDescription,
+	fields []*client.FieldDescription,
+	reverse bool,
+	showDeleted bool,
+) error {
+	af.col = col
+	af.fields = fields
+	af.reverse = reverse
+	af.showDeleted = showDeleted
+
+	// the keys fetcher is reused, so that its iterators are closed when the fetcher is
+	// initialized again, as done for each parent by the joins of related documents.
+	if af.keys == nil {
+		af.keys = new(DocumentFetcher)
+	}
+	return af.keys.Init(col, fields, reverse, true)
+}
+
+// Start starts listing the documents of the given spans.
+func (af *AsOfFetcher) Start(ctx context.Context, txn datastore.Txn, spans core.Spans) error {
+	if af.col == nil {
+		return client.NewErrUninitializeProperty("AsOfFetcher", "CollectionDescription")
+	}
+
+	af.txn = txn
+	return af.keys.Start(ctx, txn, spans)
+}
+
+// FetchNext returns the next encoded document as of the fetcher time.
+func (af *AsOfFetcher) FetchNext(ctx context.Context) (*encodedDocument, error) {
+	var encdoc *encodedDocument
+	err := af.fetchNext(ctx, func(vf *VersionedFetcher) (bool, error) {
+		var err error
+		encdoc, err = vf.FetchNext(ctx)
+		return encdoc != nil, err
+	})
+	return encdoc, err
+}
+
+// FetchNextDecoded returns the next decoded document as of the fetcher time.
+func (af *AsOfFetcher) FetchNextDecoded(ctx context.Context) (*client.Document, error) {
+	var doc *client.Document
+	err := af.fetchNext(ctx, func(vf *VersionedFetcher) (bool, error) {
+		var err error
+		doc, err = vf.FetchNextDecoded(ctx)
+		return doc != nil, err
+	})
+	return doc, err
+}
+
+// FetchNextDoc returns the next document as of the fetcher time as a core.Doc.
+// The first return value is the parsed document key.
+func (af *AsOfFetcher) FetchNextDoc(
+	ctx context.Context,
+	mapping *core.DocumentMapping,
+) ([]byte, core.Doc, error) {
+	var key []byte
+	var doc core.Doc
+	err := af.fetchNext(ctx, func(vf *VersionedFetcher) (bool, error) {
+		var err error
+		key, doc, err = vf.FetchNextDoc(ctx, mapping)
+		return len(doc.Fields) > 0, err
+	})
+	if err != nil {
+		return nil, core.Doc{}, err
+	}
+	return key, doc, nil
+}
+
+// fetchNext calls the given fetch function with a VersionedFetcher started at the version
+// of each of the next documents, until it returns true or there are no more documents.
+// The document may not be returned at its version, if it was deleted at that time.
+func (af *AsOfFetcher) fetchNext(ctx context.Context, fetch func(*VersionedFetcher) (bool, error)) error {
+	for {
+		vf, err := af.nextVersionedFetcher(ctx)
+		if err != nil || vf == nil {
+			return err
+		}
+
+		found, err := fetch(vf)
+		if closeErr := vf.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil || found {
+			return err
+		}
+	}
+}
+
+// Close closes the AsOfFetcher.
+func (af *AsOfFetcher) Close() error {
+	return af.keys.Close()
+}
+
+// nextVersionedFetcher returns a VersionedFetcher started at the version of the next
+// document that has one at the fetcher time, or nil if there are no more documents.
+func (af *AsOfFetcher) nextVersionedFetcher(ctx context.Context) (*VersionedFetcher, error) {
+	for {
+		encdoc, err := af.nextKey(ctx)
+		if err != nil || encdoc == nil {
+			return nil, err
+		}
+
+		dockey := string(encdoc.Key)
+		version, found, err := getVersionAsOf(ctx, af.txn, dockey, af.asOf)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			continue
+		}
+
+		vf := new(VersionedFetcher)
+		if err := vf.Init(af.col, af.fields, af.reverse, af.showDeleted); err != nil {
+			return nil, err
+		}
+		if err := vf.Start(ctx, af.txn, NewVersionedSpan(core.DataStoreKey{DocKey: dockey}, version)); err != nil {
+			return nil, err
+		}
+		return vf, nil
+	}
+}
+
+// nextKey returns the next document listed by the keys fetcher, in the same order
+// as the DocumentFetcher would return them.
+func (af *AsOfFetcher) nextKey(ctx context.Context) (*encodedDocument, error) {
+	df := af.keys
+	ddf := df.deletedDocFetcher
+	if !ddf.kvEnd && (df.kvEnd ||
+		(af.reverse && ddf.kv.Key.DocKey > df.kv.Key.DocKey) ||
+		(!af.reverse && ddf.kv.Key.DocKey < df.kv.Key.DocKey)) {
+		return ddf.FetchNext(ctx)
+	}
+	return df.FetchNext(ctx)
+}
+
+// getVersionAsOf returns the Cid of the latest composite commit of the given document
+// made at or before the given unix time in nanoseconds.
+//
+// The composite DAG is walked from its heads and each path stops at the first commit
+// made at or before that time, as the commits it descends from are older. Of those,
+// the one with the latest time wins, ties are resolved by the highest priority and
+// then by the Cid.
+func getVersionAsOf(
+	ctx context.Context,
+	txn datastore.Txn,
+	dockey string,
+	asOf int64,
+) (cid.Cid, bool, error) {
+	headset := clock.NewHeadSet(
+		txn.Headstore(),
+		core.HeadStoreKey{DocKey: dockey, FieldId: core.COMPOSITE_NAMESPACE},
+	)
+	heads, _, err := headset.List(ctx)
+	if err != nil {
+		return cid.Undef, false, err
+	}
+
+	var version cid.Cid
+	var versionDelta *corecrdt.CompositeDAGDelta
+	visited := map[cid.Cid]struct{}{}
+	queue := heads
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if _, ok := visited[current]; ok {
+			continue
+		}
+		visited[current] = struct{}{}
+
+		hasBlock, err := txn.DAGstore().Has(ctx, current)
+		if err != nil {
+			return cid.Undef, false, err
+		}
+		if !hasBlock {
+			// the history older than a compaction snapshot is not available anymore.
+			snapshot, isCompacted, err := clock.GetCompactedSnapshot(ctx, txn.Systemstore(), current)
+			if err != nil {
+				return cid.Undef, false, err
+			}
+			if isCompacted {
+				queue = append(queue, snapshot)
+				continue
+			}
+		}
+
+		block, err := txn.DAGstore().Get(ctx, current)
+		if err != nil {
+			return cid.Undef, false, err
+		}
+		nd, err := dag.DecodeProtobuf(block.RawData())
+		if err != nil {
+			return cid.Undef, false, err
+		}
+		delta, err := corecrdt.CompositeDAG{}.DeltaDecode(nd)
+		if err != nil {
+			return cid.Undef, false, err
+		}
+		compositeDelta := delta.(*corecrdt.CompositeDAGDelta)
+
+		if compositeDelta.CommitTime != 0 && compositeDelta.CommitTime <= asOf {
+			if versionDelta == nil || isLaterVersion(current, compositeDelta, version, versionDelta) {
+				version = current
+				versionDelta = compositeDelta
+			}
+			continue
+		}
+
+		for _, l := range nd.Links() {
+			if l.Name == core.HEAD {
+				queue = append(queue, l.Cid)
+			}
+		}
+	}
+
+	return version, versionDelta != nil, nil
+}
+
+// isLaterVersion returns true if the commit a is a later version of a document than
+// the commit b.
+func isLaterVersion(
+	a cid.Cid,
+	aDelta *corecrdt.CompositeDAGDelta,
+	b cid.Cid,
+	bDelta *corecrdt.CompositeDAGDelta,
+) bool {
+	if aDelta.CommitTime != bDelta.CommitTime {
+		return aDelta.CommitTime > bDelta.CommitTime
+	}
+	if aDelta.Priority != bDelta.Priority {
+		return aDelta.Priority > bDelta.Priority
+	}
+	return strings.Compare(a.String(), b.String()) > 0
+}
@@ -35,6 +35,10 @@ type encProperty struct {
 func (e encProperty) Decode() (client.CType, any, error) {
 	ctype := client.CType(e.Raw[0])
 	buf := e.Raw[1:]
+	if len(buf) == 0 {
+		// the value of a cleared field is stored as an empty byte array
+		return ctype, nil, nil
+	}
 	var val any
 	err := cbor.Unmarshal(buf, &val)
 	if err != nil {
@@ -11,6 +11,8 @@
 package fetcher
 
 import (
+	"github.com/ipfs/go-cid"
+
 	"github.com/sourcenetwork/defradb/errors"
 )
 
@@ -25,6 +27,7 @@ const (
 	errVFetcherFailedToDecodeNode   string = "(version fetcher) failed to decode protobuf"
 	errVFetcherFailedToGetDagLink   string = "(version fetcher) failed to get node link from DAG"
 	errFailedToGetDagNode           string = "failed to get DAG Node"
+	errVersionCompacted             string = "the requested version has been compacted"
 )
 
 var (
@@ -39,6 +42,7 @@ var (
 	ErrVFetcherFailedToGetDagLink   = errors.New(errVFetcherFailedToGetDagLink)
 	ErrFailedToGetDagNode           = errors.New(errFailedToGetDagNode)
 	ErrSingleSpanOnly               = errors.New("spans must contain only a single entry")
+	ErrVersionCompacted             = errors.New(errVersionCompacted)
 )
 
 // NewErrFieldIdNotFound returns an error indicating that the given FieldId was not found.
@@ -91,3 +95,13 @@ func NewErrVFetcherFailedToGetDagLink(inner error) error {
 func NewErrFailedToGetDagNode(inner error) error {
 	return errors.Wrap(errFailedToGetDagNode, inner)
 }
+
+// NewErrVersionCompacted returns an error indicating that the requested version has been
+// folded into the given snapshot by a history compaction, and can no longer be retrieved.
+func NewErrVersionCompacted(version cid.Cid, snapshot cid.Cid) error {
+	return errors.New(
+		errVersionCompacted,
+		errors.NewKV("Version", version),
+		errors.NewKV("Snapshot", snapshot),
+	)
+}
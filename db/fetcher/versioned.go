@@ -26,6 +26,7 @@ import (
 	"github.com/sourcenetwork/defradb/db/base"
 	"github.com/sourcenetwork/defradb/errors"
 	"github.com/sourcenetwork/defradb/events"
+	"github.com/sourcenetwork/defradb/merkle/clock"
 	"github.com/sourcenetwork/defradb/merkle/crdt"
 )
 
@@ -202,8 +203,24 @@ func (vf *VersionedFetcher) seekTo(c cid.Cid) error {
 	// reinit the queued cids list
 	vf.queuedCids = list.New()
 
+	// A version that has been folded into a snapshot by a history compaction can not be
+	// reconstructed, the snapshot holds a later state than the one requested.
+	hasBlock, err := vf.txn.DAGstore().Has(vf.ctx, c)
+	if err != nil {
+		return NewErrVFetcherFailedToFindBlock(err)
+	}
+	if !hasBlock {
+		snapshot, isCompacted, err := clock.GetCompactedSnapshot(vf.ctx, vf.txn.Systemstore(), c)
+		if err != nil {
+			return NewErrVFetcherFailedToFindBlock(err)
+		}
+		if isCompacted {
+			return NewErrVersionCompacted(c, snapshot)
+		}
+	}
+
 	// recursive step through the graph
-	err := vf.seekNext(c, true)
+	err = vf.seekNext(c, true)
 	if err != nil {
 		return err
 	}
@@ -264,6 +281,22 @@ func (vf *VersionedFetcher) seekNext(c cid.Cid, topParent bool) error {
 		return nil
 	}
 
+	hasBlock, err := vf.txn.DAGstore().Has(vf.ctx, c)
+	if err != nil {
+		return NewErrVFetcherFailedToFindBlock(err)
+	}
+	if !hasBlock {
+		// the parent of a retained block may have been folded into a snapshot by a
+		// history compaction, in which case the snapshot holds the state it had.
+		snapshot, isCompacted, err := clock.GetCompactedSnapshot(vf.ctx, vf.txn.Systemstore(), c)
+		if err != nil {
+			return NewErrVFetcherFailedToFindBlock(err)
+		}
+		if isCompacted {
+			return vf.seekNext(snapshot, topParent)
+		}
+	}
+
 	blk, err := vf.txn.DAGstore().Get(vf.ctx, c)
 	if err != nil {
 		return NewErrVFetcherFailedToGetBlock(err)
@@ -0,0 +1,59 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+/*
+Package fulltext provides the full-text indexes of string fields.
+
+Values are analyzed into terms by splitting them into words, lower-casing the words and
+reducing them to their stems. The index holds the number of times each term occurs in the
+value of each document, from which the documents matching a search are ranked by relevance.
+*/
+package fulltext
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Analyze returns the terms of the given text, in the order in which they occur.
+//
+// The text is split into words on any character that is not a letter or a digit, the
+// words are then lower-cased and reduced to their stems.
+func Analyze(text string) []string {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	terms := make([]string, len(words))
+	for i, word := range words {
+		terms[i] = Stem(strings.ToLower(word))
+	}
+	return terms
+}
+
+// Frequencies returns the number of times each term occurs in the given text.
+func Frequencies(text string) map[string]uint64 {
+	frequencies := map[string]uint64{}
+	for _, term := range Analyze(text) {
+		frequencies[term]++
+	}
+	return frequencies
+}
+
+// Matches returns true if the given text contains any of the terms of the given query.
+func Matches(text string, query string) bool {
+	frequencies := Frequencies(text)
+	for _, term := range Analyze(query) {
+		if _, ok := frequencies[term]; ok {
+			return true
+		}
+	}
+	return false
+}
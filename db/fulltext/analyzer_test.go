@@ -0,0 +1,65 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package fulltext
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStem(t *testing.T) {
+	words := map[string]string{
+		"caresses":    "caress",
+		"ponies":      "poni",
+		"cats":        "cat",
+		"feed":        "feed",
+		"agreed":      "agre",
+		"plastered":   "plaster",
+		"motoring":    "motor",
+		"hopping":     "hop",
+		"filing":      "file",
+		"happy":       "happi",
+		"relational":  "relat",
+		"connection":  "connect",
+		"connected":   "connect",
+		"generalize":  "gener",
+		"hopefulness": "hope",
+		"running":     "run",
+		"runs":        "run",
+		"is":          "is",
+		"web3":        "web3",
+	}
+	for word, stem := range words {
+		require.Equal(t, stem, Stem(word), word)
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	terms := Analyze("Running, RUNS and the run-down Café!")
+	require.Equal(t, []string{"run", "run", "and", "the", "run", "down", "café"}, terms)
+}
+
+func TestAnalyzeEmpty(t *testing.T) {
+	require.Empty(t, Analyze(" ,.! "))
+}
+
+func TestFrequencies(t *testing.T) {
+	frequencies := Frequencies("Running, running and more running")
+	require.Equal(t, map[string]uint64{"run": 3, "and": 1, "more": 1}, frequencies)
+}
+
+func TestMatches(t *testing.T) {
+	require.True(t, Matches("Likes hiking in the mountains", "hikes"))
+	require.True(t, Matches("Likes hiking in the mountains", "swims MOUNTAIN"))
+	require.False(t, Matches("Likes hiking in the mountains", "swimming"))
+	require.False(t, Matches("Likes hiking in the mountains", ""))
+}
@@ -0,0 +1,25 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package fulltext
+
+import "github.com/sourcenetwork/defradb/errors"
+
+const (
+	errInvalidIndexEntry string = "invalid full-text index entry"
+)
+
+var (
+	ErrInvalidIndexEntry = errors.New(errInvalidIndexEntry)
+)
+
+func NewErrInvalidIndexEntry(key string) error {
+	return errors.New(errInvalidIndexEntry, errors.NewKV("Key", key))
+}
@@ -0,0 +1,375 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package fulltext
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"sort"
+
+	"github.com/fxamacker/cbor/v2"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"github.com/sourcenetwork/immutable"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/db/base"
+	"github.com/sourcenetwork/defradb/errors"
+)
+
+// The parameters of the Okapi BM25 ranking function.
+const (
+	// bm25K1 controls how quickly the score of a term saturates as it occurs more often.
+	bm25K1 = 1.2
+	// bm25B controls how much the score of a term is reduced in longer values.
+	bm25B = 0.75
+)
+
+// document is the record of the terms of a document that were last indexed.
+type document struct {
+	_ struct{} `cbor:",toarray"`
+
+	// The number of terms in the value of the document.
+	Length uint64
+
+	// The number of times each term occurs in the value of the document.
+	Terms map[string]uint64
+}
+
+// Match is a document matching a full-text search.
+type Match struct {
+	// The key of the matching document.
+	DocKey string
+
+	// The relevance of the document to the search, the higher the score the more relevant
+	// the document is.
+	Score float64
+}
+
+// IndexedFields returns the fields of the given collection that have a full-text index.
+func IndexedFields(desc client.CollectionDescription) []client.FieldDescription {
+	fields := []client.FieldDescription{}
+	for _, field := range desc.Schema.Fields {
+		if field.Index == client.FULLTEXT_INDEX {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// Update replaces the indexed terms of the given field of the given document with the terms
+// of the given value.
+//
+// If the value is empty the document is removed from the index of the field.
+func Update(
+	ctx context.Context,
+	store datastore.DSReaderWriter,
+	desc client.CollectionDescription,
+	field client.FieldDescription,
+	dockey string,
+	value immutable.Option[string],
+) error {
+	if err := remove(ctx, store, desc, field, dockey); err != nil {
+		return err
+	}
+	if !value.HasValue() {
+		return nil
+	}
+
+	doc := document{
+		Terms: Frequencies(value.Value()),
+	}
+	for term, frequency := range doc.Terms {
+		doc.Length += frequency
+
+		key := core.FullTextTokenKey{
+			CollectionID: desc.IDString(),
+			FieldID:      field.ID.String(),
+			Token:        term,
+			DocKey:       dockey,
+		}
+		if err := store.Put(ctx, key.ToDS(), binary.AppendUvarint(nil, frequency)); err != nil {
+			return err
+		}
+	}
+
+	buf, err := cbor.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	key := core.FullTextDocumentKey{
+		CollectionID: desc.IDString(),
+		FieldID:      field.ID.String(),
+		DocKey:       dockey,
+	}
+	return store.Put(ctx, key.ToDS(), buf)
+}
+
+// Refresh updates all the full-text indexes of the given collection from the values of the given
+// document, as they are currently held in the given store.
+//
+// It is used once changes have been merged into the state of the document without the
+// resulting values being known, such as when changes are received from other peers.
+func Refresh(
+	ctx context.Context,
+	store datastore.DSReaderWriter,
+	desc client.CollectionDescription,
+	dockey string,
+) error {
+	for _, field := range IndexedFields(desc) {
+		key := base.MakeDocKey(desc, dockey).WithValueFlag().WithFieldId(field.ID.String())
+		value, err := getStoredValue(ctx, store, key)
+		if err != nil {
+			return err
+		}
+		if err := Update(ctx, store, desc, field, dockey, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Remove removes the given document from all the full-text indexes of the given collection.
+func Remove(
+	ctx context.Context,
+	store datastore.DSReaderWriter,
+	desc client.CollectionDescription,
+	dockey string,
+) error {
+	for _, field := range IndexedFields(desc) {
+		if err := remove(ctx, store, desc, field, dockey); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// remove removes the given document from the index of the given field.
+func remove(
+	ctx context.Context,
+	store datastore.DSReaderWriter,
+	desc client.CollectionDescription,
+	field client.FieldDescription,
+	dockey string,
+) error {
+	key := core.FullTextDocumentKey{
+		CollectionID: desc.IDString(),
+		FieldID:      field.ID.String(),
+		DocKey:       dockey,
+	}
+	doc, hasDoc, err := getDocument(ctx, store, key.ToDS())
+	if err != nil || !hasDoc {
+		return err
+	}
+
+	for term := range doc.Terms {
+		tokenKey := core.FullTextTokenKey{
+			CollectionID: desc.IDString(),
+			FieldID:      field.ID.String(),
+			Token:        term,
+			DocKey:       dockey,
+		}
+		if err := store.Delete(ctx, tokenKey.ToDS()); err != nil {
+			return err
+		}
+	}
+	return store.Delete(ctx, key.ToDS())
+}
+
+// Search returns the documents of which the value of the given field contains any of the terms
+// of the given query, the most relevant documents first.
+//
+// Documents are ranked using the Okapi BM25 ranking function, documents of equal relevance are
+// returned in key order.
+func Search(
+	ctx context.Context,
+	store datastore.DSReaderWriter,
+	desc client.CollectionDescription,
+	field client.FieldDescription,
+	text string,
+) ([]Match, error) {
+	// the terms are deduplicated and sorted so that the scores are summed in a consistent order
+	terms := []string{}
+	seen := map[string]struct{}{}
+	for _, term := range Analyze(text) {
+		if _, ok := seen[term]; !ok {
+			seen[term] = struct{}{}
+			terms = append(terms, term)
+		}
+	}
+	sort.Strings(terms)
+	if len(terms) == 0 {
+		return []Match{}, nil
+	}
+
+	lengths, err := getDocumentLengths(ctx, store, desc, field)
+	if err != nil {
+		return nil, err
+	}
+	if len(lengths) == 0 {
+		return []Match{}, nil
+	}
+	var totalLength uint64
+	for _, length := range lengths {
+		totalLength += length
+	}
+	averageLength := float64(totalLength) / float64(len(lengths))
+
+	scores := map[string]float64{}
+	for _, term := range terms {
+		frequencies, err := getTermFrequencies(ctx, store, desc, field, term)
+		if err != nil {
+			return nil, err
+		}
+
+		documentCount := float64(len(lengths))
+		matchCount := float64(len(frequencies))
+		idf := math.Log(1 + (documentCount-matchCount+0.5)/(matchCount+0.5))
+
+		for dockey, frequency := range frequencies {
+			tf := float64(frequency)
+			lengthRatio := float64(lengths[dockey]) / averageLength
+			scores[dockey] += idf * tf * (bm25K1 + 1) / (tf + bm25K1*(1-bm25B+bm25B*lengthRatio))
+		}
+	}
+
+	matches := make([]Match, 0, len(scores))
+	for dockey, score := range scores {
+		matches = append(matches, Match{DocKey: dockey, Score: score})
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].DocKey < matches[j].DocKey
+	})
+	return matches, nil
+}
+
+// getDocumentLengths returns the number of terms in the indexed values of the documents in the
+// index of the given field.
+func getDocumentLengths(
+	ctx context.Context,
+	store datastore.DSReaderWriter,
+	desc client.CollectionDescription,
+	field client.FieldDescription,
+) (map[string]uint64, error) {
+	prefix := core.FullTextDocumentKey{
+		CollectionID: desc.IDString(),
+		FieldID:      field.ID.String(),
+	}
+	q, err := store.Query(ctx, query.Query{
+		Prefix: prefix.ToString(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = q.Close()
+	}()
+
+	lengths := map[string]uint64{}
+	for res := range q.Next() {
+		if res.Error != nil {
+			return nil, res.Error
+		}
+		var doc document
+		if err := cbor.Unmarshal(res.Value, &doc); err != nil {
+			return nil, err
+		}
+		lengths[ds.RawKey(res.Key).BaseNamespace()] = doc.Length
+	}
+	return lengths, nil
+}
+
+// getTermFrequencies returns the number of times the given term occurs in each of the documents
+// containing it, in the index of the given field.
+func getTermFrequencies(
+	ctx context.Context,
+	store datastore.DSReaderWriter,
+	desc client.CollectionDescription,
+	field client.FieldDescription,
+	term string,
+) (map[string]uint64, error) {
+	prefix := core.FullTextTokenKey{
+		CollectionID: desc.IDString(),
+		FieldID:      field.ID.String(),
+		Token:        term,
+	}
+	q, err := store.Query(ctx, query.Query{
+		Prefix: prefix.ToString(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = q.Close()
+	}()
+
+	frequencies := map[string]uint64{}
+	for res := range q.Next() {
+		if res.Error != nil {
+			return nil, res.Error
+		}
+		frequency, n := binary.Uvarint(res.Value)
+		if n <= 0 {
+			return nil, NewErrInvalidIndexEntry(res.Key)
+		}
+		frequencies[ds.RawKey(res.Key).BaseNamespace()] = frequency
+	}
+	return frequencies, nil
+}
+
+// getStoredValue returns the string value held by the given field key, if any.
+//
+// Fields of deleted documents are not held under value keys, so they have no value.
+func getStoredValue(
+	ctx context.Context,
+	store datastore.DSReaderWriter,
+	key core.DataStoreKey,
+) (immutable.Option[string], error) {
+	buf, err := store.Get(ctx, key.ToDS())
+	if errors.Is(err, ds.ErrNotFound) {
+		return immutable.None[string](), nil
+	}
+	if err != nil {
+		return immutable.None[string](), err
+	}
+	// the first byte is the CRDT type of the value, cleared values are otherwise empty
+	if len(buf) <= 1 {
+		return immutable.None[string](), nil
+	}
+
+	var value string
+	if err := cbor.Unmarshal(buf[1:], &value); err != nil {
+		return immutable.None[string](), err
+	}
+	return immutable.Some(value), nil
+}
+
+func getDocument(ctx context.Context, store datastore.DSReaderWriter, key ds.Key) (document, bool, error) {
+	buf, err := store.Get(ctx, key)
+	if errors.Is(err, ds.ErrNotFound) {
+		return document{}, false, nil
+	}
+	if err != nil {
+		return document{}, false, err
+	}
+
+	var doc document
+	if err := cbor.Unmarshal(buf, &doc); err != nil {
+		return document{}, false, err
+	}
+	return doc, true, nil
+}
@@ -0,0 +1,407 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package fulltext
+
+// Stem reduces the given lower-case English word to its stem using the Porter stemming
+// algorithm, so that words sharing a stem, such as "connected" and "connection", match.
+//
+// Words that are not made up solely of the letters a to z, and words shorter than three
+// letters, are returned as given.
+func Stem(word string) string {
+	if len(word) <= 2 {
+		return word
+	}
+	for i := 0; i < len(word); i++ {
+		if word[i] < 'a' || word[i] > 'z' {
+			return word
+		}
+	}
+
+	s := &stemmer{b: []byte(word), k: len(word) - 1}
+	s.step1ab()
+	if s.k > 0 {
+		s.step1c()
+		s.step2()
+		s.step3()
+		s.step4()
+		s.step5()
+	}
+	return string(s.b[:s.k+1])
+}
+
+// stemmer holds the state of a word being stemmed.
+//
+// b[0:k+1] is the current stem of the word, and j is the end of the stem preceding the
+// suffix last matched by ends.
+type stemmer struct {
+	b []byte
+	k int
+	j int
+}
+
+// isConsonant returns true if b[i] is a consonant.
+//
+// 'y' is a consonant if it is the first letter, or if it follows a vowel.
+func (s *stemmer) isConsonant(i int) bool {
+	switch s.b[i] {
+	case 'a', 'e', 'i', 'o', 'u':
+		return false
+	case 'y':
+		if i == 0 {
+			return true
+		}
+		return !s.isConsonant(i - 1)
+	default:
+		return true
+	}
+}
+
+// measure returns the number of vowel-consonant sequences in b[0:j+1].
+//
+// Writing c for a consonant sequence and v for a vowel sequence, any word is of the form
+// [c](vc){m}[v], and the measure is m.
+func (s *stemmer) measure() int {
+	n := 0
+	i := 0
+	for {
+		if i > s.j {
+			return n
+		}
+		if !s.isConsonant(i) {
+			break
+		}
+		i++
+	}
+	i++
+	for {
+		for {
+			if i > s.j {
+				return n
+			}
+			if s.isConsonant(i) {
+				break
+			}
+			i++
+		}
+		i++
+		n++
+		for {
+			if i > s.j {
+				return n
+			}
+			if !s.isConsonant(i) {
+				break
+			}
+			i++
+		}
+		i++
+	}
+}
+
+// hasVowelInStem returns true if b[0:j+1] contains a vowel.
+func (s *stemmer) hasVowelInStem() bool {
+	for i := 0; i <= s.j; i++ {
+		if !s.isConsonant(i) {
+			return true
+		}
+	}
+	return false
+}
+
+// endsWithDoubleConsonant returns true if b[j-1:j+1] is a double consonant.
+func (s *stemmer) endsWithDoubleConsonant(j int) bool {
+	if j < 1 || s.b[j] != s.b[j-1] {
+		return false
+	}
+	return s.isConsonant(j)
+}
+
+// endsWithCVC returns true if b[i-2:i+1] is consonant-vowel-consonant, and the last
+// consonant is not 'w', 'x' or 'y'.
+//
+// This is used when restoring an 'e' at the end of a short word, e.g. cav(e), lov(e),
+// hop(e), crim(e), but snow, box, tray.
+func (s *stemmer) endsWithCVC(i int) bool {
+	if i < 2 || !s.isConsonant(i) || s.isConsonant(i-1) || !s.isConsonant(i-2) {
+		return false
+	}
+	switch s.b[i] {
+	case 'w', 'x', 'y':
+		return false
+	}
+	return true
+}
+
+// ends returns true if b[0:k+1] ends with the given suffix, setting j to the end of the
+// stem preceding it.
+func (s *stemmer) ends(suffix string) bool {
+	length := len(suffix)
+	if length > s.k+1 {
+		return false
+	}
+	if string(s.b[s.k-length+1:s.k+1]) != suffix {
+		return false
+	}
+	s.j = s.k - length
+	return true
+}
+
+// setTo replaces the suffix following b[0:j+1] with the given value.
+func (s *stemmer) setTo(value string) {
+	s.b = append(s.b[:s.j+1], value...)
+	s.k = s.j + len(value)
+}
+
+// replace replaces the matched suffix with the given value, if the measure of the stem
+// preceding it is greater than zero.
+func (s *stemmer) replace(value string) {
+	if s.measure() > 0 {
+		s.setTo(value)
+	}
+}
+
+// step1ab removes plurals and -ed or -ing, e.g.
+//
+//	caresses  ->  caress
+//	ponies    ->  poni
+//	cats      ->  cat
+//	feed      ->  feed
+//	agreed    ->  agree
+//	plastered ->  plaster
+//	motoring  ->  motor
+//	hopping   ->  hop
+//	filing    ->  file
+func (s *stemmer) step1ab() {
+	if s.b[s.k] == 's' {
+		switch {
+		case s.ends("sses"):
+			s.k -= 2
+		case s.ends("ies"):
+			s.setTo("i")
+		case s.b[s.k-1] != 's':
+			s.k--
+		}
+	}
+
+	if s.ends("eed") {
+		if s.measure() > 0 {
+			s.k--
+		}
+		return
+	}
+
+	if (s.ends("ed") || s.ends("ing")) && s.hasVowelInStem() {
+		s.k = s.j
+		switch {
+		case s.ends("at"):
+			s.setTo("ate")
+		case s.ends("bl"):
+			s.setTo("ble")
+		case s.ends("iz"):
+			s.setTo("ize")
+		case s.endsWithDoubleConsonant(s.k):
+			switch s.b[s.k] {
+			case 'l', 's', 'z':
+			default:
+				s.k--
+			}
+		default:
+			s.j = s.k
+			if s.measure() == 1 && s.endsWithCVC(s.k) {
+				s.setTo("e")
+			}
+		}
+	}
+}
+
+// step1c turns a terminal 'y' into an 'i' when there is another vowel in the stem.
+func (s *stemmer) step1c() {
+	if s.ends("y") && s.hasVowelInStem() {
+		s.b[s.k] = 'i'
+	}
+}
+
+// step2 maps double suffixes to single ones, e.g. -ization (= -ize plus -ation) maps to
+// -ize, provided the measure of the preceding stem is greater than zero.
+func (s *stemmer) step2() {
+	if s.k < 1 {
+		return
+	}
+	switch s.b[s.k-1] {
+	case 'a':
+		if s.ends("ational") {
+			s.replace("ate")
+		} else if s.ends("tional") {
+			s.replace("tion")
+		}
+	case 'c':
+		if s.ends("enci") {
+			s.replace("ence")
+		} else if s.ends("anci") {
+			s.replace("ance")
+		}
+	case 'e':
+		if s.ends("izer") {
+			s.replace("ize")
+		}
+	case 'l':
+		if s.ends("bli") {
+			s.replace("ble")
+		} else if s.ends("alli") {
+			s.replace("al")
+		} else if s.ends("entli") {
+			s.replace("ent")
+		} else if s.ends("eli") {
+			s.replace("e")
+		} else if s.ends("ousli") {
+			s.replace("ous")
+		}
+	case 'o':
+		if s.ends("ization") {
+			s.replace("ize")
+		} else if s.ends("ation") {
+			s.replace("ate")
+		} else if s.ends("ator") {
+			s.replace("ate")
+		}
+	case 's':
+		if s.ends("alism") {
+			s.replace("al")
+		} else if s.ends("iveness") {
+			s.replace("ive")
+		} else if s.ends("fulness") {
+			s.replace("ful")
+		} else if s.ends("ousness") {
+			s.replace("ous")
+		}
+	case 't':
+		if s.ends("aliti") {
+			s.replace("al")
+		} else if s.ends("iviti") {
+			s.replace("ive")
+		} else if s.ends("biliti") {
+			s.replace("ble")
+		}
+	case 'g':
+		if s.ends("logi") {
+			s.replace("log")
+		}
+	}
+}
+
+// step3 deals with -ic-, -full, -ness etc, in a similar way to step2.
+func (s *stemmer) step3() {
+	switch s.b[s.k] {
+	case 'e':
+		if s.ends("icate") {
+			s.replace("ic")
+		} else if s.ends("ative") {
+			s.replace("")
+		} else if s.ends("alize") {
+			s.replace("al")
+		}
+	case 'i':
+		if s.ends("iciti") {
+			s.replace("ic")
+		}
+	case 'l':
+		if s.ends("ical") {
+			s.replace("ic")
+		} else if s.ends("ful") {
+			s.replace("")
+		}
+	case 's':
+		if s.ends("ness") {
+			s.replace("")
+		}
+	}
+}
+
+// step4 removes -ant, -ence etc, when the measure of the preceding stem is greater than one.
+func (s *stemmer) step4() {
+	if s.k < 1 {
+		return
+	}
+	switch s.b[s.k-1] {
+	case 'a':
+		if !s.ends("al") {
+			return
+		}
+	case 'c':
+		if !s.ends("ance") && !s.ends("ence") {
+			return
+		}
+	case 'e':
+		if !s.ends("er") {
+			return
+		}
+	case 'i':
+		if !s.ends("ic") {
+			return
+		}
+	case 'l':
+		if !s.ends("able") && !s.ends("ible") {
+			return
+		}
+	case 'n':
+		if !s.ends("ant") && !s.ends("ement") && !s.ends("ment") && !s.ends("ent") {
+			return
+		}
+	case 'o':
+		if s.ends("ion") {
+			if s.j < 0 || (s.b[s.j] != 's' && s.b[s.j] != 't') {
+				return
+			}
+		} else if !s.ends("ou") {
+			return
+		}
+	case 's':
+		if !s.ends("ism") {
+			return
+		}
+	case 't':
+		if !s.ends("ate") && !s.ends("iti") {
+			return
+		}
+	case 'u':
+		if !s.ends("ous") {
+			return
+		}
+	case 'v':
+		if !s.ends("ive") {
+			return
+		}
+	case 'z':
+		if !s.ends("ize") {
+			return
+		}
+	default:
+		return
+	}
+	if s.measure() > 1 {
+		s.k = s.j
+	}
+}
+
+// step5 removes a final -e if the measure of the preceding stem is greater than one, and
+// changes -ll to -l if the measure of the word is greater than one.
+func (s *stemmer) step5() {
+	s.j = s.k
+	if s.b[s.k] == 'e' {
+		m := s.measure()
+		if m > 1 || (m == 1 && !s.endsWithCVC(s.k-1)) {
+			s.k--
+		}
+	}
+	if s.b[s.k] == 'l' && s.endsWithDoubleConsonant(s.k) && s.measure() > 1 {
+		s.k--
+	}
+}
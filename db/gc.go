@@ -0,0 +1,235 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"context"
+	"encoding/binary"
+	"sort"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/logging"
+)
+
+// collectGarbage marks the blocks reachable from the heads of the headstore and sweeps the
+// remaining blocks of the blockstore.
+func (db *db) collectGarbage(
+	ctx context.Context,
+	txn datastore.Txn,
+	opts client.GCOptions,
+) (*client.GCResult, error) {
+	heads, err := getAllHeads(ctx, txn)
+	if err != nil {
+		return nil, err
+	}
+
+	reachable, err := getDAGBlockCids(ctx, txn, heads, false)
+	if err != nil {
+		return nil, err
+	}
+	marked := make(map[cid.Cid]struct{}, len(reachable))
+	for _, blockCid := range reachable {
+		marked[blockCid] = struct{}{}
+	}
+
+	// the blocks are listed first so that they are not deleted while the store is iterated
+	blockCids, err := getAllBlockCids(ctx, txn)
+	if err != nil {
+		return nil, err
+	}
+	unreachable := []cid.Cid{}
+	for _, blockCid := range blockCids {
+		if _, ok := marked[blockCid]; !ok {
+			unreachable = append(unreachable, blockCid)
+		}
+	}
+	sort.Slice(unreachable, func(i, j int) bool {
+		return unreachable[i].String() < unreachable[j].String()
+	})
+
+	unreachableSince, err := getUnreachableBlocks(ctx, txn)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &client.GCResult{
+		Reachable:   int64(len(reachable)),
+		Unreachable: make([]string, 0, len(unreachable)),
+	}
+	gracePeriod := db.gcGracePeriod
+	if opts.GracePeriod.HasValue() {
+		gracePeriod = opts.GracePeriod.Value()
+	}
+	now := time.Now()
+	for _, blockCid := range unreachable {
+		result.Unreachable = append(result.Unreachable, blockCid.String())
+		if opts.DryRun {
+			continue
+		}
+
+		since, wasUnreachable := unreachableSince[blockCid]
+		delete(unreachableSince, blockCid)
+		if !wasUnreachable {
+			since = now
+		}
+		if now.Sub(since) < gracePeriod {
+			// the block may be part of a sync whose heads are not committed yet
+			if !wasUnreachable {
+				err := setUnreachableBlock(ctx, txn, blockCid, now)
+				if err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+
+		if err := txn.DAGstore().DeleteBlock(ctx, blockCid); err != nil {
+			return nil, err
+		}
+		err := txn.Systemstore().Delete(ctx, core.NewUnreachableBlockKey(blockCid).ToDS())
+		if err != nil {
+			return nil, err
+		}
+		result.RemovedBlocks++
+	}
+
+	if !opts.DryRun {
+		// the remaining blocks have been made reachable, or removed, since they were found unreachable
+		for blockCid := range unreachableSince {
+			err := txn.Systemstore().Delete(ctx, core.NewUnreachableBlockKey(blockCid).ToDS())
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// getAllBlockCids returns the Cids of all the blocks of the blockstore.
+func getAllBlockCids(ctx context.Context, txn datastore.Txn) ([]cid.Cid, error) {
+	keys, err := txn.DAGstore().AllKeysChan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	blockCids := []cid.Cid{}
+	for key := range keys {
+		// the blockstore only keeps the multihash of the blocks, the links between them
+		// use the protobuf codec.
+		blockCids = append(blockCids, cid.NewCidV1(cid.DagProtobuf, key.Hash()))
+	}
+	return blockCids, nil
+}
+
+// getUnreachableBlocks returns the blocks found unreachable by the previous garbage collections,
+// with the time at which they have first been found unreachable.
+func getUnreachableBlocks(ctx context.Context, txn datastore.Txn) (map[cid.Cid]time.Time, error) {
+	q, err := txn.Systemstore().Query(ctx, query.Query{
+		Prefix: core.NewUnreachableBlockKey(cid.Undef).ToString(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := map[cid.Cid]time.Time{}
+	for res := range q.Next() {
+		if res.Error != nil {
+			_ = q.Close()
+			return nil, res.Error
+		}
+		blockCid, err := cid.Decode(ds.NewKey(res.Key).BaseNamespace())
+		if err != nil {
+			_ = q.Close()
+			return nil, err
+		}
+		blocks[blockCid] = time.Unix(0, int64(binary.BigEndian.Uint64(res.Value)))
+	}
+
+	return blocks, q.Close()
+}
+
+// setUnreachableBlock records the time at which the block of the given Cid has first been
+// found unreachable.
+func setUnreachableBlock(ctx context.Context, txn datastore.Txn, blockCid cid.Cid, since time.Time) error {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(since.UnixNano()))
+	return txn.Systemstore().Put(ctx, core.NewUnreachableBlockKey(blockCid).ToDS(), buf[:])
+}
+
+// getAllHeads returns the Cids of all the heads in the headstore, of every document and field.
+func getAllHeads(ctx context.Context, txn datastore.Txn) ([]cid.Cid, error) {
+	q, err := txn.Headstore().Query(ctx, query.Query{KeysOnly: true})
+	if err != nil {
+		return nil, err
+	}
+
+	heads := []cid.Cid{}
+	for res := range q.Next() {
+		if res.Error != nil {
+			_ = q.Close()
+			return nil, res.Error
+		}
+		key, err := core.NewHeadStoreKey(res.Key)
+		if err != nil {
+			_ = q.Close()
+			return nil, err
+		}
+		heads = append(heads, key.Cid)
+	}
+
+	return heads, q.Close()
+}
+
+// scheduleGC starts collecting the garbage of the database at the configured interval, until
+// the database is closed.
+func (db *db) scheduleGC() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	db.stopGC = func() {
+		cancel()
+		<-done
+	}
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(db.gcInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			res, err := (&implicitTxnDB{db}).CollectGarbage(ctx, client.GCOptions{})
+			if err != nil {
+				// the garbage is collected again at the next tick
+				log.ErrorE(ctx, "Failed to collect garbage", err)
+				continue
+			}
+			log.Info(
+				ctx,
+				"Collected garbage",
+				logging.NewKV("Reachable", res.Reachable),
+				logging.NewKV("RemovedBlocks", res.RemovedBlocks),
+			)
+		}
+	}()
+}
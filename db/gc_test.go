@@ -0,0 +1,201 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	badger "github.com/dgraph-io/badger/v3"
+	dag "github.com/ipfs/boxo/ipld/merkledag"
+	"github.com/ipfs/go-cid"
+	"github.com/sourcenetwork/immutable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/core"
+	badgerds "github.com/sourcenetwork/defradb/datastore/badger/v3"
+	"github.com/sourcenetwork/defradb/merkle/clock"
+)
+
+func putOrphanBlock(t *testing.T, ctx context.Context, db *implicitTxnDB) cid.Cid {
+	txn, err := db.NewTxn(ctx, false)
+	require.NoError(t, err)
+
+	nd := dag.NodeWithData([]byte("orphan"))
+	require.NoError(t, txn.DAGstore().Put(ctx, nd))
+	require.NoError(t, txn.Commit(ctx))
+
+	// the garbage is reported with the protobuf codec of the links between the blocks
+	return cid.NewCidV1(cid.DagProtobuf, nd.Cid().Hash())
+}
+
+// putSyncedBlock writes a block to the root blockstore, like the blocks fetched from other
+// peers during a sync, before their heads are committed.
+func putSyncedBlock(t *testing.T, ctx context.Context, db *implicitTxnDB) cid.Cid {
+	nd := dag.NodeWithData([]byte("synced"))
+	require.NoError(t, db.Blockstore().Put(ctx, nd))
+
+	return cid.NewCidV1(cid.DagProtobuf, nd.Cid().Hash())
+}
+
+// commitSyncedHead commits a head pointing to the given block, like a sync does once all the
+// blocks of a document have been fetched.
+func commitSyncedHead(t *testing.T, ctx context.Context, db *implicitTxnDB, c cid.Cid) {
+	txn, err := db.NewTxn(ctx, false)
+	require.NoError(t, err)
+
+	headset := clock.NewHeadSet(
+		txn.Headstore(),
+		core.HeadStoreKey{DocKey: "bae-synced", FieldId: core.COMPOSITE_NAMESPACE},
+	)
+	require.NoError(t, headset.Write(ctx, c, 1))
+	require.NoError(t, txn.Commit(ctx))
+}
+
+func hasUnreachableRecord(t *testing.T, ctx context.Context, db *implicitTxnDB, c cid.Cid) bool {
+	txn, err := db.NewTxn(ctx, true)
+	require.NoError(t, err)
+	defer txn.Discard(ctx)
+
+	has, err := txn.Systemstore().Has(ctx, core.NewUnreachableBlockKey(c).ToDS())
+	require.NoError(t, err)
+	return has
+}
+
+func hasBlock(t *testing.T, ctx context.Context, db *implicitTxnDB, c cid.Cid) bool {
+	txn, err := db.NewTxn(ctx, true)
+	require.NoError(t, err)
+	defer txn.Discard(ctx)
+
+	has, err := txn.DAGstore().Has(ctx, c)
+	require.NoError(t, err)
+	return has
+}
+
+func TestCollectGarbageWithUnreachableBlock(t *testing.T) {
+	ctx := context.Background()
+	db, col, doc := newTestFsckDocument(t, ctx)
+	orphan := putOrphanBlock(t, ctx, db)
+
+	res, err := db.CollectGarbage(ctx, client.GCOptions{DryRun: true})
+	require.NoError(t, err)
+	// two composite blocks, two blocks for Age and one for Name
+	assert.Equal(t, int64(5), res.Reachable)
+	assert.Equal(t, []string{orphan.String()}, res.Unreachable)
+	assert.Equal(t, int64(0), res.RemovedBlocks)
+	assert.True(t, hasBlock(t, ctx, db, orphan))
+
+	res, err = db.CollectGarbage(ctx, client.GCOptions{GracePeriod: immutable.Some(time.Duration(0))})
+	require.NoError(t, err)
+	assert.Equal(t, []string{orphan.String()}, res.Unreachable)
+	assert.Equal(t, int64(1), res.RemovedBlocks)
+	assert.False(t, hasBlock(t, ctx, db, orphan))
+
+	fsck, err := col.Fsck(ctx, client.FsckOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, fsck.Inconsistencies)
+
+	_, err = col.Get(ctx, doc.Key(), false)
+	require.NoError(t, err)
+}
+
+func TestCollectGarbageWithClearedHeads(t *testing.T) {
+	ctx := context.Background()
+	db, _, _ := newTestFsckDocument(t, ctx)
+	clearStores(t, ctx, db)
+
+	res, err := db.CollectGarbage(ctx, client.GCOptions{GracePeriod: immutable.Some(time.Duration(0))})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), res.Reachable)
+	assert.Len(t, res.Unreachable, 5)
+	assert.Equal(t, int64(5), res.RemovedBlocks)
+}
+
+func TestCollectGarbageWithCompactedDocument(t *testing.T) {
+	ctx := context.Background()
+	db, col, _ := newTestFsckDocument(t, ctx)
+
+	_, err := col.Compact(ctx, client.CompactOptions{Depth: 1})
+	require.NoError(t, err)
+
+	res, err := db.CollectGarbage(ctx, client.GCOptions{DryRun: true})
+	require.NoError(t, err)
+	assert.NotZero(t, res.Reachable)
+	assert.Empty(t, res.Unreachable)
+}
+
+func TestCollectGarbageOnSchedule(t *testing.T) {
+	ctx := context.Background()
+	opts := badgerds.Options{Options: badger.DefaultOptions("").WithInMemory(true)}
+	rootstore, err := badgerds.NewDatastore("", &opts)
+	require.NoError(t, err)
+	db, err := newDB(
+		ctx,
+		rootstore,
+		WithGCInterval(10*time.Millisecond),
+		WithGCGracePeriod(20*time.Millisecond),
+	)
+	require.NoError(t, err)
+	defer db.Close(ctx)
+
+	orphan := putOrphanBlock(t, ctx, db)
+
+	assert.Eventually(t, func() bool {
+		return !hasBlock(t, ctx, db, orphan)
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestCollectGarbageDuringSyncKeepsSyncedBlocks(t *testing.T) {
+	ctx := context.Background()
+	db, _, _ := newTestFsckDocument(t, ctx)
+	synced := putSyncedBlock(t, ctx, db)
+
+	// the collection runs while the sync has fetched the block, but not committed its head yet,
+	// the blocks are kept for the default grace period
+	res, err := db.CollectGarbage(ctx, client.GCOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{synced.String()}, res.Unreachable)
+	assert.Equal(t, int64(0), res.RemovedBlocks)
+	assert.True(t, hasBlock(t, ctx, db, synced))
+	assert.True(t, hasUnreachableRecord(t, ctx, db, synced))
+
+	commitSyncedHead(t, ctx, db, synced)
+
+	res, err = db.CollectGarbage(ctx, client.GCOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), res.Reachable)
+	assert.Empty(t, res.Unreachable)
+	assert.True(t, hasBlock(t, ctx, db, synced))
+	assert.False(t, hasUnreachableRecord(t, ctx, db, synced))
+}
+
+func TestCollectGarbageAfterGracePeriodRemovesBlock(t *testing.T) {
+	ctx := context.Background()
+	db, _, _ := newTestFsckDocument(t, ctx)
+	orphan := putSyncedBlock(t, ctx, db)
+
+	res, err := db.CollectGarbage(ctx, client.GCOptions{GracePeriod: immutable.Some(time.Millisecond)})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), res.RemovedBlocks)
+	assert.True(t, hasBlock(t, ctx, db, orphan))
+
+	time.Sleep(time.Millisecond)
+
+	res, err = db.CollectGarbage(ctx, client.GCOptions{GracePeriod: immutable.Some(time.Millisecond)})
+	require.NoError(t, err)
+	assert.Equal(t, []string{orphan.String()}, res.Unreachable)
+	assert.Equal(t, int64(1), res.RemovedBlocks)
+	assert.False(t, hasBlock(t, ctx, db, orphan))
+	assert.False(t, hasUnreachableRecord(t, ctx, db, orphan))
+}
@@ -36,7 +36,7 @@ func (db *db) execRequest(ctx context.Context, request string, txn datastore.Txn
 		return res
 	}
 
-	pub, subRequest, err := db.checkForClientSubscriptions(parsedRequest)
+	pub, err := db.checkForClientSubscriptions(ctx, txn, parsedRequest)
 	if err != nil {
 		res.GQL.Errors = []error{err}
 		return res
@@ -44,7 +44,6 @@ func (db *db) execRequest(ctx context.Context, request string, txn datastore.Txn
 
 	if pub != nil {
 		res.Pub = pub
-		go db.handleSubscription(ctx, pub, subRequest)
 		return res
 	}
 
@@ -57,6 +56,11 @@ func (db *db) execRequest(ctx context.Context, request string, txn datastore.Txn
 	}
 
 	res.GQL.Data = results
+	if pageInfo := planner.PageInfo(); pageInfo.HasValue() {
+		res.GQL.Extensions = map[string]any{
+			client.PageInfoExtension: pageInfo.Value(),
+		}
+	}
 	return res
 }
 
@@ -12,38 +12,65 @@ package db
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/client/request"
+	"github.com/sourcenetwork/defradb/datastore"
 	"github.com/sourcenetwork/defradb/events"
 	"github.com/sourcenetwork/defradb/planner"
 )
 
-func (db *db) checkForClientSubscriptions(r *request.Request) (
-	*events.Publisher[events.Update],
-	*request.ObjectSubscription,
+// checkForClientSubscriptions starts handling the subscription of the given request, if any,
+// and returns the stream of results to the client.
+func (db *db) checkForClientSubscriptions(ctx context.Context, txn datastore.Txn, r *request.Request) (
+	events.Streamer,
 	error,
 ) {
 	if len(r.Subscription) == 0 || len(r.Subscription[0].Selections) == 0 {
 		// This is not a subscription request and we have nothing to do here
-		return nil, nil, nil
-	}
-
-	if !db.events.Updates.HasValue() {
-		return nil, nil, ErrSubscriptionsNotAllowed
+		return nil, nil
 	}
 
 	s := r.Subscription[0].Selections[0]
-	if subRequest, ok := s.(*request.ObjectSubscription); ok {
+	switch subRequest := s.(type) {
+	case *request.ObjectSubscription:
+		if !db.events.Updates.HasValue() {
+			return nil, ErrSubscriptionsNotAllowed
+		}
+
 		pub, err := events.NewPublisher(db.events.Updates.Value(), 5)
 		if err != nil {
-			return nil, nil, err
+			return nil, err
+		}
+
+		go db.handleSubscription(ctx, pub, subRequest)
+		return pub, nil
+
+	case *request.ConflictSubscription:
+		if !db.events.Conflicts.HasValue() {
+			return nil, ErrSubscriptionsNotAllowed
 		}
 
-		return pub, subRequest, nil
+		schemaID := ""
+		if subRequest.Collection.HasValue() {
+			col, err := db.getCollectionByName(ctx, txn, subRequest.Collection.Value())
+			if err != nil {
+				return nil, err
+			}
+			schemaID = col.SchemaID()
+		}
+
+		pub, err := events.NewPublisher(db.events.Conflicts.Value(), 5)
+		if err != nil {
+			return nil, err
+		}
+
+		go db.handleConflictSubscription(ctx, pub, subRequest, schemaID)
+		return pub, nil
 	}
 
-	return nil, nil, client.NewErrUnexpectedType[request.ObjectSubscription]("SubscriptionSelection", s)
+	return nil, client.NewErrUnexpectedType[request.ObjectSubscription]("SubscriptionSelection", s)
 }
 
 func (db *db) handleSubscription(
@@ -80,3 +107,72 @@ func (db *db) handleSubscription(
 		})
 	}
 }
+
+// handleConflictSubscription publishes the requested fields of each conflict matching the
+// given subscription. The conflicts are limited to the collection of the given schema ID
+// if it is not empty.
+func (db *db) handleConflictSubscription(
+	ctx context.Context,
+	pub *events.Publisher[events.Conflict],
+	r *request.ConflictSubscription,
+	schemaID string,
+) {
+	for evt := range pub.Event() {
+		if schemaID != "" && evt.SchemaID != schemaID {
+			continue
+		}
+		if r.DocKey.HasValue() && evt.DocKey != r.DocKey.Value() {
+			continue
+		}
+
+		result, err := conflictToMap(evt, r.Fields)
+		if err != nil {
+			pub.Publish(client.GQLResult{
+				Errors: []error{err},
+			})
+			continue
+		}
+
+		pub.Publish(client.GQLResult{
+			Data: []map[string]any{result},
+		})
+	}
+}
+
+// conflictToMap returns the given fields of the conflict, keyed by their alias if any.
+// Field values are returned as JSON strings as they may be of any type.
+func conflictToMap(evt events.Conflict, fields []request.Field) (map[string]any, error) {
+	result := make(map[string]any, len(fields))
+	for _, field := range fields {
+		var value any
+		switch field.Name {
+		case request.DockeyFieldName:
+			value = evt.DocKey
+		case request.FieldNameFieldName:
+			value = evt.FieldName
+		case request.LosingCidFieldName:
+			value = evt.LosingCid.String()
+		case request.WinningCidFieldName:
+			value = evt.WinningCid.String()
+		case request.LosingValueFieldName:
+			buf, err := json.Marshal(evt.LosingValue)
+			if err != nil {
+				return nil, err
+			}
+			value = string(buf)
+		case request.WinningValueFieldName:
+			buf, err := json.Marshal(evt.WinningValue)
+			if err != nil {
+				return nil, err
+			}
+			value = string(buf)
+		}
+
+		if field.Alias.HasValue() {
+			result[field.Alias.Value()] = value
+		} else {
+			result[field.Name] = value
+		}
+	}
+	return result, nil
+}
@@ -331,3 +331,24 @@ func (db *implicitTxnDB) GetAllP2PCollections(ctx context.Context) ([]string, er
 func (db *explicitTxnDB) GetAllP2PCollections(ctx context.Context) ([]string, error) {
 	return db.getAllP2PCollections(ctx, db.txn)
 }
+
+// CollectGarbage removes the blocks of the blockstore that are not reachable from any head.
+func (db *implicitTxnDB) CollectGarbage(ctx context.Context, opts client.GCOptions) (*client.GCResult, error) {
+	txn, err := db.NewTxn(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	defer txn.Discard(ctx)
+
+	res, err := db.collectGarbage(ctx, txn, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, txn.Commit(ctx)
+}
+
+// CollectGarbage removes the blocks of the blockstore that are not reachable from any head.
+func (db *explicitTxnDB) CollectGarbage(ctx context.Context, opts client.GCOptions) (*client.GCResult, error) {
+	return db.collectGarbage(ctx, db.txn, opts)
+}
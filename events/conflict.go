@@ -0,0 +1,40 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package events
+
+import (
+	"github.com/ipfs/go-cid"
+	"github.com/sourcenetwork/immutable"
+)
+
+// ConflictChannel is the bus onto which conflicts are published.
+type ConflictChannel = immutable.Option[Channel[Conflict]]
+
+// EmptyConflictChannel is an empty ConflictChannel.
+var EmptyConflictChannel = immutable.None[Channel[Conflict]]()
+
+// Conflict represents a remote update of a document field that has won over a concurrent
+// local update of the same field when merged.
+type Conflict struct {
+	DocKey    string
+	SchemaID  string
+	FieldName string
+
+	// LosingCid is the Cid of the local field head that has been overwritten.
+	LosingCid cid.Cid
+	// WinningCid is the Cid of the remote block that has won the merge.
+	WinningCid cid.Cid
+
+	// LosingValue is the field value that has been overwritten.
+	LosingValue any
+	// WinningValue is the field value after the merge.
+	WinningValue any
+}
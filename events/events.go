@@ -49,4 +49,8 @@ func New[T any](commandBufferSize int, eventBufferSize int) Channel[T] {
 type Events struct {
 	// Updates publishes an `Update` for each document written to in the database.
 	Updates UpdateChannel
+
+	// Conflicts publishes a `Conflict` for each remote update that overwrites a
+	// concurrent local update.
+	Conflicts ConflictChannel
 }
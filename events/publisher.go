@@ -15,6 +15,17 @@ import "time"
 // time limit we set for the client to read after publishing.
 var clientTimeout = 60 * time.Second
 
+// Streamer is the client facing side of a Publisher, it exposes the data
+// published to the client regardless of the type of events.
+type Streamer interface {
+	// Stream returns the streaming channel
+	Stream() chan any
+	// Unsubscribe unsubscribes the client for the event channel and closes the stream.
+	Unsubscribe()
+}
+
+var _ Streamer = (*Publisher[Update])(nil)
+
 // Publisher hold a referance to the event channel,
 // the associated subscription channel and the stream channel that
 // returns data to the subscribed client
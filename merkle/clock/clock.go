@@ -63,7 +63,13 @@ func (mc *MerkleClock) putBlock(
 		delta.SetPriority(height)
 	}
 
-	node, err := makeNode(delta, heads)
+	var node ipld.Node
+	var err error
+	if key, ok := signerFromContext(ctx); ok {
+		node, err = makeSignedNode(key, delta, heads)
+	} else {
+		node, err = makeNode(delta, heads)
+	}
 	if err != nil {
 		return nil, NewErrCreatingBlock(err)
 	}
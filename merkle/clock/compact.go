@@ -0,0 +1,101 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package clock
+
+import (
+	"context"
+
+	cid "github.com/ipfs/go-cid"
+	ds "github.com/ipfs/go-datastore"
+	ipld "github.com/ipfs/go-ipld-format"
+
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/errors"
+)
+
+// NewSnapshotNode returns a new block for the given delta that has no `_head` links.
+//
+// Snapshot blocks are the roots of compacted histories, they hold the full state of
+// the document at their priority. They are signed with the signer of the given context,
+// if any.
+func NewSnapshotNode(ctx context.Context, delta core.Delta) (ipld.Node, error) {
+	if key, ok := signerFromContext(ctx); ok {
+		return makeSignedNode(key, delta, nil)
+	}
+	return makeNode(delta, nil)
+}
+
+// MarkCompacted records that the block of the given Cid has been folded into
+// the given snapshot block.
+func MarkCompacted(
+	ctx context.Context,
+	store datastore.DSReaderWriter,
+	c cid.Cid,
+	snapshot cid.Cid,
+) error {
+	return store.Put(ctx, core.NewCompactedBlockKey(c).ToDS(), snapshot.Bytes())
+}
+
+// GetCompactedSnapshot returns the snapshot block that the block of the given Cid was
+// folded into, and true, if the block has been compacted.
+func GetCompactedSnapshot(
+	ctx context.Context,
+	store datastore.DSReaderWriter,
+	c cid.Cid,
+) (cid.Cid, bool, error) {
+	buf, err := store.Get(ctx, core.NewCompactedBlockKey(c).ToDS())
+	if errors.Is(err, ds.ErrNotFound) {
+		return cid.Undef, false, nil
+	}
+	if err != nil {
+		return cid.Undef, false, err
+	}
+
+	snapshot, err := cid.Cast(buf)
+	if err != nil {
+		return cid.Undef, false, err
+	}
+	return snapshot, true, nil
+}
+
+// SetDocSnapshot records the given snapshot block as the latest snapshot of the document
+// of the given key.
+func SetDocSnapshot(
+	ctx context.Context,
+	store datastore.DSReaderWriter,
+	dockey string,
+	snapshot cid.Cid,
+) error {
+	return store.Put(ctx, core.NewDocSnapshotKey(dockey).ToDS(), snapshot.Bytes())
+}
+
+// GetDocSnapshot returns the latest snapshot block of the document of the given key, and
+// true, if the history of the document has been compacted.
+func GetDocSnapshot(
+	ctx context.Context,
+	store datastore.DSReaderWriter,
+	dockey string,
+) (cid.Cid, bool, error) {
+	buf, err := store.Get(ctx, core.NewDocSnapshotKey(dockey).ToDS())
+	if errors.Is(err, ds.ErrNotFound) {
+		return cid.Undef, false, nil
+	}
+	if err != nil {
+		return cid.Undef, false, err
+	}
+
+	snapshot, err := cid.Cast(buf)
+	if err != nil {
+		return cid.Undef, false, err
+	}
+	return snapshot, true, nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package clock
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcenetwork/defradb/core/crdt"
+	"github.com/sourcenetwork/defradb/datastore"
+)
+
+func TestGetCompactedSnapshotWithUncompactedBlock(t *testing.T) {
+	ctx := context.Background()
+	store := datastore.AsDSReaderWriter(newDS())
+
+	_, isCompacted, err := GetCompactedSnapshot(ctx, store, newRandomCID())
+	if err != nil {
+		t.Errorf("Failed to get compacted snapshot, err: %v", err)
+		return
+	}
+	if isCompacted {
+		t.Error("Block should not be compacted")
+	}
+}
+
+func TestGetCompactedSnapshotWithCompactedBlock(t *testing.T) {
+	ctx := context.Background()
+	store := datastore.AsDSReaderWriter(newDS())
+	c := newRandomCID()
+	snapshot := newRandomCID()
+
+	err := MarkCompacted(ctx, store, c, snapshot)
+	if err != nil {
+		t.Errorf("Failed to mark block as compacted, err: %v", err)
+		return
+	}
+
+	result, isCompacted, err := GetCompactedSnapshot(ctx, store, c)
+	if err != nil {
+		t.Errorf("Failed to get compacted snapshot, err: %v", err)
+		return
+	}
+	if !isCompacted {
+		t.Error("Block should be compacted")
+		return
+	}
+	if !result.Equals(snapshot) {
+		t.Errorf("Snapshot does not match. Have %v, want %v", result, snapshot)
+	}
+}
+
+func TestNewSnapshotNode(t *testing.T) {
+	delta := &crdt.LWWRegDelta{
+		Data: []byte("test"),
+	}
+	node, err := NewSnapshotNode(context.Background(), delta)
+	if err != nil {
+		t.Errorf("Failed to create snapshot node, err: %v", err)
+		return
+	}
+
+	if len(node.Links()) != 0 {
+		t.Errorf("Node links should be empty. Have %v, want %v", len(node.Links()), 0)
+	}
+}
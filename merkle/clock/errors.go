@@ -26,6 +26,7 @@ const (
 	errReplacingHead          = "error replacing head"
 	errCouldNotFindBlock      = "error checking for known block "
 	errFailedToGetNextQResult = "failed to get next query result"
+	errInvalidBlockSignature  = "invalid block signature"
 )
 
 var (
@@ -39,6 +40,7 @@ var (
 	ErrCouldNotFindBlock      = errors.New(errCouldNotFindBlock)
 	ErrFailedToGetNextQResult = errors.New(errFailedToGetNextQResult)
 	ErrDecodingHeight         = errors.New("error decoding height")
+	ErrInvalidBlockSignature  = errors.New(errInvalidBlockSignature)
 )
 
 func NewErrCreatingBlock(inner error) error {
@@ -81,3 +83,12 @@ func NewErrCouldNotFindBlock(cid cid.Cid, inner error) error {
 func NewErrFailedToGetNextQResult(inner error) error {
 	return errors.Wrap(errFailedToGetNextQResult, inner)
 }
+
+// NewErrInvalidBlockSignature returns an error indicating that the signature of the block
+// of the given Cid does not match its content, or could not be read.
+func NewErrInvalidBlockSignature(cid cid.Cid, inner error) error {
+	if inner == nil {
+		return errors.New(errInvalidBlockSignature, errors.NewKV("Cid", cid))
+	}
+	return errors.Wrap(errInvalidBlockSignature, inner, errors.NewKV("Cid", cid))
+}
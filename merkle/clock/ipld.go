@@ -113,6 +113,24 @@ func (ng *CrdtNodeGetter) GetDeltas(ctx context.Context, cids []cid.Cid) <-chan
 // }
 
 func makeNode(delta core.Delta, heads []cid.Cid) (ipld.Node, error) {
+	links := make([]*ipld.Link, 0, len(heads))
+	// add heads
+	for _, h := range heads {
+		links = append(links, &ipld.Link{Name: "_head", Cid: h})
+	}
+
+	// add delta specific links
+	if comp, ok := delta.(core.CompositeDelta); ok {
+		for _, dagLink := range comp.Links() {
+			links = append(links, &ipld.Link{Name: dagLink.Name, Cid: dagLink.Cid})
+		}
+	}
+	return makeNodeWithLinks(delta, links)
+}
+
+// makeNodeWithLinks returns a new block holding the given delta, and linking to the
+// given blocks in order.
+func makeNodeWithLinks(delta core.Delta, links []*ipld.Link) (ipld.Node, error) {
 	var data []byte
 	var err error
 	if delta != nil {
@@ -133,21 +151,11 @@ func makeNode(delta core.Delta, heads []cid.Cid) (ipld.Node, error) {
 		return nil, err
 	}
 
-	// add heads
-	for _, h := range heads {
-		if err = nd.AddRawLink("_head", &ipld.Link{Cid: h}); err != nil {
+	for _, l := range links {
+		if err = nd.AddRawLink(l.Name, &ipld.Link{Cid: l.Cid, Size: l.Size}); err != nil {
 			return nil, err
 		}
 	}
-
-	// add delta specific links
-	if comp, ok := delta.(core.CompositeDelta); ok {
-		for _, dagLink := range comp.Links() {
-			if err = nd.AddRawLink(dagLink.Name, &ipld.Link{Cid: dagLink.Cid}); err != nil {
-				return nil, err
-			}
-		}
-	}
 	return nd, nil
 }
 
@@ -0,0 +1,100 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package clock
+
+import (
+	"context"
+
+	cid "github.com/ipfs/go-cid"
+	ipld "github.com/ipfs/go-ipld-format"
+	"github.com/libp2p/go-libp2p/core/crypto"
+
+	"github.com/sourcenetwork/defradb/core"
+)
+
+type signerContextKey struct{}
+
+// ContextWithSigner returns a copy of the given context with which the blocks created
+// by the MerkleClock are signed using the given private key.
+func ContextWithSigner(ctx context.Context, key crypto.PrivKey) context.Context {
+	return context.WithValue(ctx, signerContextKey{}, key)
+}
+
+func signerFromContext(ctx context.Context) (crypto.PrivKey, bool) {
+	key, ok := ctx.Value(signerContextKey{}).(crypto.PrivKey)
+	return key, ok && key != nil
+}
+
+// makeSignedNode returns a new block for the given delta signed with the given key.
+//
+// The signature is made over the raw data of the block holding the public key of the
+// signer but no signature. Deltas that cannot carry a signature are left unsigned.
+func makeSignedNode(key crypto.PrivKey, delta core.Delta, heads []cid.Cid) (ipld.Node, error) {
+	signed, ok := delta.(core.SignedDelta)
+	if !ok {
+		return makeNode(delta, heads)
+	}
+
+	signer, err := crypto.MarshalPublicKey(key.GetPublic())
+	if err != nil {
+		return nil, err
+	}
+
+	signed.SetSignature(signer, nil)
+	unsigned, err := makeNode(delta, heads)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := key.Sign(unsigned.RawData())
+	if err != nil {
+		return nil, err
+	}
+
+	signed.SetSignature(signer, signature)
+	return makeNode(delta, heads)
+}
+
+// VerifySignature verifies the signature of the given block, from which the given delta
+// has been decoded.
+//
+// It returns the public key of the signer, nil if the block is not signed, and an error
+// if the signature does not match the content of the block.
+func VerifySignature(nd ipld.Node, delta core.Delta) (crypto.PubKey, error) {
+	signed, ok := delta.(core.SignedDelta)
+	if !ok {
+		return nil, nil
+	}
+	signer, signature := signed.GetSignature()
+	if len(signer) == 0 {
+		return nil, nil
+	}
+
+	pubKey, err := crypto.UnmarshalPublicKey(signer)
+	if err != nil {
+		return nil, NewErrInvalidBlockSignature(nd.Cid(), err)
+	}
+
+	signed.SetSignature(signer, nil)
+	unsigned, err := makeNodeWithLinks(delta, nd.Links())
+	signed.SetSignature(signer, signature)
+	if err != nil {
+		return nil, err
+	}
+
+	valid, err := pubKey.Verify(unsigned.RawData(), signature)
+	if err != nil {
+		return nil, NewErrInvalidBlockSignature(nd.Cid(), err)
+	}
+	if !valid {
+		return nil, NewErrInvalidBlockSignature(nd.Cid(), nil)
+	}
+	return pubKey, nil
+}
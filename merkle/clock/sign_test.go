@@ -0,0 +1,102 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package clock
+
+import (
+	"context"
+	"testing"
+
+	dag "github.com/ipfs/boxo/ipld/merkledag"
+	cid "github.com/ipfs/go-cid"
+	ipld "github.com/ipfs/go-ipld-format"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/core/crdt"
+)
+
+func newTestSigningKey(t *testing.T) crypto.PrivKey {
+	key, _, err := crypto.GenerateKeyPair(crypto.Ed25519, 0)
+	require.NoError(t, err)
+	return key
+}
+
+func decodeTestNode(t *testing.T, node ipld.Node) (ipld.Node, core.Delta) {
+	nd, err := dag.DecodeProtobuf(node.RawData())
+	require.NoError(t, err)
+	delta, err := crdt.LWWRegister{}.DeltaDecode(nd)
+	require.NoError(t, err)
+	return nd, delta
+}
+
+func TestMerkleClockPutBlockWithSigner(t *testing.T) {
+	key := newTestSigningKey(t)
+	ctx := ContextWithSigner(context.Background(), key)
+	clk := newTestMerkleClock()
+	head, err := cid.Decode("bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi")
+	require.NoError(t, err)
+
+	node, err := clk.putBlock(ctx, []cid.Cid{head}, 1, &crdt.LWWRegDelta{Data: []byte("test")})
+	require.NoError(t, err)
+
+	nd, delta := decodeTestNode(t, node)
+	signer, signature := delta.(core.SignedDelta).GetSignature()
+	expectedSigner, err := crypto.MarshalPublicKey(key.GetPublic())
+	require.NoError(t, err)
+	require.Equal(t, expectedSigner, signer)
+	require.NotEmpty(t, signature)
+
+	pubKey, err := VerifySignature(nd, delta)
+	require.NoError(t, err)
+	require.True(t, pubKey.Equals(key.GetPublic()))
+}
+
+func TestMerkleClockPutBlockWithoutSignerIsUnsigned(t *testing.T) {
+	clk := newTestMerkleClock()
+
+	node, err := clk.putBlock(context.Background(), nil, 1, &crdt.LWWRegDelta{Data: []byte("test")})
+	require.NoError(t, err)
+
+	nd, delta := decodeTestNode(t, node)
+	pubKey, err := VerifySignature(nd, delta)
+	require.NoError(t, err)
+	require.Nil(t, pubKey)
+}
+
+func TestVerifySignatureWithTamperedBlockReturnsError(t *testing.T) {
+	key := newTestSigningKey(t)
+	node, err := makeSignedNode(key, &crdt.LWWRegDelta{Data: []byte("test"), Priority: 1}, nil)
+	require.NoError(t, err)
+
+	_, delta := decodeTestNode(t, node)
+	delta.(*crdt.LWWRegDelta).Data = []byte("tampered")
+	tampered, err := makeNodeWithLinks(delta, nil)
+	require.NoError(t, err)
+
+	_, err = VerifySignature(tampered, delta)
+	require.ErrorIs(t, err, ErrInvalidBlockSignature)
+}
+
+func TestVerifySignatureWithOtherSignerReturnsError(t *testing.T) {
+	key := newTestSigningKey(t)
+	node, err := makeSignedNode(key, &crdt.LWWRegDelta{Data: []byte("test"), Priority: 1}, nil)
+	require.NoError(t, err)
+
+	nd, delta := decodeTestNode(t, node)
+	otherSigner, err := crypto.MarshalPublicKey(newTestSigningKey(t).GetPublic())
+	require.NoError(t, err)
+	_, signature := delta.(core.SignedDelta).GetSignature()
+	delta.(core.SignedDelta).SetSignature(otherSigner, signature)
+
+	_, err = VerifySignature(nd, delta)
+	require.ErrorIs(t, err, ErrInvalidBlockSignature)
+}
@@ -12,6 +12,7 @@ package crdt
 
 import (
 	"context"
+	"time"
 
 	ipld "github.com/ipfs/go-ipld-format"
 
@@ -91,15 +92,22 @@ func NewMerkleCompositeDAG(
 }
 
 // Delete sets the values of CompositeDAG for a delete.
+//
+// The given commit time is recorded in the delta, unless it is the zero time, along
+// with the given author, unless it is empty.
 func (m *MerkleCompositeDAG) Delete(
 	ctx context.Context,
 	links []core.DAGLink,
+	commitTime time.Time,
+	author string,
 ) (ipld.Node, uint64, error) {
 	// Set() call on underlying CompositeDAG CRDT
 	// persist/publish delta
 	log.Debug(ctx, "Applying delta-mutator 'Delete' on CompositeDAG")
 	delta := m.reg.Set([]byte{}, links)
 	delta.Status = client.Deleted
+	delta.CommitTime = unixNanoOrZero(commitTime)
+	delta.Author = author
 	nd, err := m.Publish(ctx, delta)
 	if err != nil {
 		return nil, 0, err
@@ -109,15 +117,22 @@ func (m *MerkleCompositeDAG) Delete(
 }
 
 // Set sets the values of CompositeDAG. The value is always the object from the mutation operations.
+//
+// The given commit time is recorded in the delta, unless it is the zero time, along
+// with the given author, unless it is empty.
 func (m *MerkleCompositeDAG) Set(
 	ctx context.Context,
 	patch []byte,
 	links []core.DAGLink,
+	commitTime time.Time,
+	author string,
 ) (ipld.Node, uint64, error) {
 	// Set() call on underlying CompositeDAG CRDT
 	// persist/publish delta
 	log.Debug(ctx, "Applying delta-mutator 'Set' on CompositeDAG")
 	delta := m.reg.Set(patch, links)
+	delta.CommitTime = unixNanoOrZero(commitTime)
+	delta.Author = author
 	nd, err := m.Publish(ctx, delta)
 	if err != nil {
 		return nil, 0, err
@@ -136,3 +151,12 @@ func (m *MerkleCompositeDAG) Value(ctx context.Context) ([]byte, error) {
 func (m *MerkleCompositeDAG) Merge(ctx context.Context, other core.Delta, id string) error {
 	return m.reg.Merge(ctx, other, id)
 }
+
+// unixNanoOrZero returns the unix time in nanoseconds of the given time,
+// or zero if it is the zero time.
+func unixNanoOrZero(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
@@ -13,6 +13,7 @@ package crdt
 import (
 	"context"
 	"testing"
+	"time"
 
 	ds "github.com/ipfs/go-datastore"
 	"github.com/stretchr/testify/assert"
@@ -221,7 +222,7 @@ func TestLWWRegisterFactoryFn(t *testing.T) {
 	lwwreg, ok := crdt.(*MerkleLWWRegister)
 	assert.True(t, ok)
 
-	_, _, err := lwwreg.Set(ctx, []byte("hi"))
+	_, _, err := lwwreg.Set(ctx, []byte("hi"), time.Time{})
 	assert.NoError(t, err)
 }
 
@@ -234,6 +235,6 @@ func TestCompositeRegisterFactoryFn(t *testing.T) {
 	merkleReg, ok := crdt.(*MerkleCompositeDAG)
 	assert.True(t, ok)
 
-	_, _, err := merkleReg.Set(ctx, []byte("hi"), []core.DAGLink{})
+	_, _, err := merkleReg.Set(ctx, []byte("hi"), []core.DAGLink{}, time.Time{}, "")
 	assert.NoError(t, err)
 }
@@ -12,6 +12,7 @@ package crdt
 
 import (
 	"context"
+	"time"
 
 	ipld "github.com/ipfs/go-ipld-format"
 
@@ -85,10 +86,17 @@ func NewMerkleLWWRegister(
 }
 
 // Set the value of the register.
-func (mlwwreg *MerkleLWWRegister) Set(ctx context.Context, value []byte) (ipld.Node, uint64, error) {
+//
+// The given commit time is recorded in the delta, unless it is the zero time.
+func (mlwwreg *MerkleLWWRegister) Set(
+	ctx context.Context,
+	value []byte,
+	commitTime time.Time,
+) (ipld.Node, uint64, error) {
 	// Set() call on underlying LWWRegister CRDT
 	// persist/publish delta
 	delta := mlwwreg.reg.Set(value)
+	delta.CommitTime = unixNanoOrZero(commitTime)
 	nd, err := mlwwreg.Publish(ctx, delta)
 	return nd, delta.GetPriority(), err
 }
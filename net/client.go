@@ -46,6 +46,11 @@ func (s *server) pushLog(ctx context.Context, evt events.Update, pid peer.ID) er
 		logging.NewKV("CID", evt.Cid),
 		logging.NewKV("SchemaId", evt.SchemaID))
 
+	snapshot, err := s.peer.getDocSnapshot(ctx, evt.DocKey)
+	if err != nil {
+		return err
+	}
+
 	body := &pb.PushLogRequest_Body{
 		DocKey:   &pb.ProtoDocKey{DocKey: dockey},
 		Cid:      &pb.ProtoCid{Cid: evt.Cid},
@@ -54,6 +59,7 @@ func (s *server) pushLog(ctx context.Context, evt events.Update, pid peer.ID) er
 		Log: &pb.Document_Log{
 			Block: evt.Block.RawData(),
 		},
+		Snapshot: snapshot,
 	}
 	req := &pb.PushLogRequest{
 		Body: body,
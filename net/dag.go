@@ -61,6 +61,8 @@ type dagJob struct {
 	// Transaction common to a pushlog event. It is used to pass it along to processLog
 	// and handleChildBlocks within the dagWorker.
 	txn datastore.Txn
+	// conflicts collects the conflicts of the blocks merged by the pushlog event.
+	conflicts *conflictChecks
 
 	// OLD FIELDS
 	// root       cid.Cid         // the root of the branch we are walking down
@@ -133,6 +135,7 @@ func (p *Peer) dagWorker(jobs chan *dagJob) {
 			job.fieldName,
 			job.node,
 			job.nodeGetter,
+			job.conflicts,
 			true,
 		)
 		if err != nil {
@@ -162,6 +165,7 @@ func (p *Peer) dagWorker(jobs chan *dagJob) {
 				j.node,
 				children,
 				j.nodeGetter,
+				j.conflicts,
 			)
 			j.session.Done()
 		}(job)
@@ -0,0 +1,273 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package net
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/ipfs/boxo/ipld/car"
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	ipld "github.com/ipfs/go-ipld-format"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/core"
+	corecrdt "github.com/sourcenetwork/defradb/core/crdt"
+	"github.com/sourcenetwork/defradb/datastore"
+	badger "github.com/sourcenetwork/defradb/datastore/badger/v3"
+	"github.com/sourcenetwork/defradb/errors"
+	"github.com/sourcenetwork/defradb/logging"
+)
+
+// ImportDAG imports the documents of the given CARv1 file, as written by an export, into
+// the database.
+//
+// The roots of the file must be the composite heads of the documents. Their DAGs are merged
+// through the same path as the blocks received from other peers, so that the documents converge
+// exactly as if they had been synced over P2P, and the blocks whose signature is not accepted
+// by the given policy are rejected. Purged documents and already known heads are skipped.
+//
+// All the documents are imported, or none.
+func ImportDAG(
+	ctx context.Context,
+	db client.DB,
+	r io.Reader,
+	policy SignaturePolicy,
+) (*client.ImportDAGResult, error) {
+	cr, err := car.NewCarReader(r)
+	if err != nil {
+		return nil, errors.Wrap("failed to read CAR header", err)
+	}
+	getter := &carNodeGetter{blocks: map[cid.Cid]blocks.Block{}}
+	for {
+		block, err := cr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap("failed to read CAR block", err)
+		}
+		getter.blocks[block.Cid()] = block
+	}
+
+	var txnErr error
+	for retry := 0; retry < db.MaxTxnRetries(); retry++ {
+		res, err := importDAGTxn(ctx, db, cr.Header.Roots, getter, policy)
+		if err == nil {
+			return res, nil
+		}
+		txnErr = err
+		if !errors.Is(txnErr, badger.ErrTxnConflict) {
+			return nil, txnErr
+		}
+	}
+
+	return nil, client.NewErrMaxTxnRetries(txnErr)
+}
+
+// importDAGTxn imports the documents of the given roots within a single transaction.
+func importDAGTxn(
+	ctx context.Context,
+	db client.DB,
+	roots []cid.Cid,
+	getter *carNodeGetter,
+	policy SignaturePolicy,
+) (*client.ImportDAGResult, error) {
+	txn, err := db.NewTxn(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	defer txn.Discard(ctx)
+
+	res, err := importDAG(ctx, db, txn, roots, getter, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, txn.Commit(ctx)
+}
+
+func importDAG(
+	ctx context.Context,
+	db client.DB,
+	txn datastore.Txn,
+	roots []cid.Cid,
+	getter *carNodeGetter,
+	policy SignaturePolicy,
+) (*client.ImportDAGResult, error) {
+	store := db.WithTxn(txn)
+	results := &client.ImportDAGResult{
+		DocKeys: []string{},
+	}
+	imported := map[string]struct{}{}
+	for _, root := range roots {
+		exists, err := txn.DAGstore().Has(ctx, root)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			log.Debug(ctx, "Already have block locally, skipping", logging.NewKV("CID", root))
+			continue
+		}
+
+		nd, err := getter.Get(ctx, root)
+		if err != nil {
+			return nil, err
+		}
+		delta, err := corecrdt.CompositeDAG{}.DeltaDecode(nd)
+		if err != nil {
+			return nil, errors.Wrap(fmt.Sprintf("root %s is not a composite block", root), err)
+		}
+		composite := delta.(*corecrdt.CompositeDAGDelta)
+		docKey := core.DataStoreKey{DocKey: string(composite.DocKey)}
+
+		// purged documents must not be re-created from imported blocks
+		isPurged, err := txn.Systemstore().Has(ctx, core.NewPurgedDocKey(docKey.DocKey).ToDS())
+		if err != nil {
+			return nil, err
+		}
+		if isPurged {
+			log.Debug(ctx, "Ignoring import of a purged document", logging.NewKV("DocKey", docKey))
+			continue
+		}
+
+		version, err := store.GetCollectionByVersionID(ctx, composite.SchemaVersionID)
+		if err != nil {
+			return nil, errors.Wrap(
+				fmt.Sprintf("Failed to get collection from schema version %s", composite.SchemaVersionID),
+				err,
+			)
+		}
+		col, err := store.GetCollectionBySchemaID(ctx, version.SchemaID())
+		if err != nil {
+			return nil, errors.Wrap(fmt.Sprintf("Failed to get collection from schemaID %s", version.SchemaID()), err)
+		}
+
+		merged, err := importDocumentDAG(ctx, db, txn, col, docKey, nd, getter, policy)
+		if err != nil {
+			return nil, err
+		}
+		results.MergedBlocks += merged
+		if _, ok := imported[docKey.DocKey]; !ok {
+			imported[docKey.DocKey] = struct{}{}
+			results.DocKeys = append(results.DocKeys, docKey.DocKey)
+			results.Count++
+		}
+	}
+	return results, nil
+}
+
+// importDocumentDAG merges the DAG of a document from the given composite head, walking its
+// unknown children in the same way as the DAG sync, and returns the number of blocks merged.
+func importDocumentDAG(
+	ctx context.Context,
+	db client.DB,
+	txn datastore.Txn,
+	col client.Collection,
+	dockey core.DataStoreKey,
+	root ipld.Node,
+	getter *carNodeGetter,
+	policy SignaturePolicy,
+) (int64, error) {
+	type dagImportJob struct {
+		fieldName string
+		node      ipld.Node
+	}
+
+	conflicts := newConflictChecks()
+	merged := int64(0)
+	visited := map[cid.Cid]struct{}{root.Cid(): {}}
+	queue := []dagImportJob{{node: root}}
+	for len(queue) > 0 {
+		job := queue[0]
+		queue = queue[1:]
+
+		children, err := mergeBlock(
+			ctx,
+			db,
+			txn,
+			col,
+			dockey,
+			job.node.Cid(),
+			job.fieldName,
+			job.node,
+			getter,
+			conflicts,
+			policy,
+		)
+		if err != nil {
+			return 0, err
+		}
+		merged++
+
+		for _, c := range children {
+			if _, ok := visited[c]; ok {
+				continue
+			}
+			visited[c] = struct{}{}
+
+			var fieldName string
+			// loop over our children to get the corresponding field names from the DAG
+			for _, l := range job.node.Links() {
+				if c == l.Cid && l.Name != core.HEAD {
+					fieldName = l.Name
+				}
+			}
+			// heads of subfields are still subfields, not composites
+			if fieldName == "" && job.fieldName != "" {
+				fieldName = job.fieldName
+			}
+
+			nd, err := getter.Get(ctx, c)
+			if errors.Is(err, ipld.ErrNotFound{Cid: c}) {
+				// like during a DAG sync, the blocks that cannot be found are skipped,
+				// these are usually blocks that have been folded into a snapshot.
+				log.Debug(ctx, "Block not found in CAR file, skipping", logging.NewKV("CID", c))
+				continue
+			}
+			if err != nil {
+				return 0, err
+			}
+			queue = append(queue, dagImportJob{fieldName: fieldName, node: nd})
+		}
+	}
+	if err := conflicts.publish(ctx, txn); err != nil {
+		return 0, err
+	}
+	return merged, nil
+}
+
+// carNodeGetter is a node getter over the blocks read from a CAR file.
+type carNodeGetter struct {
+	blocks map[cid.Cid]blocks.Block
+}
+
+var _ ipld.NodeGetter = (*carNodeGetter)(nil)
+
+func (g *carNodeGetter) Get(ctx context.Context, c cid.Cid) (ipld.Node, error) {
+	block, ok := g.blocks[c]
+	if !ok {
+		return nil, ipld.ErrNotFound{Cid: c}
+	}
+	return ipld.Decode(block)
+}
+
+func (g *carNodeGetter) GetMany(ctx context.Context, cids []cid.Cid) <-chan *ipld.NodeOption {
+	out := make(chan *ipld.NodeOption, len(cids))
+	for _, c := range cids {
+		nd, err := g.Get(ctx, c)
+		out <- &ipld.NodeOption{Node: nd, Err: err}
+	}
+	close(out)
+	return out
+}
@@ -385,6 +385,9 @@ type PushLogRequest_Body struct {
 	Creator string `protobuf:"bytes,4,opt,name=creator,proto3" json:"creator,omitempty"`
 	// record is the actual record payload.
 	Log *Document_Log `protobuf:"bytes,5,opt,name=log,proto3" json:"log,omitempty"`
+	// snapshot is the CID of the latest compaction snapshot of the document, if its
+	// history has been compacted.
+	Snapshot *ProtoCid `protobuf:"bytes,6,opt,name=snapshot,proto3,customtype=ProtoCid" json:"snapshot,omitempty"`
 }
 
 func (m *PushLogRequest_Body) Reset()         { *m = PushLogRequest_Body{} }
@@ -568,37 +571,38 @@ func init() {
 func init() { proto.RegisterFile("net.proto", fileDescriptor_a5b10ce944527a32) }
 
 var fileDescriptor_a5b10ce944527a32 = []byte{
-	// 480 bytes of a gzipped FileDescriptorProto
+	// 494 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x93, 0xc1, 0x6e, 0xda, 0x40,
-	0x10, 0x86, 0x71, 0x20, 0x40, 0x06, 0x12, 0x9a, 0x81, 0xb4, 0xce, 0x46, 0x72, 0x22, 0x0e, 0x6d,
-	0x2f, 0x35, 0x52, 0x2a, 0x55, 0xea, 0x95, 0x52, 0x91, 0xaa, 0x39, 0x44, 0xee, 0x13, 0xd8, 0xeb,
-	0xad, 0x8d, 0x0a, 0x59, 0x6a, 0xd6, 0x95, 0x78, 0x8b, 0xbe, 0x48, 0xdf, 0xa3, 0xc7, 0xf4, 0x56,
-	0xe5, 0x10, 0x55, 0xf0, 0x04, 0x7d, 0x83, 0x6a, 0x67, 0x63, 0xc0, 0xe0, 0x43, 0x6f, 0x9e, 0xf9,
-	0xff, 0x99, 0xd9, 0xf9, 0x46, 0x86, 0x83, 0x5b, 0xa1, 0xdc, 0x69, 0x22, 0x95, 0xc4, 0x2a, 0x7d,
-	0x06, 0xec, 0x55, 0x34, 0x52, 0x71, 0x1a, 0xb8, 0x5c, 0x4e, 0x7a, 0x91, 0x8c, 0x64, 0x8f, 0xe4,
-	0x20, 0xfd, 0x4c, 0x11, 0x05, 0xf4, 0x65, 0xca, 0xba, 0x09, 0xd4, 0x07, 0x92, 0xa7, 0x13, 0x71,
-	0xab, 0xf0, 0x05, 0x54, 0x43, 0xc9, 0x3f, 0x8a, 0xb9, 0x6d, 0x5d, 0x58, 0x2f, 0x9b, 0xfd, 0xd6,
-	0xfd, 0xc3, 0x79, 0xe3, 0x46, 0xdb, 0x06, 0x94, 0xf6, 0x1e, 0x65, 0xbc, 0x80, 0x4a, 0x2c, 0xfc,
-	0xd0, 0xae, 0x90, 0xad, 0x79, 0xff, 0x70, 0x5e, 0x27, 0xdb, 0xbb, 0x51, 0xe8, 0x91, 0xc2, 0xce,
-	0xa0, 0x7c, 0x2d, 0x23, 0xec, 0xc0, 0x7e, 0x30, 0x96, 0xfc, 0x8b, 0x69, 0xe8, 0x99, 0xa0, 0xdb,
-	0x01, 0x1c, 0x0a, 0x35, 0x90, 0x7c, 0x98, 0xf8, 0xd3, 0xd8, 0x13, 0x5f, 0x53, 0x31, 0x53, 0x5d,
-	0x84, 0x27, 0xb9, 0xec, 0x74, 0x3c, 0xef, 0x9e, 0x40, 0xfb, 0x26, 0x9d, 0xc5, 0xdb, 0xd6, 0x36,
-	0x1c, 0xe7, 0xd3, 0xda, 0xdb, 0x82, 0xc3, 0xa1, 0x50, 0xd7, 0x32, 0xca, 0x5c, 0x87, 0xd0, 0xc8,
-	0x12, 0x5a, 0xff, 0x6b, 0xc1, 0x91, 0xae, 0x5a, 0x3b, 0xb0, 0x07, 0x95, 0x40, 0x86, 0x66, 0xdd,
-	0xc6, 0xe5, 0x99, 0x6b, 0x10, 0xba, 0x79, 0x97, 0xdb, 0x97, 0xe1, 0xdc, 0x23, 0x23, 0xfb, 0x61,
-	0x41, 0x45, 0x87, 0xff, 0x8f, 0xca, 0x81, 0x32, 0x1f, 0x85, 0xf6, 0x5e, 0x01, 0x29, 0x2d, 0x20,
-	0x83, 0xfa, 0x8c, 0xc7, 0x62, 0xe2, 0x7f, 0x18, 0xd8, 0x65, 0x82, 0xb4, 0x8a, 0xd1, 0x86, 0x1a,
-	0x4f, 0x84, 0xaf, 0x64, 0x42, 0xa4, 0x0f, 0xbc, 0x2c, 0xc4, 0xe7, 0x50, 0x1e, 0xcb, 0xc8, 0xde,
-	0xa7, 0x77, 0x77, 0xb2, 0x77, 0x67, 0x87, 0x74, 0xf5, 0xe3, 0xb5, 0x41, 0x83, 0x1a, 0x0a, 0x75,
-	0x25, 0xfc, 0x70, 0x83, 0xcb, 0x11, 0x34, 0x57, 0x1b, 0x6a, 0x30, 0xc7, 0xd0, 0xda, 0x34, 0x4d,
-	0xc7, 0xf3, 0xcb, 0x5f, 0x7b, 0x50, 0xfb, 0x24, 0x92, 0x6f, 0x23, 0x2e, 0xf0, 0x3d, 0x61, 0xcc,
-	0x58, 0x23, 0xcb, 0xa6, 0xed, 0x9e, 0x90, 0xd9, 0x85, 0x9a, 0x9e, 0x51, 0xc2, 0x2b, 0x33, 0x75,
-	0xd5, 0x27, 0x47, 0x7b, 0xbb, 0xd1, 0x69, 0xb1, 0x68, 0x3a, 0xbd, 0x81, 0xaa, 0xb9, 0x2b, 0x9e,
-	0x6c, 0xcc, 0x5b, 0x2f, 0xc8, 0xda, 0xdb, 0x69, 0x53, 0xf7, 0x16, 0x6a, 0x8f, 0x7b, 0xe3, 0xd3,
-	0xe2, 0x53, 0xb3, 0xce, 0x4e, 0xde, 0x94, 0xf6, 0x01, 0xd6, 0x88, 0xf0, 0x74, 0xa3, 0x7f, 0x9e,
-	0x2d, 0x7b, 0x56, 0x24, 0x51, 0x8f, 0xbe, 0xfd, 0x73, 0xe1, 0x58, 0x77, 0x0b, 0xc7, 0xfa, 0xb3,
-	0x70, 0xac, 0xef, 0x4b, 0xa7, 0x74, 0xb7, 0x74, 0x4a, 0xbf, 0x97, 0x4e, 0x29, 0xa8, 0xd2, 0xaf,
-	0xf8, 0xfa, 0x5f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x4b, 0x46, 0x27, 0x1c, 0xce, 0x03, 0x00, 0x00,
+	0x10, 0x86, 0x31, 0x10, 0x43, 0x06, 0x12, 0x9a, 0x81, 0xb4, 0xce, 0x46, 0x72, 0x22, 0x0e, 0x6d,
+	0x2e, 0x35, 0x52, 0x2a, 0x55, 0xea, 0x95, 0x52, 0x91, 0xaa, 0x39, 0x44, 0xee, 0x13, 0xd8, 0xeb,
+	0xad, 0x8d, 0x0a, 0xac, 0x6b, 0xaf, 0x2b, 0xf1, 0x16, 0xbd, 0xf7, 0x85, 0x7a, 0x4c, 0x4f, 0xad,
+	0x72, 0x88, 0x2a, 0x78, 0x91, 0x6a, 0x77, 0x63, 0xc0, 0xc4, 0x87, 0xdc, 0x3c, 0xf3, 0xff, 0x33,
+	0x3b, 0xfb, 0xcd, 0x1a, 0xf6, 0xe7, 0x4c, 0x38, 0x71, 0xc2, 0x05, 0x47, 0x53, 0x7d, 0xfa, 0xe4,
+	0x75, 0x38, 0x11, 0x51, 0xe6, 0x3b, 0x94, 0xcf, 0x06, 0x21, 0x0f, 0xf9, 0x40, 0xc9, 0x7e, 0xf6,
+	0x45, 0x45, 0x2a, 0x50, 0x5f, 0xba, 0xac, 0x9f, 0x40, 0x73, 0xc4, 0x69, 0x36, 0x63, 0x73, 0x81,
+	0xaf, 0xc0, 0x0c, 0x38, 0xfd, 0xc4, 0x16, 0x96, 0x71, 0x6e, 0x5c, 0xb4, 0x87, 0x9d, 0xbb, 0xfb,
+	0xb3, 0xd6, 0x8d, 0xb4, 0x8d, 0x54, 0xda, 0x7d, 0x90, 0xf1, 0x1c, 0xea, 0x11, 0xf3, 0x02, 0xab,
+	0xae, 0x6c, 0xed, 0xbb, 0xfb, 0xb3, 0xa6, 0xb2, 0xbd, 0x9f, 0x04, 0xae, 0x52, 0xc8, 0x29, 0xd4,
+	0xae, 0x79, 0x88, 0x3d, 0xd8, 0xf3, 0xa7, 0x9c, 0x7e, 0xd5, 0x0d, 0x5d, 0x1d, 0xf4, 0x7b, 0x80,
+	0x63, 0x26, 0x46, 0x9c, 0x8e, 0x13, 0x2f, 0x8e, 0x5c, 0xf6, 0x2d, 0x63, 0xa9, 0xe8, 0x23, 0x3c,
+	0x2b, 0x64, 0xe3, 0xe9, 0xa2, 0x7f, 0x0c, 0xdd, 0x9b, 0x2c, 0x8d, 0x76, 0xad, 0x5d, 0x38, 0x2a,
+	0xa6, 0xa5, 0xb7, 0x03, 0x07, 0x63, 0x26, 0xae, 0x79, 0x98, 0xbb, 0x0e, 0xa0, 0x95, 0x27, 0xa4,
+	0xfe, 0xb3, 0x0a, 0x87, 0xb2, 0x6a, 0xe3, 0xc0, 0x01, 0xd4, 0x7d, 0x1e, 0xe8, 0xeb, 0xb6, 0x2e,
+	0x4f, 0x1d, 0x8d, 0xd0, 0x29, 0xba, 0x9c, 0x21, 0x0f, 0x16, 0xae, 0x32, 0x92, 0x3f, 0x06, 0xd4,
+	0x65, 0xf8, 0x74, 0x54, 0x36, 0xd4, 0xe8, 0x24, 0xb0, 0xaa, 0x25, 0xa4, 0xa4, 0x80, 0x04, 0x9a,
+	0x29, 0x8d, 0xd8, 0xcc, 0xfb, 0x38, 0xb2, 0x6a, 0x0a, 0xd2, 0x3a, 0x46, 0x0b, 0x1a, 0x34, 0x61,
+	0x9e, 0xe0, 0x89, 0x22, 0xbd, 0xef, 0xe6, 0x21, 0xbe, 0x84, 0xda, 0x94, 0x87, 0xd6, 0x9e, 0x9a,
+	0xbb, 0x97, 0xcf, 0x9d, 0x2f, 0xd2, 0x91, 0xc3, 0x4b, 0x03, 0x5e, 0x40, 0x33, 0x9d, 0x7b, 0x71,
+	0x1a, 0x71, 0x61, 0x99, 0x25, 0x23, 0xac, 0x55, 0x89, 0x74, 0xcc, 0xc4, 0x15, 0xf3, 0x82, 0x2d,
+	0x82, 0x87, 0xd0, 0x5e, 0xb3, 0x90, 0x08, 0x8f, 0xa0, 0xb3, 0x6d, 0x8a, 0xa7, 0x8b, 0xcb, 0xdf,
+	0x55, 0x68, 0x7c, 0x66, 0xc9, 0xf7, 0x09, 0x65, 0xf8, 0x41, 0x01, 0xcf, 0xb7, 0x82, 0x24, 0x9f,
+	0xeb, 0xf1, 0xb2, 0x89, 0x55, 0xaa, 0xc9, 0x33, 0x2a, 0x78, 0xa5, 0x4f, 0x5d, 0xf7, 0x29, 0xec,
+	0x65, 0xb7, 0xd1, 0x49, 0xb9, 0xa8, 0x3b, 0xbd, 0x05, 0x53, 0xbf, 0x00, 0x3c, 0xde, 0x3a, 0x6f,
+	0x73, 0x41, 0xd2, 0xdd, 0x4d, 0xeb, 0xba, 0x77, 0xd0, 0x78, 0xb8, 0x37, 0x3e, 0x2f, 0x7f, 0x14,
+	0xa4, 0xf7, 0x28, 0xaf, 0x4b, 0x87, 0x00, 0x1b, 0x44, 0x78, 0xb2, 0xd5, 0xbf, 0xc8, 0x96, 0xbc,
+	0x28, 0x93, 0x54, 0x8f, 0xa1, 0xf5, 0x6b, 0x69, 0x1b, 0xb7, 0x4b, 0xdb, 0xf8, 0xb7, 0xb4, 0x8d,
+	0x1f, 0x2b, 0xbb, 0x72, 0xbb, 0xb2, 0x2b, 0x7f, 0x57, 0x76, 0xc5, 0x37, 0xd5, 0x4f, 0xfb, 0xe6,
+	0x7f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x27, 0x89, 0xbc, 0x82, 0xf8, 0x03, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1105,6 +1109,18 @@ func (m *PushLogRequest_Body) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Snapshot != nil {
+		{
+			size := m.Snapshot.Size()
+			i -= size
+			if _, err := m.Snapshot.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
 	if m.Log != nil {
 		{
 			size, err := m.Log.MarshalToSizedBuffer(dAtA[:i])
@@ -1361,6 +1377,10 @@ func (m *PushLogRequest_Body) Size() (n int) {
 		l = m.Log.Size()
 		n += 1 + l + sovNet(uint64(l))
 	}
+	if m.Snapshot != nil {
+		l = m.Snapshot.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
 	return n
 }
 
@@ -2188,6 +2208,41 @@ func (m *PushLogRequest_Body) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Snapshot", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoCid
+			m.Snapshot = &v
+			if err := m.Snapshot.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipNet(dAtA[iNdEx:])
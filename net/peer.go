@@ -77,6 +77,9 @@ type Peer struct {
 	replicators map[string]map[peer.ID]struct{}
 	mu          sync.Mutex
 
+	// the signatures accepted on the blocks received from peers
+	signaturePolicy SignaturePolicy
+
 	// peer DAG service
 	ipld.DAGService
 	exch  exchange.Interface
@@ -94,6 +97,7 @@ func NewPeer(
 	dht routing.Routing,
 	ps *pubsub.PubSub,
 	tcpAddr ma.Multiaddr,
+	signaturePolicy SignaturePolicy,
 	serverOptions []grpc.ServerOption,
 	dialOptions []grpc.DialOption,
 ) (*Peer, error) {
@@ -101,6 +105,13 @@ func NewPeer(
 		return nil, errors.New("database object can't be empty")
 	}
 
+	if len(signaturePolicy.TrustedSigners) > 0 {
+		// the blocks of this peer may come back to it through the other peers
+		trusted := make([]peer.ID, 0, len(signaturePolicy.TrustedSigners)+1)
+		trusted = append(trusted, signaturePolicy.TrustedSigners...)
+		signaturePolicy.TrustedSigners = append(trusted, h.ID())
+	}
+
 	ctx, cancel := context.WithCancel(ctx)
 	p := &Peer{
 		host:           h,
@@ -114,6 +125,8 @@ func NewPeer(
 		sendJobs:       make(chan *dagJob),
 		replicators:    make(map[string]map[peer.ID]struct{}),
 		queuedChildren: newCidSafeSet(),
+
+		signaturePolicy: signaturePolicy,
 	}
 	var err error
 	p.server, err = newServer(p, db, dialOptions...)
@@ -191,6 +204,12 @@ func (p *Peer) Start() error {
 	return nil
 }
 
+// SignaturePolicy returns the policy applied to the signatures of the blocks received from
+// other peers.
+func (p *Peer) SignaturePolicy() SignaturePolicy {
+	return p.signaturePolicy
+}
+
 // Close the peer node and all its internal workers/goroutines/loops.
 func (p *Peer) Close() error {
 	// close topics
@@ -670,6 +689,11 @@ func (p *Peer) handleDocUpdateLog(evt events.Update) error {
 		logging.NewKV("CID", evt.Cid),
 		logging.NewKV("SchemaId", evt.SchemaID))
 
+	snapshot, err := p.getDocSnapshot(p.ctx, evt.DocKey)
+	if err != nil {
+		return err
+	}
+
 	body := &pb.PushLogRequest_Body{
 		DocKey:   &pb.ProtoDocKey{DocKey: dockey},
 		Cid:      &pb.ProtoCid{Cid: evt.Cid},
@@ -678,6 +702,7 @@ func (p *Peer) handleDocUpdateLog(evt events.Update) error {
 		Log: &pb.Document_Log{
 			Block: evt.Block.RawData(),
 		},
+		Snapshot: snapshot,
 	}
 	req := &pb.PushLogRequest{
 		Body: body,
@@ -697,6 +722,28 @@ func (p *Peer) handleDocUpdateLog(evt events.Update) error {
 	return nil
 }
 
+// getDocSnapshot returns the latest compaction snapshot of the given document, or nil if the
+// history of the document has never been compacted.
+//
+// Peers that never had the history of the document need the snapshot to sync it, as the
+// blocks folded into it are not served anymore.
+func (p *Peer) getDocSnapshot(ctx context.Context, dockey string) (*pb.ProtoCid, error) {
+	txn, err := p.db.NewTxn(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	defer txn.Discard(ctx)
+
+	snapshot, isCompacted, err := clock.GetDocSnapshot(ctx, txn.Systemstore(), dockey)
+	if err != nil {
+		return nil, err
+	}
+	if !isCompacted {
+		return nil, nil
+	}
+	return &pb.ProtoCid{Cid: snapshot}, nil
+}
+
 func (p *Peer) pushLogToReplicators(ctx context.Context, lg events.Update) {
 	// push to each peer (replicator)
 	peers := make(map[string]struct{})
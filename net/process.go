@@ -13,18 +13,24 @@
 package net
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"sync"
 
+	"github.com/fxamacker/cbor/v2"
 	blocks "github.com/ipfs/go-block-format"
 	"github.com/ipfs/go-cid"
+	ds "github.com/ipfs/go-datastore"
 	ipld "github.com/ipfs/go-ipld-format"
+	"github.com/libp2p/go-libp2p/core/peer"
 
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/core"
+	corecrdt "github.com/sourcenetwork/defradb/core/crdt"
 	"github.com/sourcenetwork/defradb/datastore"
 	"github.com/sourcenetwork/defradb/db/base"
+	"github.com/sourcenetwork/defradb/db/fulltext"
 	"github.com/sourcenetwork/defradb/errors"
 	"github.com/sourcenetwork/defradb/events"
 	"github.com/sourcenetwork/defradb/logging"
@@ -43,10 +49,44 @@ func (p *Peer) processLog(
 	field string,
 	nd ipld.Node,
 	getter ipld.NodeGetter,
+	conflicts *conflictChecks,
 	removeChildren bool,
 ) ([]cid.Cid, error) {
 	log.Debug(ctx, "Running processLog")
 
+	cids, err := mergeBlock(ctx, p.db, txn, col, dockey, c, field, nd, getter, conflicts, p.signaturePolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	if removeChildren {
+		// mark this obj as done
+		p.queuedChildren.Remove(c)
+	}
+
+	return cids, nil
+}
+
+// mergeBlock merges the given block into the state of its document and returns the Cids of the
+// children of the block that are not known yet.
+//
+// It is the common path of the blocks received from other peers and of the imported blocks.
+// The conflicts of the merged blocks with the local updates are collected into the given
+// conflictChecks, and the blocks whose signature is not accepted by the given policy are
+// rejected.
+func mergeBlock(
+	ctx context.Context,
+	db client.DB,
+	txn datastore.Txn,
+	col client.Collection,
+	dockey core.DataStoreKey,
+	c cid.Cid,
+	field string,
+	nd ipld.Node,
+	getter ipld.NodeGetter,
+	conflicts *conflictChecks,
+	policy SignaturePolicy,
+) ([]cid.Cid, error) {
 	crdt, err := initCRDTForType(ctx, txn, col, dockey, field)
 	if err != nil {
 		return nil, err
@@ -57,6 +97,10 @@ func (p *Peer) processLog(
 		return nil, errors.Wrap("failed to decode delta object", err)
 	}
 
+	if err := policy.verify(nd, delta); err != nil {
+		return nil, err
+	}
+
 	log.Debug(
 		ctx,
 		"Processing PushLog request",
@@ -68,18 +112,65 @@ func (p *Peer) processLog(
 		return nil, err
 	}
 
-	ng := p.createNodeGetter(crdt, getter)
+	if err := conflicts.track(ctx, db, txn, col, dockey, field, nd, crdt); err != nil {
+		return nil, err
+	}
+
+	ng := createNodeGetter(crdt, getter)
 	cids, err := crdt.Clock().ProcessNode(ctx, ng, c, delta.GetPriority(), delta, nd)
 	if err != nil {
 		return nil, err
 	}
 
-	if removeChildren {
-		// mark this obj as done
-		p.queuedChildren.Remove(c)
+	// the merged values of the document are only known once the block has been processed
+	if err := fulltext.Refresh(ctx, txn.Datastore(), col.Description(), dockey.DocKey); err != nil {
+		return nil, err
 	}
 
-	return cids, nil
+	return filterCompactedChildren(ctx, txn, col, dockey, field, c, delta.GetPriority(), cids)
+}
+
+// SignaturePolicy defines which of the blocks received from other peers, or imported, are
+// accepted with regard to their signature. Blocks with an invalid signature are always rejected.
+type SignaturePolicy struct {
+	// RequireSigned rejects the blocks that are not signed.
+	RequireSigned bool
+	// TrustedSigners, if not empty, rejects the blocks that are not signed by one of the
+	// given peers.
+	TrustedSigners []peer.ID
+}
+
+// verify verifies the signature of the given block, from which the given delta has been
+// decoded, against the policy.
+func (sp SignaturePolicy) verify(nd ipld.Node, delta core.Delta) error {
+	signer, err := clock.VerifySignature(nd, delta)
+	if err != nil {
+		return err
+	}
+	if signer == nil {
+		if sp.RequireSigned || len(sp.TrustedSigners) > 0 {
+			return errors.New("block is not signed", errors.NewKV("CID", nd.Cid()))
+		}
+		return nil
+	}
+	if len(sp.TrustedSigners) == 0 {
+		return nil
+	}
+
+	signerID, err := peer.IDFromPublicKey(signer)
+	if err != nil {
+		return err
+	}
+	for _, trusted := range sp.TrustedSigners {
+		if signerID == trusted {
+			return nil
+		}
+	}
+	return errors.New(
+		"block is not signed by a trusted peer",
+		errors.NewKV("CID", nd.Cid()),
+		errors.NewKV("Signer", signerID),
+	)
 }
 
 func initCRDTForType(
@@ -121,6 +212,350 @@ func initCRDTForType(
 	)
 }
 
+// conflictChecks collects the conflict checks of the fields merged by a DAG sync, so that
+// conflicts are only decided once all the blocks of the sync have been merged.
+//
+// Blocks are merged from the remote heads down to the local ones, so a local head that is not
+// a parent of a remote block may still be one of its ancestors. It is only known to be
+// concurrent to the remote block if it is still a head once the whole DAG has been merged.
+type conflictChecks struct {
+	mu     sync.Mutex
+	checks map[string]*conflictCheck
+}
+
+func newConflictChecks() *conflictChecks {
+	return &conflictChecks{
+		checks: map[string]*conflictCheck{},
+	}
+}
+
+// track records the local state of the field of the given block, unless a block of this field
+// has already been merged by the sync.
+func (ccs *conflictChecks) track(
+	ctx context.Context,
+	db client.DB,
+	txn datastore.Txn,
+	col client.Collection,
+	dockey core.DataStoreKey,
+	field string,
+	nd ipld.Node,
+	crdt crdt.MerkleCRDT,
+) error {
+	ccs.mu.Lock()
+	defer ccs.mu.Unlock()
+
+	key := dockey.DocKey + "/" + field
+	if _, ok := ccs.checks[key]; ok {
+		// the local state of the field has already been modified by the sync
+		return nil
+	}
+	check, err := newConflictCheck(ctx, db, txn, col, dockey, field, nd, crdt)
+	if err != nil {
+		return err
+	}
+	ccs.checks[key] = check
+	return nil
+}
+
+// publish publishes the conflicts of the merged blocks with the local updates once the
+// transaction succeeds.
+func (ccs *conflictChecks) publish(ctx context.Context, txn datastore.Txn) error {
+	ccs.mu.Lock()
+	defer ccs.mu.Unlock()
+
+	for _, check := range ccs.checks {
+		if err := check.publishIfOverwritten(ctx, txn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// conflictCheck holds the local state of a field prior to merging remote blocks into it,
+// so that a conflict can be published should the remote blocks overwrite a concurrent
+// local update.
+type conflictCheck struct {
+	conflicts events.ConflictChannel
+	crdt      crdt.MerkleCRDT
+	headKey   core.HeadStoreKey
+	col       client.Collection
+	dockey    string
+	field     string
+
+	// remoteHead is the first merged remote block of the field.
+	remoteHead cid.Cid
+	// localHeads are the local field heads that are not parents of the remote block.
+	localHeads []cid.Cid
+	localValue []byte
+}
+
+// newConflictCheck returns a conflictCheck for the given field block, or nil if the block
+// cannot conflict with a local update. That is the case for composite blocks, and for
+// blocks that descend from all the local heads of the field.
+func newConflictCheck(
+	ctx context.Context,
+	db client.DB,
+	txn datastore.Txn,
+	col client.Collection,
+	dockey core.DataStoreKey,
+	field string,
+	nd ipld.Node,
+	crdt crdt.MerkleCRDT,
+) (*conflictCheck, error) {
+	conflicts := db.Events().Conflicts
+	if field == "" || !conflicts.HasValue() {
+		return nil, nil
+	}
+
+	fd, ok := col.Description().GetField(field)
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("Couldn't find field %s for doc %s", field, dockey))
+	}
+	headKey := core.HeadStoreKey{DocKey: dockey.DocKey, FieldId: fd.ID.String()}
+	heads, _, err := clock.NewHeadSet(txn.Headstore(), headKey).List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	parents := map[cid.Cid]struct{}{}
+	for _, l := range nd.Links() {
+		if l.Name == core.HEAD {
+			parents[l.Cid] = struct{}{}
+		}
+	}
+
+	localHeads := []cid.Cid{}
+	for _, head := range heads {
+		if head.Equals(nd.Cid()) {
+			// the block is already known
+			return nil, nil
+		}
+		if _, ok := parents[head]; !ok {
+			localHeads = append(localHeads, head)
+		}
+	}
+	if len(localHeads) == 0 {
+		return nil, nil
+	}
+
+	localValue, err := crdt.Value(ctx)
+	if errors.Is(err, ds.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &conflictCheck{
+		conflicts:  conflicts,
+		crdt:       crdt,
+		headKey:    headKey,
+		col:        col,
+		dockey:     dockey.DocKey,
+		field:      field,
+		remoteHead: nd.Cid(),
+		localHeads: localHeads,
+		localValue: localValue,
+	}, nil
+}
+
+// publishIfOverwritten publishes a conflict once the transaction succeeds if one of the local
+// heads is still concurrent to the merged remote blocks, and if merging them has overwritten
+// the local value.
+func (cc *conflictCheck) publishIfOverwritten(ctx context.Context, txn datastore.Txn) error {
+	if cc == nil {
+		return nil
+	}
+
+	heads, _, err := clock.NewHeadSet(txn.Headstore(), cc.headKey).List(ctx)
+	if err != nil {
+		return err
+	}
+	localHead := cid.Undef
+	for _, head := range heads {
+		for _, c := range cc.localHeads {
+			if head.Equals(c) && !localHead.Defined() {
+				localHead = c
+			}
+		}
+	}
+	if !localHead.Defined() {
+		// the local heads were ancestors of the remote blocks
+		return nil
+	}
+
+	value, err := cc.crdt.Value(ctx)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(value, cc.localValue) {
+		return nil
+	}
+
+	losingValue, err := decodeFieldValue(cc.localValue)
+	if err != nil {
+		return err
+	}
+	winningValue, err := decodeFieldValue(value)
+	if err != nil {
+		return err
+	}
+
+	conflict := events.Conflict{
+		DocKey:       cc.dockey,
+		SchemaID:     cc.col.SchemaID(),
+		FieldName:    cc.field,
+		LosingCid:    localHead,
+		WinningCid:   cc.remoteHead,
+		LosingValue:  losingValue,
+		WinningValue: winningValue,
+	}
+	txn.OnSuccess(func() {
+		cc.conflicts.Value().Publish(conflict)
+	})
+	return nil
+}
+
+func decodeFieldValue(buf []byte) (any, error) {
+	if len(buf) == 0 {
+		return nil, nil
+	}
+	var value any
+	err := cbor.Unmarshal(buf, &value)
+	return value, err
+}
+
+// processSnapshot merges the compaction snapshot of the given Cid into the document, unless
+// it is already known.
+//
+// The blocks folded into the snapshot are not served by any peer, so the ones still linked to
+// by the retained blocks of the document are recorded as folded into the snapshot before the
+// retained blocks are synced.
+func (p *Peer) processSnapshot(
+	ctx context.Context,
+	txn datastore.Txn,
+	col client.Collection,
+	dockey core.DataStoreKey,
+	snapshot cid.Cid,
+	getter ipld.NodeGetter,
+	conflicts *conflictChecks,
+) error {
+	known, err := txn.DAGstore().Has(ctx, snapshot)
+	if err != nil {
+		return err
+	}
+	if known {
+		return nil
+	}
+	_, isCompacted, err := clock.GetCompactedSnapshot(ctx, txn.Systemstore(), snapshot)
+	if err != nil {
+		return err
+	}
+	if isCompacted {
+		return nil
+	}
+
+	getCtx, cancel := context.WithTimeout(ctx, DAGSyncTimeout)
+	defer cancel()
+	nd, err := getter.Get(getCtx, snapshot)
+	if err != nil {
+		return errors.Wrap(fmt.Sprintf("failed to get snapshot %s", snapshot), err)
+	}
+	delta, err := corecrdt.CompositeDAG{}.DeltaDecode(nd)
+	if err != nil {
+		return errors.Wrap("failed to decode delta object", err)
+	}
+
+	for _, buf := range delta.(*corecrdt.CompositeDAGDelta).Compacted {
+		c, err := cid.Cast(buf)
+		if err != nil {
+			return err
+		}
+		hasBlock, err := txn.DAGstore().Has(ctx, c)
+		if err != nil {
+			return err
+		}
+		if hasBlock {
+			continue
+		}
+		if err := clock.MarkCompacted(ctx, txn.Systemstore(), c, snapshot); err != nil {
+			return err
+		}
+	}
+
+	cids, err := p.processLog(ctx, txn, col, dockey, snapshot, "", nd, getter, conflicts, false)
+	if err != nil {
+		return err
+	}
+	if len(cids) > 0 {
+		var session sync.WaitGroup
+		p.handleChildBlocks(&session, txn, col, dockey, "", nd, cids, getter, conflicts)
+		session.Wait()
+		p.closeJob <- dockey.DocKey
+	}
+
+	// the snapshot is passed on to the peers that sync the document from this one
+	_, hasSnapshot, err := clock.GetDocSnapshot(ctx, txn.Systemstore(), dockey.DocKey)
+	if err != nil {
+		return err
+	}
+	if hasSnapshot {
+		return nil
+	}
+	return clock.SetDocSnapshot(ctx, txn.Systemstore(), dockey.DocKey, snapshot)
+}
+
+// filterCompactedChildren removes the children that have been folded into a snapshot
+// block by a history compaction. Those blocks are no longer in the blockstore but they
+// are known, so like any other known block the given root becomes a new head, replacing
+// the snapshot if it is a head.
+func filterCompactedChildren(
+	ctx context.Context,
+	txn datastore.MultiStore,
+	col client.Collection,
+	dockey core.DataStoreKey,
+	field string,
+	root cid.Cid,
+	rootPrio uint64,
+	children []cid.Cid,
+) ([]cid.Cid, error) {
+	remaining := make([]cid.Cid, 0, len(children))
+	for _, child := range children {
+		snapshot, isCompacted, err := clock.GetCompactedSnapshot(ctx, txn.Systemstore(), child)
+		if err != nil {
+			return nil, err
+		}
+		if !isCompacted {
+			remaining = append(remaining, child)
+			continue
+		}
+
+		fieldID := core.COMPOSITE_NAMESPACE
+		if field != "" {
+			fd, ok := col.Description().GetField(field)
+			if !ok {
+				return nil, errors.New(fmt.Sprintf("Couldn't find field %s for doc %s", field, dockey))
+			}
+			fieldID = fd.ID.String()
+		}
+
+		headset := clock.NewHeadSet(txn.Headstore(), core.HeadStoreKey{DocKey: dockey.DocKey, FieldId: fieldID})
+		isHead, err := headset.IsHead(ctx, snapshot)
+		if err != nil {
+			return nil, err
+		}
+		if isHead {
+			err = headset.Replace(ctx, snapshot, root, rootPrio)
+		} else {
+			err = headset.Write(ctx, root, rootPrio)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return remaining, nil
+}
+
 func decodeBlockBuffer(buf []byte, cid cid.Cid) (ipld.Node, error) {
 	blk, err := blocks.NewBlockWithCid(buf, cid)
 	if err != nil {
@@ -129,7 +564,7 @@ func decodeBlockBuffer(buf []byte, cid cid.Cid) (ipld.Node, error) {
 	return ipld.Decode(blk)
 }
 
-func (p *Peer) createNodeGetter(
+func createNodeGetter(
 	crdt crdt.MerkleCRDT,
 	getter ipld.NodeGetter,
 ) *clock.CrdtNodeGetter {
@@ -148,6 +583,7 @@ func (p *Peer) handleChildBlocks(
 	nd ipld.Node,
 	children []cid.Cid,
 	getter ipld.NodeGetter,
+	conflicts *conflictChecks,
 ) {
 	if len(children) == 0 {
 		return
@@ -200,6 +636,7 @@ func (p *Peer) handleChildBlocks(
 			nodeGetter: getter,
 			node:       cNode,
 			txn:        txn,
+			conflicts:  conflicts,
 		}
 
 		select {
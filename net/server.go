@@ -251,6 +251,16 @@ func (s *server) PushLog(ctx context.Context, req *pb.PushLogRequest) (*pb.PushL
 		defer txn.Discard(ctx)
 		store := s.db.WithTxn(txn)
 
+		// purged documents must not be re-created from the blocks of other peers
+		isPurged, err := txn.Systemstore().Has(ctx, core.NewPurgedDocKey(docKey.DocKey).ToDS())
+		if err != nil {
+			return nil, err
+		}
+		if isPurged {
+			log.Debug(ctx, "Ignoring PushLog of a purged document", logging.NewKV("DocKey", docKey))
+			return &pb.PushLogReply{}, nil
+		}
+
 		col, err := store.GetCollectionBySchemaID(ctx, schemaID)
 		if err != nil {
 			return nil, errors.Wrap(fmt.Sprintf("Failed to get collection from schemaID %s", schemaID), err)
@@ -263,13 +273,23 @@ func (s *server) PushLog(ctx context.Context, req *pb.PushLogRequest) (*pb.PushL
 			getter = sessionMaker.Session(ctx)
 		}
 
+		conflicts := newConflictChecks()
+
+		// the blocks folded by a compaction of the document are only known through its snapshot
+		if req.Body.Snapshot != nil {
+			err = s.peer.processSnapshot(ctx, txn, col, docKey, req.Body.Snapshot.Cid, getter, conflicts)
+			if err != nil {
+				return nil, errors.Wrap("failed to process snapshot", err)
+			}
+		}
+
 		// handleComposite
 		nd, err := decodeBlockBuffer(req.Body.Log.Block, cid)
 		if err != nil {
 			return nil, errors.Wrap("failed to decode block to ipld.Node", err)
 		}
 
-		cids, err := s.peer.processLog(ctx, txn, col, docKey, cid, "", nd, getter, false)
+		cids, err := s.peer.processLog(ctx, txn, col, docKey, cid, "", nd, getter, conflicts, false)
 		if err != nil {
 			log.ErrorE(
 				ctx,
@@ -289,7 +309,7 @@ func (s *server) PushLog(ctx context.Context, req *pb.PushLogRequest) (*pb.PushL
 				logging.NewKV("CID", cid),
 			)
 			var session sync.WaitGroup
-			s.peer.handleChildBlocks(&session, txn, col, docKey, "", nd, cids, getter)
+			s.peer.handleChildBlocks(&session, txn, col, docKey, "", nd, cids, getter, conflicts)
 			session.Wait()
 			// dagWorkers specific to the dockey will have been spawned within handleChildBlocks.
 			// Once we are done with the dag syncing process, we can get rid of those workers.
@@ -298,6 +318,10 @@ func (s *server) PushLog(ctx context.Context, req *pb.PushLogRequest) (*pb.PushL
 			log.Debug(ctx, "No more children to process for log", logging.NewKV("CID", cid))
 		}
 
+		if err := conflicts.publish(ctx, txn); err != nil {
+			return nil, err
+		}
+
 		if txnErr = txn.Commit(ctx); txnErr != nil {
 			if errors.Is(txnErr, badger.ErrTxnConflict) {
 				continue
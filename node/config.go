@@ -16,6 +16,7 @@ import (
 	"time"
 
 	cconnmgr "github.com/libp2p/go-libp2p/core/connmgr"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/p2p/net/connmgr"
 	ma "github.com/multiformats/go-multiaddr"
 	"google.golang.org/grpc"
@@ -23,11 +24,16 @@ import (
 
 // Options is the node options.
 type Options struct {
-	ListenAddrs       []ma.Multiaddr
-	TCPAddr           ma.Multiaddr
-	DataPath          string
-	EnablePubSub      bool
-	EnableRelay       bool
+	ListenAddrs  []ma.Multiaddr
+	TCPAddr      ma.Multiaddr
+	DataPath     string
+	EnablePubSub bool
+	EnableRelay  bool
+	// RequireSignedBlocks rejects the blocks received from peers that are not signed.
+	RequireSignedBlocks bool
+	// TrustedSigners, if not empty, rejects the blocks received from peers that are not
+	// signed by one of the given peers.
+	TrustedSigners    []peer.ID
 	GRPCServerOptions []grpc.ServerOption
 	GRPCDialOptions   []grpc.DialOption
 	ConnManager       cconnmgr.ConnManager
@@ -82,6 +88,23 @@ func WithEnableRelay(enable bool) NodeOpt {
 	}
 }
 
+// WithRequireSignedBlocks rejects the blocks received from peers that are not signed.
+func WithRequireSignedBlocks(require bool) NodeOpt {
+	return func(opt *Options) error {
+		opt.RequireSignedBlocks = require
+		return nil
+	}
+}
+
+// WithTrustedSigners rejects the blocks received from peers that are not signed by one of
+// the given peers.
+func WithTrustedSigners(signers ...peer.ID) NodeOpt {
+	return func(opt *Options) error {
+		opt.TrustedSigners = signers
+		return nil
+	}
+}
+
 // ListenP2PAddrStrings sets the address to listen on given as strings.
 func ListenP2PAddrStrings(addrs ...string) NodeOpt {
 	return func(opt *Options) error {
@@ -101,7 +101,7 @@ func NewNode(
 	}
 	fin.Add(peerstore)
 
-	hostKey, err := getHostKey(options.DataPath)
+	hostKey, err := GetHostKey(options.DataPath)
 	if err != nil {
 		return nil, fin.Cleanup(err)
 	}
@@ -162,6 +162,10 @@ func NewNode(
 		ddht,
 		ps,
 		options.TCPAddr,
+		net.SignaturePolicy{
+			RequireSigned:  options.RequireSignedBlocks,
+			TrustedSigners: options.TrustedSigners,
+		},
 		options.GRPCServerOptions,
 		options.GRPCDialOptions,
 	)
@@ -374,8 +378,11 @@ func (n *Node) WaitForPushLogFromPeerEvent(id peer.ID) error {
 	}
 }
 
+// GetHostKey returns the private key of the libp2p host stored in the given directory,
+// generating a new key if none exists yet.
+//
 // replace with proper keystore
-func getHostKey(keypath string) (crypto.PrivKey, error) {
+func GetHostKey(keypath string) (crypto.PrivKey, error) {
 	// If a local datastore is used, the key is written to a file
 	pth := filepath.Join(keypath, "key")
 	_, err := os.Stat(pth)
@@ -11,16 +11,21 @@
 package planner
 
 import (
+	"time"
+
 	"github.com/fxamacker/cbor/v2"
 	dag "github.com/ipfs/boxo/ipld/merkledag"
 	blocks "github.com/ipfs/go-block-format"
 	cid "github.com/ipfs/go-cid"
 	ipld "github.com/ipfs/go-ipld-format"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
 
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/client/request"
 	"github.com/sourcenetwork/defradb/core"
 	"github.com/sourcenetwork/defradb/db/fetcher"
+	"github.com/sourcenetwork/defradb/merkle/clock"
 	"github.com/sourcenetwork/defradb/planner/mapper"
 )
 
@@ -209,6 +214,32 @@ func (n *dagScanNode) Next() (bool, error) {
 		return n.Next()
 	}
 
+	hasBlock, err := store.Has(n.planner.ctx, *currentCid)
+	if err != nil {
+		return false, err
+	}
+	if !hasBlock {
+		snapshot, isCompacted, err := clock.GetCompactedSnapshot(
+			n.planner.ctx,
+			n.planner.txn.Systemstore(),
+			*currentCid,
+		)
+		if err != nil {
+			return false, err
+		}
+		if isCompacted {
+			// The history behind this block has been folded into a snapshot block.
+			// Field histories end here, whilst the composite history continues from
+			// the snapshot.
+			n.visitedNodes[currentCid.String()] = true
+			if n.commitSelect.FieldID.HasValue() && n.commitSelect.FieldID.Value() != core.COMPOSITE_NAMESPACE {
+				return n.Next()
+			}
+			n.queuedCids = append([]*cid.Cid{&snapshot}, n.queuedCids...)
+			return n.Next()
+		}
+	}
+
 	// use the stored cid to scan through the blockstore
 	// clear the cid after
 	block, err := store.Get(n.planner.ctx, *currentCid)
@@ -345,6 +376,24 @@ func (n *dagScanNode) dagBlockToNodeDoc(block blocks.Block) (core.Doc, []*ipld.L
 	n.commitSelect.DocumentMapping.SetFirstOfName(&commit, request.FieldNameFieldName, fieldName)
 	n.commitSelect.DocumentMapping.SetFirstOfName(&commit, request.FieldIDFieldName, fieldID)
 
+	signer, err := getBlockSigner(delta)
+	if err != nil {
+		return core.Doc{}, nil, err
+	}
+	n.commitSelect.DocumentMapping.SetFirstOfName(&commit, request.SignerFieldName, signer)
+
+	var author any
+	if a, ok := delta["Author"].(string); ok && a != "" {
+		author = a
+	}
+	n.commitSelect.DocumentMapping.SetFirstOfName(&commit, request.AuthorFieldName, author)
+
+	var commitTime any
+	if t, ok := delta["CommitTime"].(uint64); ok && t != 0 {
+		commitTime = time.Unix(0, int64(t)).UTC().Format(time.RFC3339Nano)
+	}
+	n.commitSelect.DocumentMapping.SetFirstOfName(&commit, request.TimeFieldName, commitTime)
+
 	dockey, ok := delta["DocKey"].([]byte)
 	if !ok {
 		return core.Doc{}, nil, ErrDeltaMissingDockey
@@ -391,3 +440,21 @@ func (n *dagScanNode) dagBlockToNodeDoc(block blocks.Block) (core.Doc, []*ipld.L
 }
 
 func (n *dagScanNode) Append() bool { return true }
+
+// getBlockSigner returns the peer ID of the node that signed the block of the given
+// delta, or nil if the block is not signed.
+func getBlockSigner(delta map[string]any) (any, error) {
+	signer, ok := delta["Signer"].([]byte)
+	if !ok || len(signer) == 0 {
+		return nil, nil
+	}
+	pubKey, err := crypto.UnmarshalPublicKey(signer)
+	if err != nil {
+		return nil, err
+	}
+	id, err := peer.IDFromPublicKey(pubKey)
+	if err != nil {
+		return nil, err
+	}
+	return id.String(), nil
+}
@@ -126,7 +126,7 @@ func (n *countNode) Next() (bool, error) {
 		switch v.Kind() {
 		// v.Len will panic if v is not one of these types, we don't want it to panic
 		case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice, reflect.String:
-			if source.Filter == nil && source.Limit == nil {
+			if source.Filter == nil && source.Limit == nil && source.Distinct == nil {
 				count = count + v.Len()
 			} else {
 				var arrayCount int
@@ -136,28 +136,28 @@ func (n *countNode) Next() (bool, error) {
 					arrayCount = countDocs(array)
 
 				case []bool:
-					arrayCount, err = countItems(array, source.Filter, source.Limit)
+					arrayCount, err = countItems(array, &source)
 
 				case []immutable.Option[bool]:
-					arrayCount, err = countItems(array, source.Filter, source.Limit)
+					arrayCount, err = countItems(array, &source)
 
 				case []int64:
-					arrayCount, err = countItems(array, source.Filter, source.Limit)
+					arrayCount, err = countItems(array, &source)
 
 				case []immutable.Option[int64]:
-					arrayCount, err = countItems(array, source.Filter, source.Limit)
+					arrayCount, err = countItems(array, &source)
 
 				case []float64:
-					arrayCount, err = countItems(array, source.Filter, source.Limit)
+					arrayCount, err = countItems(array, &source)
 
 				case []immutable.Option[float64]:
-					arrayCount, err = countItems(array, source.Filter, source.Limit)
+					arrayCount, err = countItems(array, &source)
 
 				case []string:
-					arrayCount, err = countItems(array, source.Filter, source.Limit)
+					arrayCount, err = countItems(array, &source)
 
 				case []immutable.Option[string]:
-					arrayCount, err = countItems(array, source.Filter, source.Limit)
+					arrayCount, err = countItems(array, &source)
 				}
 				if err != nil {
 					return false, err
@@ -185,17 +185,28 @@ func countDocs(docs []core.Doc) int {
 	return count
 }
 
-func countItems[T any](source []T, filter *mapper.Filter, limit *mapper.Limit) (int, error) {
+func countItems[T comparable](source []T, aggregateTarget *mapper.AggregateTarget) (int, error) {
 	items := enumerable.New(source)
-	if filter != nil {
+	if aggregateTarget.Filter != nil {
 		items = enumerable.Where(items, func(item T) (bool, error) {
-			return mapper.RunFilter(item, filter)
+			return mapper.RunFilter(item, aggregateTarget.Filter)
 		})
 	}
 
-	if limit != nil {
-		items = enumerable.Skip(items, limit.Offset)
-		items = enumerable.Take(items, limit.Limit)
+	if aggregateTarget.Distinct != nil {
+		seenItems := map[T]struct{}{}
+		items = enumerable.Where(items, func(item T) (bool, error) {
+			if _, isDuplicate := seenItems[item]; isDuplicate {
+				return false, nil
+			}
+			seenItems[item] = struct{}{}
+			return true, nil
+		})
+	}
+
+	if aggregateTarget.Limit != nil {
+		items = enumerable.Skip(items, aggregateTarget.Limit.Offset)
+		items = enumerable.Take(items, aggregateTarget.Limit.Limit)
 	}
 
 	count := 0
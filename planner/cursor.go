@@ -0,0 +1,367 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package planner
+
+import (
+	"encoding/base64"
+	"strings"
+
+	"github.com/fxamacker/cbor/v2"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/client/request"
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/db/base"
+	"github.com/sourcenetwork/defradb/planner/mapper"
+)
+
+// cursor identifies the position of a document within the ordered results of a select.
+//
+// Results are ordered by the values of their order conditions, and then by their key,
+// so both are held by the cursor.
+type cursor struct {
+	_ struct{} `cbor:",toarray"`
+
+	// The key of the document the cursor was taken from.
+	Key string
+
+	// The values of the order conditions of the document the cursor was taken from.
+	Values []any
+}
+
+// encodeCursor returns the opaque cursor of the given document.
+func encodeCursor(doc core.Doc, ordering []mapper.OrderCondition) (string, error) {
+	c := cursor{
+		Key:    doc.GetKey(),
+		Values: make([]any, len(ordering)),
+	}
+	for i, condition := range ordering {
+		c.Values[i] = getDocProp(doc, condition.FieldIndexes)
+	}
+
+	buf, err := cbor.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// decodeCursor decodes the given opaque cursor, returning an error if it was not taken
+// from results with the given ordering.
+func decodeCursor(source string, ordering []mapper.OrderCondition) (*cursor, error) {
+	buf, err := base64.RawURLEncoding.DecodeString(source)
+	if err != nil {
+		return nil, NewErrInvalidCursor(source)
+	}
+
+	var c cursor
+	if err := cbor.Unmarshal(buf, &c); err != nil {
+		return nil, NewErrInvalidCursor(source)
+	}
+	if c.Key == "" || len(c.Values) != len(ordering) {
+		return nil, NewErrInvalidCursor(source)
+	}
+	return &c, nil
+}
+
+// compareToCursor returns a negative number if the given document comes before the
+// position of the given cursor in the given ordering, a positive number if it comes after
+// and zero if it is the document that the cursor was taken from.
+func compareToCursor(doc core.Doc, ordering []mapper.OrderCondition, c *cursor) (int, error) {
+	for i, condition := range ordering {
+		result, err := compareCursorValues(getDocProp(doc, condition.FieldIndexes), c.Values[i])
+		if err != nil {
+			return 0, err
+		}
+		if condition.Direction == mapper.DESC {
+			result = -result
+		}
+		if result != 0 {
+			return result, nil
+		}
+	}
+	return strings.Compare(doc.GetKey(), c.Key), nil
+}
+
+// compareCursorValues compares the given document value with the given cursor value.
+//
+// Integers may be decoded from the cursor as a different type to those held by the
+// document, so they are normalized before comparing.
+func compareCursorValues(docValue any, cursorValue any) (int, error) {
+	if docValue == nil || cursorValue == nil {
+		return base.Compare(docValue, cursorValue), nil
+	}
+	return compareMinMaxValues(normalizeCursorValue(docValue), normalizeCursorValue(cursorValue))
+}
+
+func normalizeCursorValue(value any) any {
+	switch v := value.(type) {
+	case int:
+		return int64(v)
+	case uint64:
+		return int64(v)
+	default:
+		return value
+	}
+}
+
+// cursorNode yields only the documents positioned after the `after` cursor and before the
+// `before` cursor of a select.
+//
+// The documents are expected to be yielded by its source in order, so the node can stop
+// as soon as it reaches the `before` cursor.
+type cursorNode struct {
+	docMapper
+
+	p    *Planner
+	plan planNode
+
+	ordering []mapper.OrderCondition
+	after    *cursor
+	before   *cursor
+
+	// The number of documents immediately preceding the `before` cursor that should be
+	// yielded, if zero all documents preceding it are yielded.
+	limit uint64
+
+	// The documents preceding the `before` cursor, only used if there is a limit.
+	buffer       []core.Doc
+	bufferFilled bool
+
+	// Set once the documents preceding the `after` cursor have been skipped.
+	passedAfter bool
+
+	// Set once a document at or after the `before` cursor has been read.
+	reachedBefore bool
+
+	// True if documents were dropped from the start of the buffer.
+	//
+	// The scan may have been seeked past the documents preceding the `after` cursor, so
+	// they can not be relied upon to be seen, instead the presence of the cursor
+	// indicates a previous page.
+	droppedFromBuffer bool
+
+	currentValue core.Doc
+
+	execInfo cursorExecInfo
+}
+
+type cursorExecInfo struct {
+	// Total number of times cursorNode was executed.
+	iterations uint64
+
+	// Total number of documents skipped as they were outside of the cursors.
+	skipped uint64
+}
+
+// Cursor creates a new cursorNode initalized from the given select.
+func (p *Planner) Cursor(parsed *mapper.Select) (*cursorNode, error) {
+	if !parsed.After.HasValue() && !parsed.Before.HasValue() {
+		return nil, nil // nothing to do
+	}
+
+	var ordering []mapper.OrderCondition
+	if parsed.OrderBy != nil {
+		ordering = parsed.OrderBy.Conditions
+	}
+
+	n := &cursorNode{
+		p:         p,
+		ordering:  ordering,
+		docMapper: docMapper{&parsed.DocumentMapping},
+	}
+
+	var err error
+	if parsed.After.HasValue() {
+		n.after, err = decodeCursor(parsed.After.Value(), ordering)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if parsed.Before.HasValue() {
+		n.before, err = decodeCursor(parsed.Before.Value(), ordering)
+		if err != nil {
+			return nil, err
+		}
+		if parsed.Limit != nil {
+			n.limit = parsed.Limit.Limit
+		}
+	}
+
+	return n, nil
+}
+
+// seek restricts the spans of the given scan to start after the `after` cursor.
+//
+// This is only possible if the results are in key order, and the scan has not already
+// been restricted to specific documents. Results in any other order must be read in
+// full to be sorted, so they are skipped up to the cursor instead.
+//
+// The end of the spans is left open, so that the document following the `before` cursor
+// can be read to find whether there is a next page.
+func (n *cursorNode) seek(scan *scanNode, desc client.CollectionDescription) {
+	if !isKeyOrder(n.ordering) || scan.spans.HasValue || n.after == nil {
+		return
+	}
+
+	start := base.MakeDocKey(desc, n.after.Key).PrefixEnd()
+	end := base.MakeCollectionKey(desc).PrefixEnd()
+	scan.Spans(core.NewSpans(core.NewSpan(start, end)))
+}
+
+// isKeyOrder returns true if results in the given ordering are in ascending key order, which
+// is the order that documents are scanned in.
+func isKeyOrder(ordering []mapper.OrderCondition) bool {
+	if len(ordering) == 0 {
+		return true
+	}
+	// keys are unique, so any following conditions have no effect on the order
+	first := ordering[0]
+	return len(first.FieldIndexes) == 1 &&
+		first.FieldIndexes[0] == core.DocKeyFieldIndex &&
+		first.Direction == mapper.ASC
+}
+
+func (n *cursorNode) Kind() string {
+	return "cursorNode"
+}
+
+func (n *cursorNode) Init() error {
+	// reset stateful data
+	n.buffer = nil
+	n.bufferFilled = false
+	n.passedAfter = false
+	n.reachedBefore = false
+	n.droppedFromBuffer = false
+	return n.plan.Init()
+}
+
+func (n *cursorNode) Start() error           { return n.plan.Start() }
+func (n *cursorNode) Spans(spans core.Spans) { n.plan.Spans(spans) }
+func (n *cursorNode) Close() error           { return n.plan.Close() }
+func (n *cursorNode) Value() core.Doc        { return n.currentValue }
+func (n *cursorNode) Source() planNode       { return n.plan }
+
+func (n *cursorNode) Next() (bool, error) {
+	n.execInfo.iterations++
+
+	if n.before == nil || n.limit == 0 {
+		return n.nextWithinCursors()
+	}
+
+	if !n.bufferFilled {
+		if err := n.fillBuffer(); err != nil {
+			return false, err
+		}
+	}
+	if len(n.buffer) == 0 {
+		return false, nil
+	}
+	n.currentValue = n.buffer[0]
+	n.buffer = n.buffer[1:]
+	return true, nil
+}
+
+// fillBuffer reads the documents preceding the `before` cursor, keeping only as many of the
+// last of them as the limit permits.
+func (n *cursorNode) fillBuffer() error {
+	n.bufferFilled = true
+	for {
+		hasNext, err := n.nextWithinCursors()
+		if err != nil || !hasNext {
+			return err
+		}
+
+		n.buffer = append(n.buffer, n.currentValue.Clone())
+		if uint64(len(n.buffer)) > n.limit {
+			n.buffer = n.buffer[1:]
+			n.droppedFromBuffer = true
+		}
+	}
+}
+
+// nextWithinCursors moves to the next document between the cursors, returning false if
+// there are none left.
+func (n *cursorNode) nextWithinCursors() (bool, error) {
+	for {
+		if n.reachedBefore {
+			return false, nil
+		}
+
+		hasNext, err := n.plan.Next()
+		if !hasNext || err != nil {
+			return false, err
+		}
+		doc := n.plan.Value()
+
+		if n.after != nil && !n.passedAfter {
+			position, err := compareToCursor(doc, n.ordering, n.after)
+			if err != nil {
+				return false, err
+			}
+			if position <= 0 {
+				n.execInfo.skipped++
+				continue
+			}
+			// The documents are ordered, so all of the following documents are after the cursor.
+			n.passedAfter = true
+		}
+
+		if n.before != nil {
+			position, err := compareToCursor(doc, n.ordering, n.before)
+			if err != nil {
+				return false, err
+			}
+			if position >= 0 {
+				n.reachedBefore = true
+				return false, nil
+			}
+		}
+
+		n.currentValue = doc
+		return true, nil
+	}
+}
+
+// hasPrevious returns true if there are documents preceding those yielded by this node.
+func (n *cursorNode) hasPrevious() bool {
+	return n.after != nil || n.droppedFromBuffer
+}
+
+// hasNext returns true if there are documents following those yielded by this node.
+//
+// These are only known of once a document at or after the `before` cursor has been read.
+func (n *cursorNode) hasNext() bool {
+	return n.reachedBefore
+}
+
+func (n *cursorNode) simpleExplain() (map[string]any, error) {
+	return map[string]any{
+		afterLabel:  n.after != nil,
+		beforeLabel: n.before != nil,
+	}, nil
+}
+
+func (n *cursorNode) Explain(explainType request.ExplainType) (map[string]any, error) {
+	switch explainType {
+	case request.SimpleExplain:
+		return n.simpleExplain()
+
+	case request.ExecuteExplain:
+		return map[string]any{
+			"iterations": n.execInfo.iterations,
+			"skipped":    n.execInfo.skipped,
+		}, nil
+
+	default:
+		return nil, ErrUnknownExplainRequestType
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package planner
+
+import (
+	"encoding/json"
+
+	dag "github.com/ipfs/boxo/ipld/merkledag"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/client/request"
+	"github.com/sourcenetwork/defradb/core"
+	corecrdt "github.com/sourcenetwork/defradb/core/crdt"
+	"github.com/sourcenetwork/defradb/merkle/clock"
+	"github.com/sourcenetwork/defradb/planner/mapper"
+)
+
+// diffNode yields the field-level changes of a document between two versions.
+type diffNode struct {
+	documentIterator
+	docMapper
+
+	p          *Planner
+	diffSelect *mapper.DiffSelect
+
+	diff  []client.FieldDiff
+	index int
+}
+
+// Diff returns a new plan node yielding the changes requested by the given DiffSelect.
+func (p *Planner) Diff(diffSelect *mapper.DiffSelect) (planNode, error) {
+	return &diffNode{
+		p:          p,
+		diffSelect: diffSelect,
+		docMapper:  docMapper{&diffSelect.DocumentMapping},
+	}, nil
+}
+
+func (n *diffNode) Kind() string {
+	return "diffNode"
+}
+
+func (n *diffNode) Init() error {
+	return nil
+}
+
+func (n *diffNode) Start() error {
+	key, err := client.NewDocKeyFromString(n.diffSelect.DocKey)
+	if err != nil {
+		return err
+	}
+
+	col, err := n.getCollection()
+	if err != nil {
+		return err
+	}
+
+	n.diff, err = col.WithTxn(n.p.txn).Diff(n.p.ctx, key, n.diffSelect.From, n.diffSelect.To)
+	return err
+}
+
+// getCollection returns the collection of the requested document, as resolved from
+// the schema version of its latest composite commit.
+func (n *diffNode) getCollection() (client.Collection, error) {
+	headset := clock.NewHeadSet(
+		n.p.txn.Headstore(),
+		core.HeadStoreKey{DocKey: n.diffSelect.DocKey, FieldId: core.COMPOSITE_NAMESPACE},
+	)
+	heads, _, err := headset.List(n.p.ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(heads) == 0 {
+		return nil, client.ErrDocumentNotFound
+	}
+
+	block, err := n.p.txn.DAGstore().Get(n.p.ctx, heads[0])
+	if err != nil {
+		return nil, err
+	}
+	nd, err := dag.DecodeProtobuf(block.RawData())
+	if err != nil {
+		return nil, err
+	}
+	delta, err := corecrdt.CompositeDAG{}.DeltaDecode(nd)
+	if err != nil {
+		return nil, err
+	}
+
+	return n.p.db.GetCollectionByVersionID(n.p.ctx, delta.(*corecrdt.CompositeDAGDelta).SchemaVersionID)
+}
+
+func (n *diffNode) Spans(spans core.Spans) {
+	// diffNode does not use spans
+}
+
+func (n *diffNode) Next() (bool, error) {
+	if n.index >= len(n.diff) {
+		return false, nil
+	}
+	fieldDiff := n.diff[n.index]
+	n.index++
+
+	oldValue, err := marshalDiffValue(fieldDiff.OldValue)
+	if err != nil {
+		return false, err
+	}
+	newValue, err := marshalDiffValue(fieldDiff.NewValue)
+	if err != nil {
+		return false, err
+	}
+
+	doc := n.diffSelect.DocumentMapping.NewDoc()
+	n.diffSelect.DocumentMapping.SetFirstOfName(&doc, request.FieldNameFieldName, fieldDiff.FieldName)
+	n.diffSelect.DocumentMapping.SetFirstOfName(&doc, request.KindFieldName, string(fieldDiff.Kind))
+	n.diffSelect.DocumentMapping.SetFirstOfName(&doc, request.OldValueFieldName, oldValue)
+	n.diffSelect.DocumentMapping.SetFirstOfName(&doc, request.NewValueFieldName, newValue)
+	n.currentValue = doc
+
+	return true, nil
+}
+
+// marshalDiffValue returns the JSON encoding of the given field value, or nil if
+// the field has no value.
+func marshalDiffValue(value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (n *diffNode) Source() planNode { return nil }
+
+func (n *diffNode) Close() error {
+	return nil
+}
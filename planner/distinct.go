@@ -0,0 +1,206 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package planner
+
+import (
+	"fmt"
+
+	"github.com/sourcenetwork/immutable"
+
+	"github.com/sourcenetwork/defradb/client/request"
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/planner/mapper"
+)
+
+const (
+	// distinctSortedStrategy de-duplicates documents that are ordered by the distinct fields,
+	// only the previous document needs to be remembered.
+	distinctSortedStrategy = "sorted"
+
+	// distinctHashedStrategy de-duplicates documents in any order, remembering every
+	// distinct value seen.
+	distinctHashedStrategy = "hashed"
+)
+
+// distinctNode yields only the first document of each set of documents that share the
+// same values for the distinct fields.
+type distinctNode struct {
+	docMapper
+
+	p    *Planner
+	plan planNode
+
+	fields   []mapper.Field
+	strategy string
+
+	previousKey immutable.Option[string]
+	seenKeys    map[string]struct{}
+
+	execInfo distinctExecInfo
+}
+
+type distinctExecInfo struct {
+	// Total number of times distinctNode was executed.
+	iterations uint64
+
+	// Total number of duplicate documents skipped.
+	duplicates uint64
+}
+
+// Distinct creates a new distinctNode initalized from the parser.Distinct object.
+//
+// If the results are ordered by the distinct fields the duplicates will be adjacent, and
+// only the previous document needs to be compared against, otherwise the values of every
+// yielded document are hashed and remembered.
+func (p *Planner) Distinct(parsed *mapper.Select, n *mapper.Distinct) (*distinctNode, error) {
+	if n == nil {
+		return nil, nil // nothing to do
+	}
+
+	strategy := distinctHashedStrategy
+	if isOrderedByFields(parsed.OrderBy, n.Fields) {
+		strategy = distinctSortedStrategy
+	}
+
+	return &distinctNode{
+		p:         p,
+		fields:    n.Fields,
+		strategy:  strategy,
+		docMapper: docMapper{&parsed.DocumentMapping},
+	}, nil
+}
+
+// isOrderedByFields returns true if the leading conditions of the given order are on all
+// of the given fields, and no others.
+func isOrderedByFields(orderBy *mapper.OrderBy, fields []mapper.Field) bool {
+	if orderBy == nil || len(orderBy.Conditions) < len(fields) {
+		return false
+	}
+
+	orderedIndexes := map[int]struct{}{}
+	for _, condition := range orderBy.Conditions[:len(fields)] {
+		if len(condition.FieldIndexes) != 1 {
+			return false
+		}
+		orderedIndexes[condition.FieldIndexes[0]] = struct{}{}
+	}
+
+	for _, field := range fields {
+		if _, isOrdered := orderedIndexes[field.Index]; !isOrdered {
+			return false
+		}
+	}
+
+	return len(orderedIndexes) == len(fields)
+}
+
+func (n *distinctNode) Kind() string {
+	return "distinctNode"
+}
+
+func (n *distinctNode) Init() error {
+	// This node may be initialized multiple times per instance (for example during a join)
+	n.previousKey = immutable.None[string]()
+	n.seenKeys = map[string]struct{}{}
+	return n.plan.Init()
+}
+
+func (n *distinctNode) Start() error           { return n.plan.Start() }
+func (n *distinctNode) Spans(spans core.Spans) { n.plan.Spans(spans) }
+func (n *distinctNode) Close() error           { return n.plan.Close() }
+func (n *distinctNode) Value() core.Doc        { return n.plan.Value() }
+func (n *distinctNode) Source() planNode       { return n.plan }
+
+func (n *distinctNode) Next() (bool, error) {
+	n.execInfo.iterations++
+
+	for {
+		if next, err := n.plan.Next(); !next {
+			return false, err
+		}
+
+		key := distinctKey(n.plan.Value(), n.fields)
+
+		switch n.strategy {
+		case distinctSortedStrategy:
+			if n.previousKey.HasValue() && n.previousKey.Value() == key {
+				n.execInfo.duplicates++
+				continue
+			}
+			n.previousKey = immutable.Some(key)
+
+		default:
+			if _, isDuplicate := n.seenKeys[key]; isDuplicate {
+				n.execInfo.duplicates++
+				continue
+			}
+			n.seenKeys[key] = struct{}{}
+		}
+
+		return true, nil
+	}
+}
+
+// distinctDocs returns the first of each set of the given documents that share the same
+// values for the given fields.
+func distinctDocs(docs []core.Doc, fields []mapper.Field) []core.Doc {
+	result := make([]core.Doc, 0, len(docs))
+	seenKeys := map[string]struct{}{}
+	for _, doc := range docs {
+		key := distinctKey(doc, fields)
+		if _, isDuplicate := seenKeys[key]; isDuplicate {
+			continue
+		}
+		seenKeys[key] = struct{}{}
+		result = append(result, doc)
+	}
+	return result
+}
+
+// distinctKey returns a key representing the values of the given fields of the given document,
+// documents with the same values will have the same key.
+func distinctKey(doc core.Doc, fields []mapper.Field) string {
+	values := make([]any, len(fields))
+	for i, field := range fields {
+		values[i] = doc.Fields[field.Index]
+	}
+	// The Go-syntax representation includes the type of each value, and quotes strings, so
+	// different values can not share the same key.
+	return fmt.Sprintf("%#v", values)
+}
+
+func (n *distinctNode) simpleExplain() (map[string]any, error) {
+	fieldNames := make([]string, len(n.fields))
+	for i, field := range n.fields {
+		fieldNames[i] = field.Name
+	}
+
+	return map[string]any{
+		fieldsLabel:   fieldNames,
+		strategyLabel: n.strategy,
+	}, nil
+}
+
+func (n *distinctNode) Explain(explainType request.ExplainType) (map[string]any, error) {
+	switch explainType {
+	case request.SimpleExplain:
+		return n.simpleExplain()
+
+	case request.ExecuteExplain:
+		return map[string]any{
+			"iterations": n.execInfo.iterations,
+			"duplicates": n.execInfo.duplicates,
+		}, nil
+
+	default:
+		return nil, ErrUnknownExplainRequestType
+	}
+}
@@ -16,6 +16,10 @@ const (
 	errUnknownDependency              string = "given field does not exist"
 	errFailedToClosePlan              string = "failed to close the plan"
 	errFailedToCollectExecExplainInfo string = "failed to collect execution explain information"
+	errIncomparableAggregateValues    string = "values of different types can not be compared"
+	errInvalidPercentile              string = "percentile must be between 0 and 100"
+	errInvalidCursor                  string = "invalid cursor"
+	errSearchWithoutFullTextIndex     string = "a full-text search requires a full-text index on the field"
 )
 
 var (
@@ -35,6 +39,15 @@ var (
 	ErrUnknownExplainRequestType           = errors.New("can not explain request of unknown type")
 	ErrFailedToCollectExecExplainInfo      = errors.New(errFailedToCollectExecExplainInfo)
 	ErrUnknownDependency                   = errors.New(errUnknownDependency)
+	ErrAsOfWithCid                         = errors.New("asOf and cid can not be used together")
+	ErrRevertRequiresSingleID              = errors.New("a revert requires the id of a single document")
+	ErrIncomparableAggregateValues         = errors.New(errIncomparableAggregateValues)
+	ErrInvalidPercentile                   = errors.New(errInvalidPercentile)
+	ErrMissingPercentile                   = errors.New("percentile aggregate is missing a percentile")
+	ErrInvalidCursor                       = errors.New(errInvalidCursor)
+	ErrCursorWithSearch                    = errors.New("cursors can not be used together with a full-text search")
+	ErrCursorWithMultipleSelects           = errors.New("cursors can only be used in requests with a single select")
+	ErrNestedSearch                        = errors.New("a full-text search can not be nested within another operator or a relation")
 )
 
 func NewErrUnknownDependency(name string) error {
@@ -48,3 +61,23 @@ func NewErrFailedToClosePlan(inner error, location string) error {
 func NewErrFailedToCollectExecExplainInfo(inner error) error {
 	return errors.Wrap(errFailedToCollectExecExplainInfo, inner)
 }
+
+func NewErrIncomparableAggregateValues(a any, b any) error {
+	return errors.New(
+		errIncomparableAggregateValues,
+		errors.NewKV("A", a),
+		errors.NewKV("B", b),
+	)
+}
+
+func NewErrInvalidPercentile(percentile float64) error {
+	return errors.New(errInvalidPercentile, errors.NewKV("Percentile", percentile))
+}
+
+func NewErrInvalidCursor(cursor string) error {
+	return errors.New(errInvalidCursor, errors.NewKV("Cursor", cursor))
+}
+
+func NewErrSearchWithoutFullTextIndex(fieldName string) error {
+	return errors.New(errSearchWithoutFullTextIndex, errors.NewKV("Field", fieldName))
+}
@@ -35,32 +35,46 @@ var (
 	_ explainablePlanNode = (*averageNode)(nil)
 	_ explainablePlanNode = (*countNode)(nil)
 	_ explainablePlanNode = (*createNode)(nil)
+	_ explainablePlanNode = (*cursorNode)(nil)
 	_ explainablePlanNode = (*dagScanNode)(nil)
 	_ explainablePlanNode = (*deleteNode)(nil)
+	_ explainablePlanNode = (*distinctNode)(nil)
 	_ explainablePlanNode = (*groupNode)(nil)
 	_ explainablePlanNode = (*limitNode)(nil)
+	_ explainablePlanNode = (*minMaxNode)(nil)
 	_ explainablePlanNode = (*orderNode)(nil)
+	_ explainablePlanNode = (*percentileNode)(nil)
+	_ explainablePlanNode = (*revertNode)(nil)
 	_ explainablePlanNode = (*scanNode)(nil)
+	_ explainablePlanNode = (*searchNode)(nil)
 	_ explainablePlanNode = (*selectNode)(nil)
 	_ explainablePlanNode = (*selectTopNode)(nil)
 	_ explainablePlanNode = (*sumNode)(nil)
 	_ explainablePlanNode = (*topLevelNode)(nil)
 	_ explainablePlanNode = (*typeIndexJoin)(nil)
 	_ explainablePlanNode = (*updateNode)(nil)
+	_ explainablePlanNode = (*varianceNode)(nil)
 )
 
 const (
+	afterLabel          = "after"
+	beforeLabel         = "before"
 	childFieldNameLabel = "childFieldName"
+	cidLabel            = "cid"
 	collectionIDLabel   = "collectionID"
 	collectionNameLabel = "collectionName"
 	dataLabel           = "data"
 	fieldNameLabel      = "fieldName"
+	fieldsLabel         = "fields"
 	filterLabel         = "filter"
 	idsLabel            = "ids"
 	limitLabel          = "limit"
 	offsetLabel         = "offset"
+	percentileLabel     = "percentile"
+	searchLabel         = "search"
 	sourcesLabel        = "sources"
 	spansLabel          = "spans"
+	strategyLabel       = "strategy"
 )
 
 // buildSimpleExplainGraph builds the explainGraph from the given top level plan.
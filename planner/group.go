@@ -170,6 +170,11 @@ func (n *groupNode) Next() (bool, error) {
 				}
 
 				childDocs := subSelect.([]core.Doc)
+				if childSelect.Distinct != nil {
+					childDocs = distinctDocs(childDocs, childSelect.Distinct.Fields)
+					group.Fields[childSelect.Index] = childDocs
+				}
+
 				if childSelect.Limit != nil {
 					l := uint64(len(childDocs))
 
@@ -17,7 +17,6 @@ import (
 )
 
 // Limit the results, yielding only what the limit/offset permits
-// @todo: Handle cursor
 type limitNode struct {
 	docMapper
 
@@ -28,6 +27,10 @@ type limitNode struct {
 	offset   uint64
 	rowIndex uint64
 
+	// If set, once the limit is reached the source is checked for any further results.
+	checkHasMore bool
+	hasMore      bool
+
 	execInfo limitExecInfo
 }
 
@@ -56,6 +59,7 @@ func (n *limitNode) Kind() string {
 
 func (n *limitNode) Init() error {
 	n.rowIndex = 0
+	n.hasMore = false
 	return n.plan.Init()
 }
 
@@ -69,6 +73,13 @@ func (n *limitNode) Next() (bool, error) {
 
 	// check if we're passed the limit
 	if n.limit != 0 && n.rowIndex >= n.limit+n.offset {
+		if n.checkHasMore && !n.hasMore {
+			hasMore, err := n.plan.Next()
+			if err != nil {
+				return false, err
+			}
+			n.hasMore = hasMore
+		}
 		return false, nil
 	}
 
@@ -10,7 +10,12 @@
 
 package mapper
 
-import "github.com/sourcenetwork/defradb/core"
+import (
+	"github.com/sourcenetwork/immutable"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/core"
+)
 
 // An optional child target.
 type OptionalChildTarget struct {
@@ -20,6 +25,9 @@ type OptionalChildTarget struct {
 	// The name of the target, for example '_sum' or 'Age'.
 	Name string
 
+	// The kind of the target, if it is a field of the host collection.
+	Kind client.FieldKind
+
 	// If true this child target exists and has been requested.
 	//
 	// If false, this property is empty and in its default state.
@@ -54,6 +62,9 @@ type Aggregate struct {
 	//
 	// For example, Average is dependent on a Sum and Count field.
 	Dependencies []*Aggregate
+
+	// The percentile, between 0 and 100, to be yielded by a percentile aggregate.
+	Percentile immutable.Option[float64]
 }
 
 func (a *Aggregate) CloneTo(index int) Requestable {
@@ -65,5 +76,6 @@ func (a *Aggregate) cloneTo(index int) *Aggregate {
 		Field:            *a.Field.cloneTo(index),
 		DocumentMapping:  a.DocumentMapping,
 		AggregateTargets: a.AggregateTargets,
+		Percentile:       a.Percentile,
 	}
 }
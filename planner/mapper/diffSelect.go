@@ -0,0 +1,27 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package mapper
+
+// DiffSelect represents a request for the field-level changes of a document
+// between two versions.
+type DiffSelect struct {
+	// The underlying Select, defining the information requested.
+	Select
+
+	// The key of the target document.
+	DocKey string
+
+	// The old version, either a composite commit Cid or client.LatestVersion.
+	From string
+
+	// The new version, either a composite commit Cid or client.LatestVersion.
+	To string
+}
@@ -12,8 +12,23 @@ package mapper
 
 import "github.com/sourcenetwork/defradb/errors"
 
+const (
+	errAggregateFilterOnRelation string = "aggregates of related objects can not be filtered on"
+	errAggregateOrderOnRelation  string = "aggregates of related objects can not be ordered by"
+)
+
 var (
-	ErrUnableToIdAggregateChild = errors.New("unable to identify aggregate child")
-	ErrAggregateTargetMissing   = errors.New("aggregate must be provided with a property to aggregate")
-	ErrFailedToFindHostField    = errors.New("failed to find host field")
+	ErrUnableToIdAggregateChild  = errors.New("unable to identify aggregate child")
+	ErrAggregateTargetMissing    = errors.New("aggregate must be provided with a property to aggregate")
+	ErrFailedToFindHostField     = errors.New("failed to find host field")
+	ErrAggregateFilterOnRelation = errors.New(errAggregateFilterOnRelation)
+	ErrAggregateOrderOnRelation  = errors.New(errAggregateOrderOnRelation)
 )
+
+func NewErrAggregateFilterOnRelation(relation string) error {
+	return errors.New(errAggregateFilterOnRelation, errors.NewKV("Relation", relation))
+}
+
+func NewErrAggregateOrderOnRelation(relation string) error {
+	return errors.New(errAggregateOrderOnRelation, errors.NewKV("Relation", relation))
+}
@@ -12,6 +12,7 @@ package mapper
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 	"strings"
 
@@ -23,6 +24,7 @@ import (
 	"github.com/sourcenetwork/defradb/connor"
 	"github.com/sourcenetwork/defradb/core"
 	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/db/fulltext"
 )
 
 // ToSelect converts the given [parser.Select] into a [Select].
@@ -60,6 +62,21 @@ func toSelect(
 		return nil, err
 	}
 
+	// Aggregates used by the filter are not rendered, but must be resolved along with the
+	// requested ones so that their values are available to the filter.
+	filterAggregates, err := resolveFilterAggregates(selectRequest.Filter, mapping, desc)
+	if err != nil {
+		return nil, err
+	}
+	aggregates = append(aggregates, filterAggregates...)
+
+	// Likewise for the aggregates that the results are ordered by.
+	orderAggregates, err := resolveOrderAggregates(selectRequest.OrderBy, mapping)
+	if err != nil {
+		return nil, err
+	}
+	aggregates = append(aggregates, orderAggregates...)
+
 	// Needs to be done before resolving aggregates, else filter conversion may fail there
 	filterDependencies, err := resolveFilterDependencies(
 		descriptionsRepo, collectionName, selectRequest.Filter, mapping, fields)
@@ -99,7 +116,10 @@ func toSelect(
 		Targetable:      toTargetable(thisIndex, selectRequest, mapping),
 		DocumentMapping: *mapping,
 		Cid:             selectRequest.CID,
+		AsOf:            selectRequest.AsOf,
 		CollectionName:  collectionName,
+		After:           selectRequest.After,
+		Before:          selectRequest.Before,
 		Fields:          fields,
 	}, nil
 }
@@ -123,6 +143,10 @@ func resolveOrderDependencies(
 		if len(condition.Fields) <= 1 {
 			continue
 		}
+		if _, isAggregate := request.Aggregates[condition.Fields[0]]; isAggregate {
+			// The hosts of the aggregates are resolved along with the aggregates.
+			continue
+		}
 
 		joinField := condition.Fields[0]
 
@@ -149,6 +173,48 @@ func resolveOrderDependencies(
 	return nil
 }
 
+// isAggregateName returns true if the given name is that of an aggregate.
+func isAggregateName(name string) bool {
+	_, isAggregate := request.Aggregates[name]
+	return isAggregate
+}
+
+// resolveOrderAggregates returns the aggregates that the given order conditions order by, for
+// example `{_count: {books: DESC}}`, mapping them to new hidden fields.
+//
+// Only the aggregates of the ordered objects are supported, not those of their relations.
+func resolveOrderAggregates(
+	source immutable.Option[request.OrderBy],
+	mapping *core.DocumentMapping,
+) ([]*aggregateRequest, error) {
+	if !source.HasValue() {
+		return nil, nil
+	}
+
+	var aggregates []*aggregateRequest
+	for _, condition := range source.Value().Conditions {
+		for i, field := range condition.Fields[1:] {
+			if _, isAggregate := request.Aggregates[field]; isAggregate {
+				return nil, NewErrAggregateOrderOnRelation(condition.Fields[i])
+			}
+		}
+
+		if _, isAggregate := request.Aggregates[condition.Fields[0]]; !isAggregate {
+			continue
+		}
+		if len(condition.Fields) < 2 {
+			return nil, ErrAggregateTargetMissing
+		}
+
+		var childName string
+		if len(condition.Fields) > 2 {
+			childName = condition.Fields[2]
+		}
+		aggregates = appendHiddenAggregate(condition.Fields[0], condition.Fields[1], childName, aggregates, mapping)
+	}
+	return aggregates, nil
+}
+
 // resolveAggregates figures out which fields the given aggregates are targeting
 // and converts the aggregateRequest into an Aggregate, appending it onto the given
 // fields slice.
@@ -179,6 +245,13 @@ func resolveAggregates(
 			// we must create it before we can convert the filter.
 			childIsMapped := len(mapping.IndexesByName[target.hostExternalName]) != 0
 
+			if target.distinct.HasValue() && len(target.distinct.Value().Fields) == 0 {
+				// Only inline array items can be de-duplicated by their own value
+				if fieldDesc, isField := desc.GetField(target.hostExternalName); !isField || fieldDesc.IsObject() {
+					return nil, client.ErrDistinctWithoutFields
+				}
+			}
+
 			var hasHost bool
 			var convertedFilter *Filter
 			if childIsMapped {
@@ -205,9 +278,10 @@ func resolveAggregates(
 							Index: int(fieldDesc.ID),
 							Name:  target.hostExternalName,
 						},
-						Filter:  ToFilter(target.filter, mapping),
-						Limit:   target.limit,
-						OrderBy: order,
+						Filter:   ToFilter(target.filter, mapping),
+						Limit:    target.limit,
+						OrderBy:  order,
+						Distinct: toDistinct(target.distinct, mapping),
 					}
 				} else {
 					childObjectIndex := mapping.FirstIndexOfName(target.hostExternalName)
@@ -218,6 +292,7 @@ func resolveAggregates(
 						convertedFilter,
 						target.limit,
 						toOrderBy(target.order, childMapping),
+						toDistinct(target.distinct, childMapping),
 						fields,
 					)
 				}
@@ -266,9 +341,10 @@ func resolveAggregates(
 							Index: index,
 							Name:  target.hostExternalName,
 						},
-						Filter:  convertedFilter,
-						Limit:   target.limit,
-						OrderBy: toOrderBy(target.order, childMapping),
+						Filter:   convertedFilter,
+						Limit:    target.limit,
+						OrderBy:  toOrderBy(target.order, childMapping),
+						Distinct: toDistinct(target.distinct, childMapping),
 					},
 					CollectionName:  childCollectionName,
 					DocumentMapping: *childMapping,
@@ -316,6 +392,16 @@ func resolveAggregates(
 					Name:     target.childExternalName,
 					HasValue: true,
 				}
+
+				if selectRequest.Root == request.ObjectSelection && hostSelect.CollectionName != "" {
+					hostDesc, err := descriptionsRepo.getCollectionDesc(hostSelect.CollectionName)
+					if err != nil {
+						return nil, err
+					}
+					if fieldDesc, isField := hostDesc.GetField(target.childExternalName); isField {
+						childTarget.Kind = fieldDesc.Kind
+					}
+				}
 			}
 
 			aggregateTargets[i] = AggregateTarget{
@@ -328,6 +414,7 @@ func resolveAggregates(
 			Field:            aggregate.field,
 			DocumentMapping:  *mapping,
 			AggregateTargets: aggregateTargets,
+			Percentile:       aggregate.percentile,
 		}
 		fields = append(fields, &newAggregate)
 		dependenciesByParentId[aggregate.field.Index] = aggregate.dependencyIndexes
@@ -566,7 +653,8 @@ func getAggregateRequests(index int, aggregate *request.Aggregate) (aggregateReq
 			Index: index,
 			Name:  aggregate.Name,
 		},
-		targets: aggregateTargets,
+		targets:    aggregateTargets,
+		percentile: aggregate.Percentile,
 	}, nil
 }
 
@@ -584,7 +672,7 @@ func getCollectionName(
 
 	if selectRequest.Name == request.GroupFieldName {
 		return parentCollectionName, nil
-	} else if selectRequest.Root == request.CommitSelection {
+	} else if selectRequest.Root == request.CommitSelection || selectRequest.Root == request.DiffSelection {
 		return parentCollectionName, nil
 	}
 
@@ -646,7 +734,15 @@ func getTopLevelInfo(
 		return mapping, &desc, nil
 	}
 
-	if selectRequest.Name == request.LinksFieldName {
+	if selectRequest.Root == request.DiffSelection {
+		for i, f := range request.DiffFields {
+			mapping.Add(i, f)
+		}
+
+		// Setting the type name must be done after adding the fields, as
+		// the typeName index is dynamic, but the field indexes are not
+		mapping.SetTypeName(request.DiffTypeName)
+	} else if selectRequest.Name == request.LinksFieldName {
 		for i, f := range request.LinksFields {
 			mapping.Add(i, f)
 		}
@@ -667,6 +763,144 @@ func getTopLevelInfo(
 	return mapping, &client.CollectionDescription{}, nil
 }
 
+// resolveFilterAggregates returns the aggregates that the given filter conditions on, for
+// example `{_count: {books: {_gt: 1}}}`, mapping them to new hidden fields.
+//
+// Only the aggregates of the filtered objects are supported, not those of their relations.
+func resolveFilterAggregates(
+	source immutable.Option[request.Filter],
+	mapping *core.DocumentMapping,
+	desc *client.CollectionDescription,
+) ([]*aggregateRequest, error) {
+	if !source.HasValue() {
+		return nil, nil
+	}
+	return resolveInnerFilterAggregates(source.Value().Conditions, mapping, desc, nil)
+}
+
+func resolveInnerFilterAggregates(
+	source map[string]any,
+	mapping *core.DocumentMapping,
+	desc *client.CollectionDescription,
+	aggregates []*aggregateRequest,
+) ([]*aggregateRequest, error) {
+	for key, clause := range source {
+		if _, isAggregate := request.Aggregates[key]; isAggregate {
+			hosts, isMap := clause.(map[string]any)
+			if !isMap {
+				continue
+			}
+			for hostName, hostClause := range hosts {
+				fieldDesc, isField := desc.GetField(hostName)
+				if key == request.CountFieldName || !isField || !fieldDesc.IsObject() {
+					// Counts and the aggregates of inline arrays target the host directly.
+					aggregates = appendHiddenAggregate(key, hostName, "", aggregates, mapping)
+					continue
+				}
+				children, isMap := hostClause.(map[string]any)
+				if !isMap {
+					continue
+				}
+				for childName := range children {
+					aggregates = appendHiddenAggregate(key, hostName, childName, aggregates, mapping)
+				}
+			}
+			continue
+		}
+
+		if strings.HasPrefix(key, "_") && key != request.KeyFieldName {
+			var err error
+			switch typedClause := clause.(type) {
+			case map[string]any:
+				aggregates, err = resolveInnerFilterAggregates(typedClause, mapping, desc, aggregates)
+			case []any:
+				for _, innerClause := range typedClause {
+					innerMap, isMap := innerClause.(map[string]any)
+					if !isMap {
+						continue
+					}
+					aggregates, err = resolveInnerFilterAggregates(innerMap, mapping, desc, aggregates)
+					if err != nil {
+						break
+					}
+				}
+			}
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if fieldDesc, isField := desc.GetField(key); isField && fieldDesc.IsObject() && hasFilterAggregate(clause) {
+			return nil, NewErrAggregateFilterOnRelation(key)
+		}
+	}
+	return aggregates, nil
+}
+
+// appendHiddenAggregate appends a new aggregate request for the given filter or order aggregate,
+// unless the same aggregate has already been used elsewhere in the filter or order.
+func appendHiddenAggregate(
+	name string,
+	hostName string,
+	childName string,
+	aggregates []*aggregateRequest,
+	mapping *core.DocumentMapping,
+) []*aggregateRequest {
+	mappedName := hiddenAggregateName(name, hostName, childName)
+	if len(mapping.IndexesByName[mappedName]) != 0 {
+		return aggregates
+	}
+
+	index := mapping.GetNextIndex()
+	mapping.Add(index, mappedName)
+	return append(aggregates, &aggregateRequest{
+		field: Field{
+			Index: index,
+			Name:  name,
+		},
+		targets: []*aggregateRequestTarget{
+			{
+				hostExternalName:  hostName,
+				childExternalName: childName,
+			},
+		},
+	})
+}
+
+// hiddenAggregateName returns the name that the aggregate of the given target is mapped to
+// when it is used in a filter or order.
+//
+// The name cannot clash with a requested field.
+func hiddenAggregateName(name string, hostName string, childName string) string {
+	if childName == "" {
+		return fmt.Sprintf("%s(%s)", name, hostName)
+	}
+	return fmt.Sprintf("%s(%s.%s)", name, hostName, childName)
+}
+
+// hasFilterAggregate returns true if the given filter clause contains a condition on an aggregate.
+func hasFilterAggregate(clause any) bool {
+	switch typedClause := clause.(type) {
+	case map[string]any:
+		for key, innerClause := range typedClause {
+			if _, isAggregate := request.Aggregates[key]; isAggregate {
+				return true
+			}
+			if hasFilterAggregate(innerClause) {
+				return true
+			}
+		}
+	case []any:
+		for _, innerClause := range typedClause {
+			if hasFilterAggregate(innerClause) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func resolveFilterDependencies(
 	descriptionsRepo *DescriptionsRepo,
 	parentCollectionName string,
@@ -696,8 +930,26 @@ func resolveInnerFilterDependencies(
 ) ([]Requestable, error) {
 	newFields := []Requestable{}
 
-	for key := range source {
+	for key, clause := range source {
+		if _, isAggregate := request.Aggregates[key]; isAggregate {
+			// The hosts of the aggregates are resolved along with the aggregates.
+			continue
+		}
+
 		if strings.HasPrefix(key, "_") && key != request.KeyFieldName {
+			// The compound operators (e.g. `_and`, `_not`) and the relation filters (e.g. `_every`)
+			// may contain filters on relations that are not otherwise requested.
+			compoundFields, err := resolveCompoundFilterDependencies(
+				descriptionsRepo,
+				parentCollectionName,
+				clause,
+				mapping,
+				concatFields(newFields, existingFields),
+			)
+			if err != nil {
+				return nil, err
+			}
+			newFields = append(newFields, compoundFields...)
 			continue
 		}
 
@@ -817,6 +1069,53 @@ func resolveInnerFilterDependencies(
 	return newFields, nil
 }
 
+// resolveCompoundFilterDependencies resolves the dependencies of the filters within the
+// clause of a compound operator, which may be a single filter or a list of them.
+func resolveCompoundFilterDependencies(
+	descriptionsRepo *DescriptionsRepo,
+	parentCollectionName string,
+	clause any,
+	mapping *core.DocumentMapping,
+	existingFields []Requestable,
+) ([]Requestable, error) {
+	switch typedClause := clause.(type) {
+	case map[string]any:
+		return resolveInnerFilterDependencies(
+			descriptionsRepo,
+			parentCollectionName,
+			typedClause,
+			mapping,
+			existingFields,
+		)
+
+	case []any:
+		newFields := []Requestable{}
+		for _, innerClause := range typedClause {
+			innerFields, err := resolveCompoundFilterDependencies(
+				descriptionsRepo,
+				parentCollectionName,
+				innerClause,
+				mapping,
+				concatFields(newFields, existingFields),
+			)
+			if err != nil {
+				return nil, err
+			}
+			newFields = append(newFields, innerFields...)
+		}
+		return newFields, nil
+
+	default:
+		return []Requestable{}, nil
+	}
+}
+
+func concatFields(a []Requestable, b []Requestable) []Requestable {
+	fields := make([]Requestable, 0, len(a)+len(b))
+	fields = append(fields, a...)
+	return append(fields, b...)
+}
+
 // ToCommitSelect converts the given [request.CommitSelect] into a [CommitSelect].
 //
 // In the process of doing so it will construct the document map required to access the data
@@ -839,6 +1138,27 @@ func ToCommitSelect(
 	}, nil
 }
 
+// ToDiffSelect converts the given [request.DiffSelect] into a [DiffSelect].
+//
+// In the process of doing so it will construct the document map required to access the data
+// yielded by the [Select] embedded in the [DiffSelect].
+func ToDiffSelect(
+	ctx context.Context,
+	txn datastore.Txn,
+	selectRequest *request.DiffSelect,
+) (*DiffSelect, error) {
+	underlyingSelect, err := ToSelect(ctx, txn, selectRequest.ToSelect())
+	if err != nil {
+		return nil, err
+	}
+	return &DiffSelect{
+		Select: *underlyingSelect,
+		DocKey: selectRequest.DocKey,
+		From:   selectRequest.From,
+		To:     selectRequest.To,
+	}, nil
+}
+
 // ToMutation converts the given [request.Mutation] into a [Mutation].
 //
 // In the process of doing so it will construct the document map required to access the data
@@ -853,6 +1173,7 @@ func ToMutation(ctx context.Context, txn datastore.Txn, mutationRequest *request
 		Select: *underlyingSelect,
 		Type:   MutationType(mutationRequest.Type),
 		Data:   mutationRequest.Data,
+		Cid:    mutationRequest.Cid,
 	}, nil
 }
 
@@ -864,6 +1185,7 @@ func toTargetable(index int, selectRequest *request.Select, docMap *core.Documen
 		Limit:       toLimit(selectRequest.Limit, selectRequest.Offset),
 		GroupBy:     toGroupBy(selectRequest.GroupBy, docMap),
 		OrderBy:     toOrderBy(selectRequest.OrderBy, docMap),
+		Distinct:    toDistinct(selectRequest.Distinct, docMap),
 		ShowDeleted: selectRequest.ShowDeleted,
 	}
 }
@@ -904,6 +1226,12 @@ func toFilterMap(
 	sourceClause any,
 	mapping *core.DocumentMapping,
 ) (connor.FilterKey, any) {
+	if _, isAggregate := request.Aggregates[sourceKey]; isAggregate {
+		if clause, isMapped := toAggregateFilterClause(sourceKey, sourceClause, mapping); isMapped {
+			return &Operator{Operation: "_and"}, clause
+		}
+	}
+
 	if strings.HasPrefix(sourceKey, "_") && sourceKey != request.KeyFieldName {
 		key := &Operator{
 			Operation: sourceKey,
@@ -928,6 +1256,27 @@ func toFilterMap(
 				returnClauses = append(returnClauses, returnClause)
 			}
 			return key, returnClauses
+		case map[string]any:
+			// If the clause is a map (e.g. `_not`) then its inner keys are on the same level.
+			returnClause := map[connor.FilterKey]any{}
+			for innerSourceKey, innerSourceValue := range typedClause {
+				rKey, rValue := toFilterMap(innerSourceKey, innerSourceValue, mapping)
+				returnClause[rKey] = rValue
+			}
+			return key, returnClause
+		case string:
+			if sourceKey == "_regex" || sourceKey == "_nregex" {
+				// The pattern is compiled once for the request, instead of for every document
+				// it is matched against. Invalid patterns are left as is so that the error
+				// is returned on evaluation of the filter.
+				if re, err := connor.CompileRegex(typedClause); err == nil {
+					return key, re
+				}
+			}
+			if sourceKey == "_search" {
+				return key, &connor.SearchCondition{Text: typedClause, Match: fulltext.Matches}
+			}
+			return key, typedClause
 		default:
 			return key, typedClause
 		}
@@ -943,17 +1292,14 @@ func toFilterMap(
 		switch typedClause := sourceClause.(type) {
 		case map[string]any:
 			returnClause := map[connor.FilterKey]any{}
+			innerMapping := mapping
+			if index >= 0 && index < len(mapping.ChildMappings) && mapping.ChildMappings[index] != nil {
+				// If the key refers to a host property in a join then we should parse the nested
+				// clause using the child mapping, as deeper keys, including those within compound
+				// operators, must refer to properties on the child items.
+				innerMapping = mapping.ChildMappings[index]
+			}
 			for innerSourceKey, innerSourceValue := range typedClause {
-				var innerMapping *core.DocumentMapping
-				switch innerSourceValue.(type) {
-				case map[string]any:
-					// If the innerSourceValue is also a map, then we should parse the nested clause
-					// using the child mapping, as this key must refer to a host property in a join
-					// and deeper keys must refer to properties on the child items.
-					innerMapping = mapping.ChildMappings[index]
-				default:
-					innerMapping = mapping
-				}
 				rKey, rValue := toFilterMap(innerSourceKey, innerSourceValue, innerMapping)
 				returnClause[rKey] = rValue
 			}
@@ -964,6 +1310,64 @@ func toFilterMap(
 	}
 }
 
+// toAggregateFilterClause converts the conditions of a filter on aggregates, for example
+// `{_sum: {books: {pages: {_gt: 1}}}}`, into conditions on the fields that the aggregates
+// have been mapped to. These are returned as the clause of an `_and` operator.
+//
+// Will return false if the aggregates have not been mapped.
+func toAggregateFilterClause(
+	name string,
+	sourceClause any,
+	mapping *core.DocumentMapping,
+) ([]any, bool) {
+	hosts, isMap := sourceClause.(map[string]any)
+	if !isMap {
+		return nil, false
+	}
+
+	returnClauses := []any{}
+	appendCondition := func(mappedName string, clause any) bool {
+		indexes := mapping.IndexesByName[mappedName]
+		if len(indexes) == 0 {
+			return false
+		}
+		returnClauses = append(returnClauses, map[connor.FilterKey]any{
+			&PropertyIndex{Index: indexes[0]}: toAggregateConditionClause(clause, mapping),
+		})
+		return true
+	}
+
+	for hostName, hostClause := range hosts {
+		if appendCondition(hiddenAggregateName(name, hostName, ""), hostClause) {
+			continue
+		}
+		children, isMap := hostClause.(map[string]any)
+		if !isMap {
+			return nil, false
+		}
+		for childName, childClause := range children {
+			if !appendCondition(hiddenAggregateName(name, hostName, childName), childClause) {
+				return nil, false
+			}
+		}
+	}
+	return returnClauses, true
+}
+
+// toAggregateConditionClause converts the operator block of a filter on an aggregate.
+func toAggregateConditionClause(sourceClause any, mapping *core.DocumentMapping) any {
+	operators, isMap := sourceClause.(map[string]any)
+	if !isMap {
+		return sourceClause
+	}
+	returnClause := map[connor.FilterKey]any{}
+	for operator, value := range operators {
+		rKey, rValue := toFilterMap(operator, value, mapping)
+		returnClause[rKey] = rValue
+	}
+	return returnClause
+}
+
 func toLimit(limit immutable.Option[uint64], offset immutable.Option[uint64]) *Limit {
 	var limitValue uint64
 	var offsetValue uint64
@@ -1009,6 +1413,25 @@ func toGroupBy(source immutable.Option[request.GroupBy], mapping *core.DocumentM
 	}
 }
 
+func toDistinct(source immutable.Option[request.Distinct], mapping *core.DocumentMapping) *Distinct {
+	if !source.HasValue() {
+		return nil
+	}
+
+	fields := make([]Field, len(source.Value().Fields))
+	for i, fieldName := range source.Value().Fields {
+		// As with groupBy, if there are multiple properties of the same name we take the first.
+		fields[i] = Field{
+			Index: mapping.FirstIndexOfName(fieldName),
+			Name:  fieldName,
+		}
+	}
+
+	return &Distinct{
+		Fields: fields,
+	}
+}
+
 func toOrderBy(source immutable.Option[request.OrderBy], mapping *core.DocumentMapping) *OrderBy {
 	if !source.HasValue() {
 		return nil
@@ -1016,6 +1439,20 @@ func toOrderBy(source immutable.Option[request.OrderBy], mapping *core.DocumentM
 
 	conditions := make([]OrderCondition, len(source.Value().Conditions))
 	for conditionIndex, condition := range source.Value().Conditions {
+		if len(condition.Fields) > 1 && isAggregateName(condition.Fields[0]) {
+			// Aggregates are mapped to a single hidden field on the ordered object.
+			var childName string
+			if len(condition.Fields) > 2 {
+				childName = condition.Fields[2]
+			}
+			mappedName := hiddenAggregateName(condition.Fields[0], condition.Fields[1], childName)
+			conditions[conditionIndex] = OrderCondition{
+				FieldIndexes: []int{mapping.FirstIndexOfName(mappedName)},
+				Direction:    SortDirection(condition.Direction),
+			}
+			continue
+		}
+
 		fieldIndexes := make([]int, len(condition.Fields))
 		currentMapping := mapping
 		for fieldIndex, field := range condition.Fields {
@@ -1071,6 +1508,10 @@ func (s Targetable) equal(other Targetable) bool {
 		return false
 	}
 
+	if !s.Distinct.equal(other.Distinct) {
+		return false
+	}
+
 	return true
 }
 
@@ -1086,6 +1527,28 @@ func (l *Limit) equal(other *Limit) bool {
 	return l.Limit == other.Limit && l.Offset == other.Offset
 }
 
+func (d *Distinct) equal(other *Distinct) bool {
+	if d == nil {
+		return other == nil
+	}
+
+	if other == nil {
+		return d == nil
+	}
+
+	if len(d.Fields) != len(other.Fields) {
+		return false
+	}
+
+	for i, field := range d.Fields {
+		if field.Index != other.Fields[i].Index {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (f *Filter) equal(other *Filter) bool {
 	if f == nil {
 		return other == nil
@@ -1186,6 +1649,9 @@ type aggregateRequest struct {
 	// The targets of this aggregate, as defined by the consumer.
 	targets           []*aggregateRequestTarget
 	dependencyIndexes []int
+
+	// The percentile requested by the consumer, only used by percentile aggregates.
+	percentile immutable.Option[float64]
 }
 
 // aggregateRequestTarget contains the user defined information for an aggregate
@@ -1212,6 +1678,9 @@ type aggregateRequestTarget struct {
 	// The order in which items should be aggregated. Affects results when used with
 	// limit. Optional.
 	order immutable.Option[request.OrderBy]
+
+	// The de-duplication to apply to the items before they are aggregated. Optional.
+	distinct immutable.Option[request.Distinct]
 }
 
 // Returns the source of the aggregate as requested by the consumer
@@ -1219,12 +1688,24 @@ func getAggregateSources(field *request.Aggregate) ([]*aggregateRequestTarget, e
 	targets := make([]*aggregateRequestTarget, len(field.Targets))
 
 	for i, target := range field.Targets {
+		if target.OrderBy.HasValue() {
+			for _, condition := range target.OrderBy.Value().Conditions {
+				if len(condition.Fields) == 0 {
+					continue
+				}
+				if _, isAggregate := request.Aggregates[condition.Fields[0]]; isAggregate {
+					return nil, NewErrAggregateOrderOnRelation(target.HostName)
+				}
+			}
+		}
+
 		targets[i] = &aggregateRequestTarget{
 			hostExternalName:  target.HostName,
 			childExternalName: target.ChildName.Value(),
 			filter:            target.Filter,
 			limit:             toLimit(target.Limit, target.Offset),
 			order:             target.OrderBy,
+			distinct:          target.Distinct,
 		}
 	}
 
@@ -1259,6 +1740,10 @@ collectionLoop:
 				continue collectionLoop
 			}
 
+			if !reflect.DeepEqual(target.distinct, potentialMatchingTarget.distinct) {
+				continue collectionLoop
+			}
+
 			if !target.filter.HasValue() && potentialMatchingTarget.filter.HasValue() {
 				continue collectionLoop
 			}
@@ -1292,15 +1777,17 @@ func tryGetTarget(
 	filter *Filter,
 	limit *Limit,
 	order *OrderBy,
+	distinct *Distinct,
 	collection []Requestable,
 ) (Requestable, bool) {
 	dummyTarget := Targetable{
 		Field: Field{
 			Name: name,
 		},
-		Filter:  filter,
-		Limit:   limit,
-		OrderBy: order,
+		Filter:   filter,
+		Limit:    limit,
+		OrderBy:  order,
+		Distinct: distinct,
 	}
 
 	for _, field := range collection {
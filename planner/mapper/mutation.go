@@ -17,6 +17,7 @@ const (
 	CreateObjects
 	UpdateObjects
 	DeleteObjects
+	RevertObjects
 )
 
 // Mutation represents a request to mutate data stored in Defra.
@@ -30,6 +31,9 @@ type Mutation struct {
 	// The data to be used for the mutation.  For example, during a create this
 	// will be the json representation of the object to be inserted.
 	Data string
+
+	// The version to restore the document to during a revert.
+	Cid string
 }
 
 func (m *Mutation) CloneTo(index int) Requestable {
@@ -41,5 +45,6 @@ func (m *Mutation) cloneTo(index int) *Mutation {
 		Select: *m.Select.cloneTo(index),
 		Type:   m.Type,
 		Data:   m.Data,
+		Cid:    m.Cid,
 	}
 }
@@ -11,6 +11,8 @@
 package mapper
 
 import (
+	"time"
+
 	"github.com/sourcenetwork/immutable"
 
 	"github.com/sourcenetwork/defradb/core"
@@ -30,9 +32,18 @@ type Select struct {
 	// A commit identifier that can be specified to request data at a given time.
 	Cid immutable.Option[string]
 
+	// A time at which the documents should be read, each document is returned at
+	// its latest version committed at or before that time.
+	AsOf immutable.Option[time.Time]
+
 	// The name of the collection that this Select selects data from.
 	CollectionName string
 
+	// Opaque cursors restricting the results to those after and/or before the documents
+	// that they were taken from.
+	After  immutable.Option[string]
+	Before immutable.Option[string]
+
 	// The fields that are to be selected.
 	//
 	// These can include stuff such as version information, aggregates, and other
@@ -57,7 +68,10 @@ func (s *Select) cloneTo(index int) *Select {
 		Targetable:      *s.Targetable.cloneTo(index),
 		DocumentMapping: s.DocumentMapping,
 		Cid:             s.Cid,
+		AsOf:            s.AsOf,
 		CollectionName:  s.CollectionName,
+		After:           s.After,
+		Before:          s.Before,
 		Fields:          s.Fields,
 	}
 }
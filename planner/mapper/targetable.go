@@ -103,6 +103,14 @@ type GroupBy struct {
 	Fields []Field
 }
 
+// Distinct represents a de-duplication instruction on a request.
+type Distinct struct {
+	// The fields by whose values documents should be de-duplicated.
+	//
+	// If empty, the items themselves are de-duplicated (inline arrays only).
+	Fields []Field
+}
+
 type SortDirection string
 
 const (
@@ -151,6 +159,10 @@ type Targetable struct {
 	// value
 	OrderBy *OrderBy
 
+	// An optional distinct clause, that can be specified to de-duplicate results by
+	// property value.
+	Distinct *Distinct
+
 	ShowDeleted bool
 }
 
@@ -162,6 +174,7 @@ func (t *Targetable) cloneTo(index int) *Targetable {
 		Limit:       t.Limit,
 		GroupBy:     t.GroupBy,
 		OrderBy:     t.OrderBy,
+		Distinct:    t.Distinct,
 		ShowDeleted: t.ShowDeleted,
 	}
 }
@@ -0,0 +1,342 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package planner
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/sourcenetwork/immutable"
+	"github.com/sourcenetwork/immutable/enumerable"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/client/request"
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/db/base"
+	"github.com/sourcenetwork/defradb/planner/mapper"
+)
+
+// minMaxNode yields the smallest, or the largest, of the values of its targets.
+//
+// Null values are ignored, if there are no values the result is null.
+type minMaxNode struct {
+	documentIterator
+	docMapper
+
+	p    *Planner
+	plan planNode
+
+	isMax             bool
+	virtualFieldIndex int
+	aggregateMapping  []mapper.AggregateTarget
+
+	execInfo minMaxExecInfo
+}
+
+type minMaxExecInfo struct {
+	// Total number of times minMaxNode was executed.
+	iterations uint64
+}
+
+// Min creates a new minMaxNode yielding the smallest value of the targets of the given aggregate.
+func (p *Planner) Min(field *mapper.Aggregate) *minMaxNode {
+	return p.newMinMaxNode(field, false)
+}
+
+// Max creates a new minMaxNode yielding the largest value of the targets of the given aggregate.
+func (p *Planner) Max(field *mapper.Aggregate) *minMaxNode {
+	return p.newMinMaxNode(field, true)
+}
+
+func (p *Planner) newMinMaxNode(field *mapper.Aggregate, isMax bool) *minMaxNode {
+	return &minMaxNode{
+		p:                 p,
+		isMax:             isMax,
+		aggregateMapping:  field.AggregateTargets,
+		virtualFieldIndex: field.Index,
+		docMapper:         docMapper{&field.DocumentMapping},
+	}
+}
+
+func (n *minMaxNode) Kind() string {
+	if n.isMax {
+		return "maxNode"
+	}
+	return "minNode"
+}
+
+func (n *minMaxNode) Init() error {
+	return n.plan.Init()
+}
+
+func (n *minMaxNode) Start() error { return n.plan.Start() }
+
+func (n *minMaxNode) Spans(spans core.Spans) { n.plan.Spans(spans) }
+
+func (n *minMaxNode) Close() error { return n.plan.Close() }
+
+func (n *minMaxNode) Source() planNode { return n.plan }
+
+func (n *minMaxNode) simpleExplain() (map[string]any, error) {
+	return explainAggregateTargets(n.aggregateMapping), nil
+}
+
+// Explain method returns a map containing all attributes of this node that
+// are to be explained, subscribes / opts-in this node to be an explainablePlanNode.
+func (n *minMaxNode) Explain(explainType request.ExplainType) (map[string]any, error) {
+	switch explainType {
+	case request.SimpleExplain:
+		return n.simpleExplain()
+
+	case request.ExecuteExplain:
+		return map[string]any{
+			"iterations": n.execInfo.iterations,
+		}, nil
+
+	default:
+		return nil, ErrUnknownExplainRequestType
+	}
+}
+
+func (n *minMaxNode) Next() (bool, error) {
+	n.execInfo.iterations++
+
+	hasNext, err := n.plan.Next()
+	if err != nil || !hasNext {
+		return hasNext, err
+	}
+
+	n.currentValue = n.plan.Value()
+
+	// the values are compared by key, date times are compared as times and not as strings
+	var result, resultKey any
+	for _, target := range n.aggregateMapping {
+		values, err := aggregateTargetValues(n.currentValue, []mapper.AggregateTarget{target})
+		if err != nil {
+			return false, err
+		}
+		for _, value := range values {
+			if value == nil {
+				continue
+			}
+			key := value
+			if target.ChildTarget.Kind == client.FieldKind_DATETIME {
+				key, err = parseDateTime(value)
+				if err != nil {
+					return false, err
+				}
+			}
+			if result == nil {
+				result, resultKey = value, key
+				continue
+			}
+			comparison, err := compareMinMaxValues(key, resultKey)
+			if err != nil {
+				return false, err
+			}
+			if (n.isMax && comparison > 0) || (!n.isMax && comparison < 0) {
+				result, resultKey = value, key
+			}
+		}
+	}
+
+	n.currentValue.Fields[n.virtualFieldIndex] = result
+	return true, nil
+}
+
+func (n *minMaxNode) SetPlan(p planNode) { n.plan = p }
+
+// explainAggregateTargets returns the simple explanation of the given aggregate targets.
+func explainAggregateTargets(targets []mapper.AggregateTarget) map[string]any {
+	sourceExplanations := make([]map[string]any, len(targets))
+
+	for i, source := range targets {
+		simpleExplainMap := map[string]any{}
+
+		// Add the filter attribute if it exists.
+		if source.Filter == nil || source.Filter.ExternalConditions == nil {
+			simpleExplainMap[filterLabel] = nil
+		} else {
+			simpleExplainMap[filterLabel] = source.Filter.ExternalConditions
+		}
+
+		// Add the main field name.
+		simpleExplainMap[fieldNameLabel] = source.Field.Name
+
+		// Add the child field name if it exists.
+		if source.ChildTarget.HasValue {
+			simpleExplainMap[childFieldNameLabel] = source.ChildTarget.Name
+		} else {
+			simpleExplainMap[childFieldNameLabel] = nil
+		}
+
+		sourceExplanations[i] = simpleExplainMap
+	}
+
+	return map[string]any{
+		sourcesLabel: sourceExplanations,
+	}
+}
+
+// aggregateTargetValues returns the values of all the given aggregate targets of the given
+// document, including nil values.
+func aggregateTargetValues(doc core.Doc, targets []mapper.AggregateTarget) ([]any, error) {
+	var values []any
+	for _, source := range targets {
+		child := doc.Fields[source.Index]
+		var sourceValues []any
+		var err error
+		switch childCollection := child.(type) {
+		case []core.Doc:
+			sourceValues = docValues(childCollection, source.ChildTarget.Index)
+
+		case []int64:
+			sourceValues, err = itemValues(childCollection, &source, lessN[int64], func(item int64) any {
+				return item
+			})
+
+		case []immutable.Option[int64]:
+			sourceValues, err = itemValues(childCollection, &source, lessO[int64], optionValue[int64])
+
+		case []float64:
+			sourceValues, err = itemValues(childCollection, &source, lessN[float64], func(item float64) any {
+				return item
+			})
+
+		case []immutable.Option[float64]:
+			sourceValues, err = itemValues(childCollection, &source, lessO[float64], optionValue[float64])
+
+		case []string:
+			sourceValues, err = itemValues(childCollection, &source, lessN[string], func(item string) any {
+				return item
+			})
+
+		case []immutable.Option[string]:
+			sourceValues, err = itemValues(childCollection, &source, lessO[string], optionValue[string])
+		}
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, sourceValues...)
+	}
+	return values, nil
+}
+
+// docValues returns the values of the given field of the documents in a slice, skipping over
+// hidden items (a grouping mechanic).
+//
+// Integer values are normalized to int64, as that is the type they are yielded as.
+func docValues(docs []core.Doc, fieldIndex int) []any {
+	values := make([]any, 0, len(docs))
+	for _, doc := range docs {
+		if doc.Hidden {
+			continue
+		}
+		switch v := doc.Fields[fieldIndex].(type) {
+		case int:
+			values = append(values, int64(v))
+		case uint64:
+			values = append(values, int64(v))
+		default:
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// itemValues returns the values of the given inline array items, after the filter, order
+// and limit of the aggregate target have been applied.
+func itemValues[T any](
+	source []T,
+	aggregateTarget *mapper.AggregateTarget,
+	less func(T, T) bool,
+	toValue func(T) any,
+) ([]any, error) {
+	items := enumerable.New(source)
+	if aggregateTarget.Filter != nil {
+		items = enumerable.Where(items, func(item T) (bool, error) {
+			return mapper.RunFilter(item, aggregateTarget.Filter)
+		})
+	}
+
+	if aggregateTarget.OrderBy != nil && len(aggregateTarget.OrderBy.Conditions) > 0 {
+		if aggregateTarget.OrderBy.Conditions[0].Direction == mapper.ASC {
+			items = enumerable.Sort(items, less, len(source))
+		} else {
+			items = enumerable.Sort(items, reverse(less), len(source))
+		}
+	}
+
+	if aggregateTarget.Limit != nil {
+		items = enumerable.Skip(items, aggregateTarget.Limit.Offset)
+		items = enumerable.Take(items, aggregateTarget.Limit.Limit)
+	}
+
+	values := []any{}
+	err := enumerable.ForEach(items, func(item T) {
+		values = append(values, toValue(item))
+	})
+
+	return values, err
+}
+
+// optionValue returns the value of the given option, or nil if it has none.
+func optionValue[T any](item immutable.Option[T]) any {
+	if !item.HasValue() {
+		return nil
+	}
+	return item.Value()
+}
+
+// compareMinMaxValues compares the given non-nil values, integers compared to
+// floats are compared as floats, and times are compared chronologically.
+//
+// Will return an error if the values are of different types.
+func compareMinMaxValues(a any, b any) (int, error) {
+	switch typedA := a.(type) {
+	case int64:
+		if typedB, isFloat := b.(float64); isFloat {
+			return base.Compare(float64(typedA), typedB), nil
+		}
+	case float64:
+		if typedB, isInt := b.(int64); isInt {
+			return base.Compare(typedA, float64(typedB)), nil
+		}
+	case time.Time:
+		if typedB, isTime := b.(time.Time); isTime {
+			switch {
+			case typedA.Before(typedB):
+				return -1, nil
+			case typedA.After(typedB):
+				return 1, nil
+			default:
+				return 0, nil
+			}
+		}
+	}
+
+	if reflect.TypeOf(a) != reflect.TypeOf(b) {
+		return 0, NewErrIncomparableAggregateValues(a, b)
+	}
+	return base.Compare(a, b), nil
+}
+
+// parseDateTime parses the given date time value, as stored in the documents.
+func parseDateTime(value any) (time.Time, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		return time.Parse(time.RFC3339, v)
+	default:
+		return time.Time{}, NewErrIncomparableAggregateValues(value, time.Time{})
+	}
+}
@@ -14,15 +14,21 @@ var (
 	_ planNode = (*averageNode)(nil)
 	_ planNode = (*countNode)(nil)
 	_ planNode = (*createNode)(nil)
+	_ planNode = (*cursorNode)(nil)
 	_ planNode = (*dagScanNode)(nil)
 	_ planNode = (*deleteNode)(nil)
+	_ planNode = (*distinctNode)(nil)
 	_ planNode = (*groupNode)(nil)
 	_ planNode = (*limitNode)(nil)
+	_ planNode = (*minMaxNode)(nil)
 	_ planNode = (*multiScanNode)(nil)
 	_ planNode = (*orderNode)(nil)
 	_ planNode = (*parallelNode)(nil)
+	_ planNode = (*percentileNode)(nil)
 	_ planNode = (*pipeNode)(nil)
+	_ planNode = (*revertNode)(nil)
 	_ planNode = (*scanNode)(nil)
+	_ planNode = (*searchNode)(nil)
 	_ planNode = (*selectNode)(nil)
 	_ planNode = (*selectTopNode)(nil)
 	_ planNode = (*sumNode)(nil)
@@ -32,6 +38,7 @@ var (
 	_ planNode = (*typeJoinOne)(nil)
 	_ planNode = (*updateNode)(nil)
 	_ planNode = (*valuesNode)(nil)
+	_ planNode = (*varianceNode)(nil)
 
 	_ MultiNode = (*parallelNode)(nil)
 	_ MultiNode = (*topLevelNode)(nil)
@@ -0,0 +1,178 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package planner
+
+import (
+	"math"
+	"sort"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/client/request"
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/planner/mapper"
+)
+
+const medianPercentile = 50
+
+// percentileNode yields the given percentile of the values of its targets, interpolating
+// linearly between the closest ranks.
+//
+// Null values are ignored, if there are no values the result is null.
+type percentileNode struct {
+	documentIterator
+	docMapper
+
+	plan planNode
+
+	isMedian          bool
+	percentile        float64
+	virtualFieldIndex int
+	aggregateMapping  []mapper.AggregateTarget
+
+	execInfo percentileExecInfo
+}
+
+type percentileExecInfo struct {
+	// Total number of times percentileNode was executed.
+	iterations uint64
+}
+
+// Median creates a new percentileNode yielding the median of the targets of the given aggregate.
+func (p *Planner) Median(field *mapper.Aggregate) *percentileNode {
+	return &percentileNode{
+		isMedian:          true,
+		percentile:        medianPercentile,
+		aggregateMapping:  field.AggregateTargets,
+		virtualFieldIndex: field.Index,
+		docMapper:         docMapper{&field.DocumentMapping},
+	}
+}
+
+// Percentile creates a new percentileNode yielding the requested percentile of the targets
+// of the given aggregate.
+//
+// Will return an error if the percentile is missing or not between 0 and 100.
+func (p *Planner) Percentile(field *mapper.Aggregate) (*percentileNode, error) {
+	if !field.Percentile.HasValue() {
+		return nil, ErrMissingPercentile
+	}
+
+	percentile := field.Percentile.Value()
+	if percentile < 0 || percentile > 100 {
+		return nil, NewErrInvalidPercentile(percentile)
+	}
+
+	return &percentileNode{
+		percentile:        percentile,
+		aggregateMapping:  field.AggregateTargets,
+		virtualFieldIndex: field.Index,
+		docMapper:         docMapper{&field.DocumentMapping},
+	}, nil
+}
+
+func (n *percentileNode) Kind() string {
+	if n.isMedian {
+		return "medianNode"
+	}
+	return "percentileNode"
+}
+
+func (n *percentileNode) Init() error {
+	return n.plan.Init()
+}
+
+func (n *percentileNode) Start() error { return n.plan.Start() }
+
+func (n *percentileNode) Spans(spans core.Spans) { n.plan.Spans(spans) }
+
+func (n *percentileNode) Close() error { return n.plan.Close() }
+
+func (n *percentileNode) Source() planNode { return n.plan }
+
+func (n *percentileNode) simpleExplain() (map[string]any, error) {
+	explanation := explainAggregateTargets(n.aggregateMapping)
+	if !n.isMedian {
+		explanation[percentileLabel] = n.percentile
+	}
+	return explanation, nil
+}
+
+// Explain method returns a map containing all attributes of this node that
+// are to be explained, subscribes / opts-in this node to be an explainablePlanNode.
+func (n *percentileNode) Explain(explainType request.ExplainType) (map[string]any, error) {
+	switch explainType {
+	case request.SimpleExplain:
+		return n.simpleExplain()
+
+	case request.ExecuteExplain:
+		return map[string]any{
+			"iterations": n.execInfo.iterations,
+		}, nil
+
+	default:
+		return nil, ErrUnknownExplainRequestType
+	}
+}
+
+func (n *percentileNode) Next() (bool, error) {
+	n.execInfo.iterations++
+
+	hasNext, err := n.plan.Next()
+	if err != nil || !hasNext {
+		return hasNext, err
+	}
+
+	n.currentValue = n.plan.Value()
+
+	values, err := aggregateTargetValues(n.currentValue, n.aggregateMapping)
+	if err != nil {
+		return false, err
+	}
+
+	numbers, err := numericValues(values)
+	if err != nil {
+		return false, err
+	}
+
+	if len(numbers) == 0 {
+		n.currentValue.Fields[n.virtualFieldIndex] = nil
+		return true, nil
+	}
+
+	sort.Float64s(numbers)
+
+	rank := n.percentile / 100 * float64(len(numbers)-1)
+	lower := numbers[int(math.Floor(rank))]
+	upper := numbers[int(math.Ceil(rank))]
+
+	n.currentValue.Fields[n.virtualFieldIndex] = lower + (upper-lower)*(rank-math.Floor(rank))
+	return true, nil
+}
+
+func (n *percentileNode) SetPlan(p planNode) { n.plan = p }
+
+// numericValues returns the given values as floats, skipping over nil values.
+func numericValues(values []any) ([]float64, error) {
+	numbers := make([]float64, 0, len(values))
+	for _, value := range values {
+		switch v := value.(type) {
+		case nil:
+			continue
+		case int64:
+			numbers = append(numbers, float64(v))
+		case float64:
+			numbers = append(numbers, v)
+		default:
+			return nil, client.NewErrUnhandledType("value", value)
+		}
+	}
+	return numbers, nil
+}
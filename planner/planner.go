@@ -13,6 +13,8 @@ package planner
 import (
 	"context"
 
+	"github.com/sourcenetwork/immutable"
+
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/client/request"
 	"github.com/sourcenetwork/defradb/core"
@@ -89,6 +91,10 @@ type Planner struct {
 	db  client.Store
 
 	ctx context.Context
+
+	// pageInfo describes the page of results yielded by the last executed request, if
+	// it was given a limit or a cursor.
+	pageInfo immutable.Option[client.PageInfo]
 }
 
 func New(ctx context.Context, db client.Store, txn datastore.Txn) *Planner {
@@ -114,6 +120,15 @@ func (p *Planner) newPlan(stmt any) (planNode, error) {
 		if len(n.Selections) == 0 {
 			return nil, ErrOperationDefinitionMissingSelection
 		}
+		if len(n.Selections) > 1 {
+			// the page info of a request is only returned for a single select, so cursors
+			// may only be given to requests with a single select.
+			for _, selection := range n.Selections {
+				if s, isSelect := selection.(*request.Select); isSelect && (s.After.HasValue() || s.Before.HasValue()) {
+					return nil, ErrCursorWithMultipleSelects
+				}
+			}
+		}
 		return p.newPlan(n.Selections[0])
 
 	case *request.Select:
@@ -138,6 +153,13 @@ func (p *Planner) newPlan(stmt any) (planNode, error) {
 		}
 		return p.CommitSelect(m)
 
+	case *request.DiffSelect:
+		m, err := mapper.ToDiffSelect(p.ctx, p.txn, n)
+		if err != nil {
+			return nil, err
+		}
+		return p.Diff(m)
+
 	case *request.ObjectMutation:
 		m, err := mapper.ToMutation(p.ctx, p.txn, n)
 		if err != nil {
@@ -160,6 +182,9 @@ func (p *Planner) newObjectMutationPlan(stmt *mapper.Mutation) (planNode, error)
 	case mapper.DeleteObjects:
 		return p.DeleteDocs(stmt)
 
+	case mapper.RevertObjects:
+		return p.RevertDoc(stmt)
+
 	default:
 		return nil, client.NewErrUnhandledType("mutation", stmt.Type)
 	}
@@ -195,7 +220,11 @@ func (p *Planner) expandPlan(planNode planNode, parentPlan *selectTopNode) error
 		return p.expandSelectTopNodePlan(n, parentPlan)
 
 	case *selectNode:
-		return p.expandPlan(n.source, parentPlan)
+		if err := p.expandPlan(n.source, parentPlan); err != nil {
+			return err
+		}
+		p.expandFilterAggregatePlans(n)
+		return nil
 
 	case *typeIndexJoin:
 		return p.expandTypeIndexJoinPlan(n, parentPlan)
@@ -240,6 +269,9 @@ func (p *Planner) expandPlan(planNode planNode, parentPlan *selectTopNode) error
 	case *deleteNode:
 		return p.expandPlan(n.source, parentPlan)
 
+	case *revertNode:
+		return p.expandPlan(n.results, parentPlan)
+
 	default:
 		return nil
 	}
@@ -270,6 +302,15 @@ func (p *Planner) expandSelectTopNodePlan(plan *selectTopNode, parentPlan *selec
 		plan.planNode = plan.order
 	}
 
+	if plan.distinct != nil {
+		p.expandDistinctPlan(plan, parentPlan)
+	}
+
+	if plan.cursor != nil {
+		plan.cursor.plan = plan.planNode
+		plan.planNode = plan.cursor
+	}
+
 	if plan.limit != nil {
 		p.expandLimitPlan(plan, parentPlan)
 	}
@@ -292,6 +333,18 @@ func (p *Planner) expandAggregatePlans(plan *selectTopNode) {
 	}
 }
 
+// expandFilterAggregatePlans wires the aggregates that the filter of the given select
+// conditions on beneath it, so that their values are available to the filter.
+func (p *Planner) expandFilterAggregatePlans(plan *selectNode) {
+	// Iterate through the aggregates backwards to ensure dependencies
+	// execute *before* any aggregate dependent on them.
+	for i := len(plan.filterAggregates) - 1; i >= 0; i-- {
+		aggregate := plan.filterAggregates[i]
+		aggregate.SetPlan(plan.source)
+		plan.source = aggregate
+	}
+}
+
 func (p *Planner) expandMultiNode(multiNode MultiNode, parentPlan *selectTopNode) error {
 	for _, child := range multiNode.Children() {
 		if err := p.expandPlan(child, parentPlan); err != nil {
@@ -387,6 +440,18 @@ func (p *Planner) expandLimitPlan(topNodeSelect *selectTopNode, parentPlan *sele
 	topNodeSelect.planNode = topNodeSelect.limit
 }
 
+func (p *Planner) expandDistinctPlan(topNodeSelect *selectTopNode, parentPlan *selectTopNode) {
+	// As with limits, distinct is handled internally by the group node for grouped
+	// child selects, so we ensure any distinct topNodeSelect is disabled here
+	if parentPlan != nil && parentPlan.group != nil && len(parentPlan.group.childSelects) != 0 {
+		topNodeSelect.distinct = nil
+		return
+	}
+
+	topNodeSelect.distinct.plan = topNodeSelect.planNode
+	topNodeSelect.planNode = topNodeSelect.distinct
+}
+
 // walkAndReplace walks through the provided plan, and searches for an instance
 // of the target plan, and replaces it with the replace plan
 func (p *Planner) walkAndReplacePlan(planNode, target, replace planNode) error {
@@ -442,6 +507,11 @@ func (p *Planner) executeRequest(
 	ctx context.Context,
 	planNode planNode,
 ) ([]map[string]any, error) {
+	top, isSelectTop := planNode.(*selectTopNode)
+	if isSelectTop {
+		top.enablePaging()
+	}
+
 	if err := planNode.Start(); err != nil {
 		return nil, err
 	}
@@ -463,9 +533,23 @@ func (p *Planner) executeRequest(
 			return nil, err
 		}
 	}
+
+	if isSelectTop && top.paged {
+		pageInfo, err := top.pageInfo()
+		if err != nil {
+			return nil, err
+		}
+		p.pageInfo = immutable.Some(pageInfo)
+	}
 	return docs, err
 }
 
+// PageInfo returns the page info of the results of the last executed request, if it was
+// given a limit or a cursor.
+func (p *Planner) PageInfo() immutable.Option[client.PageInfo] {
+	return p.pageInfo
+}
+
 // RunRequest classifies the type of request to run, runs it, and then returns the result(s).
 func (p *Planner) RunRequest(
 	ctx context.Context,
@@ -206,6 +206,8 @@ func (p *Planner) Scan(parsed *mapper.Select) *scanNode {
 	var f fetcher.Fetcher
 	if parsed.Cid.HasValue() {
 		f = new(fetcher.VersionedFetcher)
+	} else if parsed.AsOf.HasValue() {
+		f = fetcher.NewAsOfFetcher(parsed.AsOf.Value())
 	} else {
 		f = new(fetcher.DocumentFetcher)
 	}
@@ -0,0 +1,283 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package planner
+
+import (
+	"sort"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/client/request"
+	"github.com/sourcenetwork/defradb/connor"
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/db/base"
+	"github.com/sourcenetwork/defradb/db/fulltext"
+	"github.com/sourcenetwork/defradb/planner/mapper"
+)
+
+// searchNode yields the documents matching a `_search` condition on a field with a full-text
+// index, the most relevant documents first.
+//
+// The documents matching the search are found in the index, and the scan of the source of this
+// node is restricted to them. The `_search` condition remains in the filter of the scan, so the
+// documents are still matched against their current values.
+type searchNode struct {
+	docMapper
+
+	p    *Planner
+	plan planNode
+
+	// The scan of the collection, which is the source of the plan or is found within it.
+	scan *scanNode
+
+	// True if the documents to scan have been given by the parent of this node, such as
+	// by a join, in which case the documents found in the index are only ranked.
+	hasSpans bool
+
+	desc  client.CollectionDescription
+	field client.FieldDescription
+	text  string
+
+	// The rank of each of the documents matching the search, by key.
+	ranks map[string]int
+
+	// The documents yielded by the source, in order of their rank.
+	docs       []core.Doc
+	docsLoaded bool
+
+	currentValue core.Doc
+
+	execInfo searchExecInfo
+}
+
+type searchExecInfo struct {
+	// Total number of times searchNode was executed.
+	iterations uint64
+
+	// Total number of documents found in the index matching the search.
+	indexMatches uint64
+}
+
+// Search creates a new searchNode from the `_search` condition of the given select, on a field
+// with a full-text index.
+//
+// Returns nil if there is no such condition at the top level of the filter of the select. An
+// error is returned if the searched field has no full-text index, or if the condition is nested
+// within another operator or a relation, as the results could then not be ranked.
+func (p *Planner) Search(
+	parsed *mapper.Select,
+	desc client.CollectionDescription,
+) (*searchNode, error) {
+	if parsed.Filter == nil {
+		return nil, nil
+	}
+
+	// the conditions are visited in field order, so that the same field is searched
+	// if more than one is given.
+	fieldIndexes := []int{}
+	searches := map[int]*connor.SearchCondition{}
+	for key, condition := range parsed.Filter.Conditions {
+		property, isProperty := key.(*mapper.PropertyIndex)
+		if !isProperty {
+			if hasSearchCondition(condition) {
+				return nil, ErrNestedSearch
+			}
+			continue
+		}
+		operators, isMap := condition.(map[connor.FilterKey]any)
+		if !isMap {
+			continue
+		}
+		for operator, value := range operators {
+			op, isOperator := operator.(*mapper.Operator)
+			if !isOperator {
+				if hasSearchCondition(value) {
+					return nil, ErrNestedSearch
+				}
+				continue
+			}
+			if op.Operation != "_search" {
+				if hasSearchCondition(value) {
+					return nil, ErrNestedSearch
+				}
+				continue
+			}
+			if search, isSearch := value.(*connor.SearchCondition); isSearch {
+				fieldIndexes = append(fieldIndexes, property.Index)
+				searches[property.Index] = search
+			}
+		}
+	}
+	sort.Ints(fieldIndexes)
+
+	var node *searchNode
+	for _, index := range fieldIndexes {
+		fieldName := getFieldNameByIndex(&parsed.DocumentMapping, index)
+		field, ok := desc.GetField(fieldName)
+		if !ok || field.Index != client.FULLTEXT_INDEX {
+			return nil, NewErrSearchWithoutFullTextIndex(fieldName)
+		}
+		if node == nil {
+			node = &searchNode{
+				p:         p,
+				desc:      desc,
+				field:     field,
+				text:      searches[index].Text,
+				docMapper: docMapper{&parsed.DocumentMapping},
+			}
+		}
+	}
+
+	if parsed.Cid.HasValue() || parsed.AsOf.HasValue() {
+		// the index only holds the current values of the documents
+		return nil, nil
+	}
+	if parsed.DocKeys.HasValue() {
+		// the documents are already restricted to the given keys
+		return nil, nil
+	}
+	return node, nil
+}
+
+// hasSearchCondition returns true if the given filter clause holds a `_search` condition.
+func hasSearchCondition(clause any) bool {
+	switch typedClause := clause.(type) {
+	case *connor.SearchCondition:
+		return true
+	case map[connor.FilterKey]any:
+		for _, value := range typedClause {
+			if hasSearchCondition(value) {
+				return true
+			}
+		}
+	case []any:
+		for _, value := range typedClause {
+			if hasSearchCondition(value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getFieldNameByIndex returns the name of the field at the given index of the given mapping.
+func getFieldNameByIndex(mapping *core.DocumentMapping, index int) string {
+	for name, indexes := range mapping.IndexesByName {
+		for _, i := range indexes {
+			if i == index {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+func (n *searchNode) Kind() string {
+	return "searchNode"
+}
+
+func (n *searchNode) Init() error {
+	// reset stateful data
+	n.docs = nil
+	n.docsLoaded = false
+
+	matches, err := fulltext.Search(n.p.ctx, n.p.txn.Datastore(), n.desc, n.field, n.text)
+	if err != nil {
+		return err
+	}
+	n.execInfo.indexMatches += uint64(len(matches))
+
+	n.ranks = make(map[string]int, len(matches))
+	spans := make([]core.Span, len(matches))
+	for i, match := range matches {
+		n.ranks[match.DocKey] = i
+		key := base.MakeDocKey(n.desc, match.DocKey)
+		spans[i] = core.NewSpan(key, key.PrefixEnd())
+	}
+	if !n.hasSpans {
+		// the spans must be given in key order to be merged by the fetcher
+		sort.Slice(spans, func(i, j int) bool {
+			return spans[i].Start().ToString() < spans[j].Start().ToString()
+		})
+		n.scan.Spans(core.NewSpans(spans...))
+	}
+
+	return n.plan.Init()
+}
+
+func (n *searchNode) Start() error     { return n.plan.Start() }
+func (n *searchNode) Close() error     { return n.plan.Close() }
+func (n *searchNode) Value() core.Doc  { return n.currentValue }
+func (n *searchNode) Source() planNode { return n.plan }
+
+func (n *searchNode) Spans(spans core.Spans) {
+	n.hasSpans = true
+	n.plan.Spans(spans)
+}
+
+func (n *searchNode) Next() (bool, error) {
+	n.execInfo.iterations++
+
+	if !n.docsLoaded {
+		if err := n.loadDocs(); err != nil {
+			return false, err
+		}
+	}
+	if len(n.docs) == 0 {
+		return false, nil
+	}
+	n.currentValue = n.docs[0]
+	n.docs = n.docs[1:]
+	return true, nil
+}
+
+// loadDocs reads all the documents yielded by the source, and sorts them by their rank.
+func (n *searchNode) loadDocs() error {
+	n.docsLoaded = true
+	for {
+		hasNext, err := n.plan.Next()
+		if err != nil {
+			return err
+		}
+		if !hasNext {
+			break
+		}
+		doc := n.plan.Value()
+		n.docs = append(n.docs, doc.Clone())
+	}
+
+	sort.SliceStable(n.docs, func(i, j int) bool {
+		return n.ranks[n.docs[i].GetKey()] < n.ranks[n.docs[j].GetKey()]
+	})
+	return nil
+}
+
+func (n *searchNode) simpleExplain() (map[string]any, error) {
+	return map[string]any{
+		fieldNameLabel: n.field.Name,
+		searchLabel:    n.text,
+	}, nil
+}
+
+func (n *searchNode) Explain(explainType request.ExplainType) (map[string]any, error) {
+	switch explainType {
+	case request.SimpleExplain:
+		return n.simpleExplain()
+
+	case request.ExecuteExplain:
+		return map[string]any{
+			"iterations":   n.execInfo.iterations,
+			"indexMatches": n.execInfo.indexMatches,
+		}, nil
+
+	default:
+		return nil, ErrUnknownExplainRequestType
+	}
+}
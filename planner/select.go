@@ -14,7 +14,9 @@ import (
 	cid "github.com/ipfs/go-cid"
 	"github.com/sourcenetwork/immutable"
 
+	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/client/request"
+	"github.com/sourcenetwork/defradb/connor"
 	"github.com/sourcenetwork/defradb/core"
 	"github.com/sourcenetwork/defradb/db/base"
 	"github.com/sourcenetwork/defradb/db/fetcher"
@@ -50,6 +52,8 @@ type selectTopNode struct {
 
 	group      *groupNode
 	order      *orderNode
+	distinct   *distinctNode
+	cursor     *cursorNode
 	limit      *limitNode
 	aggregates []aggregateNode
 
@@ -58,6 +62,12 @@ type selectTopNode struct {
 
 	// plan is the top of the plan graph (the wired and finalized plan graph).
 	planNode planNode
+
+	// paged is true if the page of results yielded by this node is being tracked, so
+	// that its page info can be returned alongside them.
+	paged    bool
+	firstDoc immutable.Option[core.Doc]
+	lastDoc  immutable.Option[core.Doc]
 }
 
 func (n *selectTopNode) Kind() string { return "selectTopNode" }
@@ -66,7 +76,64 @@ func (n *selectTopNode) Init() error { return n.planNode.Init() }
 
 func (n *selectTopNode) Start() error { return n.planNode.Start() }
 
-func (n *selectTopNode) Next() (bool, error) { return n.planNode.Next() }
+func (n *selectTopNode) Next() (bool, error) {
+	hasNext, err := n.planNode.Next()
+	if hasNext && n.paged {
+		doc := n.planNode.Value()
+		if !n.firstDoc.HasValue() {
+			n.firstDoc = immutable.Some(doc.Clone())
+		}
+		n.lastDoc = immutable.Some(doc.Clone())
+	}
+	return hasNext, err
+}
+
+// enablePaging enables the tracking of the page of results yielded by this node, if the
+// select has been given a limit or a cursor.
+//
+// Grouped results have no cursors, and so are never paged.
+func (n *selectTopNode) enablePaging() {
+	if n.group != nil || (n.limit == nil && n.cursor == nil) {
+		return
+	}
+	n.paged = true
+	if n.limit != nil {
+		n.limit.checkHasMore = true
+	}
+}
+
+// pageInfo returns the page info of the results yielded by this node.
+//
+// Should only be called once all the results have been yielded.
+func (n *selectTopNode) pageInfo() (client.PageInfo, error) {
+	var ordering []mapper.OrderCondition
+	if n.order != nil {
+		ordering = n.order.ordering
+	}
+
+	info := client.PageInfo{}
+	if n.firstDoc.HasValue() {
+		startCursor, err := encodeCursor(n.firstDoc.Value(), ordering)
+		if err != nil {
+			return client.PageInfo{}, err
+		}
+		endCursor, err := encodeCursor(n.lastDoc.Value(), ordering)
+		if err != nil {
+			return client.PageInfo{}, err
+		}
+		info.StartCursor = startCursor
+		info.EndCursor = endCursor
+	}
+	if n.limit != nil {
+		info.HasNextPage = n.limit.hasMore
+		info.HasPreviousPage = n.limit.offset > 0
+	}
+	if n.cursor != nil {
+		info.HasNextPage = info.HasNextPage || n.cursor.hasNext()
+		info.HasPreviousPage = info.HasPreviousPage || n.cursor.hasPrevious()
+	}
+	return info, nil
+}
 
 func (n *selectTopNode) Spans(spans core.Spans) { n.planNode.Spans(spans) }
 
@@ -105,6 +172,9 @@ type selectNode struct {
 	// collection name, meta-data, etc.
 	sourceInfo sourceInfo
 
+	// the full-text search ranking the documents of the source, if any.
+	search *searchNode
+
 	// top level filter expression
 	// filter is split between select, scan, and typeIndexJoin.
 	// The filters which only apply to the main collection
@@ -115,6 +185,10 @@ type selectNode struct {
 	// are defined in the subtype scan node.
 	filter *mapper.Filter
 
+	// The aggregates that the filter conditions on. These are executed beneath
+	// the select, before the filter, instead of on top of it.
+	filterAggregates []aggregateNode
+
 	docKeys immutable.Option[[]string]
 
 	selectReq    *mapper.Select
@@ -253,6 +327,9 @@ func (n *selectNode) initSource() ([]aggregateNode, error) {
 		// a TimeTravel (History-Traversing Versioned) query, which means
 		// we need to propagate the values to the underlying VersionedFetcher
 		if n.selectReq.Cid.HasValue() {
+			if n.selectReq.AsOf.HasValue() {
+				return nil, ErrAsOfWithCid
+			}
 			c, err := cid.Decode(n.selectReq.Cid.Value())
 			if err != nil {
 				return nil, err
@@ -278,11 +355,107 @@ func (n *selectNode) initSource() ([]aggregateNode, error) {
 		}
 	}
 
-	return n.initFields(n.selectReq)
+	// The search is planned before the fields, as the conditions on relations are
+	// moved out of the filter of the select when the joins are planned.
+	searchPlan, err := n.planner.Search(n.selectReq, sourcePlan.info.collectionDescription)
+	if err != nil {
+		return nil, err
+	}
+
+	aggregates, err := n.initFields(n.selectReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok {
+		// conditions on aggregates can only be evaluated once the aggregates
+		// have been executed, so they are moved from the scan to the select.
+		for index := range filterAggregateIndexes(n.selectReq) {
+			var aggregateFilter *mapper.Filter
+			origScan.filter, aggregateFilter = splitFilterByType(origScan.filter, index)
+			n.filter = appendFilterConditions(n.filter, aggregateFilter)
+		}
+
+		// If the filter searches a field with a full-text index, the documents
+		// are found in the index and yielded in order of their relevance
+		if searchPlan != nil {
+			searchPlan.scan = origScan
+			searchPlan.plan = n.source
+			n.source = searchPlan
+			n.search = searchPlan
+		}
+	}
+
+	return aggregates, nil
+}
+
+// filterAggregateIndexes returns the indexes of the aggregates that the filter of the given
+// select conditions on, along with the indexes of the aggregates that they depend on.
+func filterAggregateIndexes(selectReq *mapper.Select) map[int]struct{} {
+	indexes := map[int]struct{}{}
+	if selectReq.Filter == nil {
+		return indexes
+	}
+
+	aggregates := map[int]*mapper.Aggregate{}
+	for _, field := range selectReq.Fields {
+		if aggregate, isAggregate := field.(*mapper.Aggregate); isAggregate {
+			aggregates[aggregate.Index] = aggregate
+		}
+	}
+
+	var addIndex func(aggregate *mapper.Aggregate)
+	addIndex = func(aggregate *mapper.Aggregate) {
+		indexes[aggregate.Index] = struct{}{}
+		for _, dependency := range aggregate.Dependencies {
+			addIndex(dependency)
+		}
+	}
+
+	var walkConditions func(clause any)
+	walkConditions = func(clause any) {
+		switch typedClause := clause.(type) {
+		case map[connor.FilterKey]any:
+			for key, innerClause := range typedClause {
+				switch typedKey := key.(type) {
+				case *mapper.PropertyIndex:
+					if aggregate, isAggregate := aggregates[typedKey.Index]; isAggregate {
+						addIndex(aggregate)
+					}
+				case *mapper.Operator:
+					walkConditions(innerClause)
+				}
+			}
+		case []any:
+			for _, innerClause := range typedClause {
+				walkConditions(innerClause)
+			}
+		}
+	}
+	walkConditions(selectReq.Filter.Conditions)
+
+	return indexes
+}
+
+// appendFilterConditions appends the conditions of the given source filter to the
+// target filter, returning the result.
+func appendFilterConditions(target *mapper.Filter, source *mapper.Filter) *mapper.Filter {
+	if source == nil || len(source.Conditions) == 0 {
+		return target
+	}
+	if target == nil || target.Conditions == nil {
+		return source
+	}
+	for key, clause := range source.Conditions {
+		target.Conditions[key] = clause
+	}
+	return target
 }
 
 func (n *selectNode) initFields(selectReq *mapper.Select) ([]aggregateNode, error) {
 	aggregates := []aggregateNode{}
+	// this must be done before the joins are added, as they split the filter
+	filterAggregates := filterAggregateIndexes(selectReq)
 	// loop over the sub type
 	// at the moment, we're only testing a single sub selection
 	for _, field := range selectReq.Fields {
@@ -298,13 +471,30 @@ func (n *selectNode) initFields(selectReq *mapper.Select) ([]aggregateNode, erro
 				plan, aggregateError = n.planner.Sum(f, selectReq)
 			case request.AverageFieldName:
 				plan, aggregateError = n.planner.Average(f)
+			case request.MinFieldName:
+				plan = n.planner.Min(f)
+			case request.MaxFieldName:
+				plan = n.planner.Max(f)
+			case request.MedianFieldName:
+				plan = n.planner.Median(f)
+			case request.PercentileFieldName:
+				plan, aggregateError = n.planner.Percentile(f)
+			case request.StdDevFieldName:
+				plan = n.planner.StdDev(f)
+			case request.VarianceFieldName:
+				plan = n.planner.Variance(f)
 			}
 
 			if aggregateError != nil {
 				return nil, aggregateError
 			}
 
-			if plan != nil {
+			if plan == nil {
+				continue
+			}
+			if _, isFilterAggregate := filterAggregates[f.Index]; isFilterAggregate {
+				n.filterAggregates = append(n.filterAggregates, plan)
+			} else {
 				aggregates = append(aggregates, plan)
 			}
 		case *mapper.Select:
@@ -446,10 +636,16 @@ func (p *Planner) SelectFromSource(
 		return nil, err
 	}
 
+	distinctPlan, err := p.Distinct(selectReq, selectReq.Distinct)
+	if err != nil {
+		return nil, err
+	}
+
 	top := &selectTopNode{
 		selectNode: s,
 		limit:      limitPlan,
 		order:      orderPlan,
+		distinct:   distinctPlan,
 		group:      groupPlan,
 		aggregates: aggregates,
 		docMapper:  docMapper{&selectReq.DocumentMapping},
@@ -490,10 +686,28 @@ func (p *Planner) Select(selectReq *mapper.Select) (planNode, error) {
 		return nil, err
 	}
 
+	distinctPlan, err := p.Distinct(selectReq, selectReq.Distinct)
+	if err != nil {
+		return nil, err
+	}
+
+	cursorPlan, err := p.Cursor(selectReq)
+	if err != nil {
+		return nil, err
+	}
+	if cursorPlan != nil && s.search != nil {
+		return nil, ErrCursorWithSearch
+	}
+	if scan, isScan := s.origSource.(*scanNode); isScan && cursorPlan != nil {
+		cursorPlan.seek(scan, s.sourceInfo.collectionDescription)
+	}
+
 	top := &selectTopNode{
 		selectNode: s,
 		limit:      limitPlan,
 		order:      orderPlan,
+		distinct:   distinctPlan,
+		cursor:     cursorPlan,
 		group:      groupPlan,
 		aggregates: aggregates,
 		docMapper:  docMapper{&selectReq.DocumentMapping},
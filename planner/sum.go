@@ -355,11 +355,15 @@ type number interface {
 	int64 | float64
 }
 
-func lessN[T number](a T, b T) bool {
+type ordered interface {
+	number | string
+}
+
+func lessN[T ordered](a T, b T) bool {
 	return a < b
 }
 
-func lessO[T number](a immutable.Option[T], b immutable.Option[T]) bool {
+func lessO[T ordered](a immutable.Option[T], b immutable.Option[T]) bool {
 	if !a.HasValue() {
 		return true
 	}
@@ -203,6 +203,18 @@ func (p *Planner) Top(m *mapper.Select) (*topLevelNode, error) {
 				child, err = p.Sum(f, m)
 			case request.AverageFieldName:
 				child, err = p.Average(f)
+			case request.MinFieldName:
+				child = p.Min(f)
+			case request.MaxFieldName:
+				child = p.Max(f)
+			case request.MedianFieldName:
+				child = p.Median(f)
+			case request.PercentileFieldName:
+				child, err = p.Percentile(f)
+			case request.StdDevFieldName:
+				child = p.StdDev(f)
+			case request.VarianceFieldName:
+				child = p.Variance(f)
 			}
 			if err != nil {
 				return nil, err
@@ -44,6 +44,11 @@ import (
 // The subType filter is the conditions that apply to the
 // queried sub type ie: {birthday: "June 26, 1990"}.
 //
+// The subType filter is evaluated once the sub types have been joined,
+// against all the related documents, so that the relation filters of
+// one-to-many relations (`_some`, `_every` and `_none`) can match them
+// as a whole.
+//
 // The typeIndexJoin works by using a basic scanNode for the
 // root, and recursively creates a new selectNode for the
 // subType.
@@ -226,17 +231,55 @@ func splitFilterByType(filter *mapper.Filter, subType int) (*mapper.Filter, *map
 		Index: subType,
 	}
 
+	splitF := &mapper.Filter{Conditions: map[connor.FilterKey]any{}}
 	keyFound, sub := removeConditionIndex(conditionKey, filter.Conditions)
-	if !keyFound {
-		return filter, &mapper.Filter{}
+	if keyFound {
+		// our schema ensures that if sub exists, its of type map[string]any
+		splitF.Conditions[conditionKey] = sub
 	}
 
-	// create new splitup filter
-	// our schema ensures that if sub exists, its of type map[string]any
-	splitF := &mapper.Filter{Conditions: map[connor.FilterKey]any{conditionKey: sub}}
+	// compound operators (e.g. `_not`) that refer to the sub type can only be
+	// evaluated once it has been joined, so they are moved as a whole.
+	for key, clause := range filter.Conditions {
+		if _, isOperator := key.(*mapper.Operator); isOperator && referencesConditionIndex(conditionKey, clause) {
+			delete(filter.Conditions, key)
+			splitF.Conditions[key] = clause
+		}
+	}
+
+	if len(splitF.Conditions) == 0 {
+		return filter, &mapper.Filter{}
+	}
 	return filter, splitF
 }
 
+// referencesConditionIndex returns true if the given clause of a compound operator
+// has a condition on the given key, at the same level.
+func referencesConditionIndex(key *mapper.PropertyIndex, clause any) bool {
+	switch typedClause := clause.(type) {
+	case map[connor.FilterKey]any:
+		for targetKey, innerClause := range typedClause {
+			switch typedKey := targetKey.(type) {
+			case *mapper.PropertyIndex:
+				if typedKey.Index == key.Index {
+					return true
+				}
+			case *mapper.Operator:
+				if referencesConditionIndex(key, innerClause) {
+					return true
+				}
+			}
+		}
+	case []any:
+		for _, innerClause := range typedClause {
+			if referencesConditionIndex(key, innerClause) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // typeJoinOne is the plan node for a type index join
 // where the root type is the primary in a one-to-one relation request.
 type typeJoinOne struct {
@@ -267,6 +310,8 @@ func (p *Planner) makeTypeJoinOne(
 		scan.filter, parent.filter = splitFilterByType(scan.filter, subType.Index)
 		subType.ShowDeleted = parent.selectReq.ShowDeleted
 	}
+	// related documents are read at the same time as their parent
+	subType.AsOf = parent.selectReq.AsOf
 
 	selectPlan, err := p.SubSelect(subType)
 	if err != nil {
@@ -456,6 +501,8 @@ func (p *Planner) makeTypeJoinMany(
 		scan.filter, parent.filter = splitFilterByType(scan.filter, subType.Index)
 		subType.ShowDeleted = parent.selectReq.ShowDeleted
 	}
+	// related documents are read at the same time as their parent
+	subType.AsOf = parent.selectReq.AsOf
 
 	selectPlan, err := p.SubSelect(subType)
 	if err != nil {
@@ -91,27 +91,24 @@ func (n *valuesNode) Less(i, j int) bool {
 }
 
 // docValueLess extracts and compare field values of a document, returns true only if strictly less when ASC,
-// and true if greater than or equal when DESC, otherwise returns false.
+// and strictly greater when DESC, otherwise returns false.
+//
+// Documents with equal values are compared by the next ordering, if any.
 func (n *valuesNode) docValueLess(docA, docB core.Doc) bool {
 	for _, order := range n.ordering {
 		compare := base.Compare(
 			getDocProp(docA, order.FieldIndexes),
 			getDocProp(docB, order.FieldIndexes),
 		)
+		if compare == 0 {
+			continue
+		}
 
 		if order.Direction == mapper.DESC {
-			if compare > 0 {
-				return true
-			} else {
-				return false
-			}
-		} else { // Otherwise assume order.Direction == mapper.ASC
-			if compare < 0 {
-				return true
-			} else {
-				return false
-			}
+			return compare > 0
 		}
+		// Otherwise assume order.Direction == mapper.ASC
+		return compare < 0
 	}
 	return false
 }
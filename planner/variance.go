@@ -0,0 +1,144 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package planner
+
+import (
+	"math"
+
+	"github.com/sourcenetwork/defradb/client/request"
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/planner/mapper"
+)
+
+// varianceNode yields the population variance, or the population standard deviation,
+// of the values of its targets.
+//
+// Null values are ignored, if there are no values the result is null.
+type varianceNode struct {
+	documentIterator
+	docMapper
+
+	plan planNode
+
+	isStdDev          bool
+	virtualFieldIndex int
+	aggregateMapping  []mapper.AggregateTarget
+
+	execInfo varianceExecInfo
+}
+
+type varianceExecInfo struct {
+	// Total number of times varianceNode was executed.
+	iterations uint64
+}
+
+// Variance creates a new varianceNode yielding the variance of the targets of the given aggregate.
+func (p *Planner) Variance(field *mapper.Aggregate) *varianceNode {
+	return newVarianceNode(field, false)
+}
+
+// StdDev creates a new varianceNode yielding the standard deviation of the targets of the
+// given aggregate.
+func (p *Planner) StdDev(field *mapper.Aggregate) *varianceNode {
+	return newVarianceNode(field, true)
+}
+
+func newVarianceNode(field *mapper.Aggregate, isStdDev bool) *varianceNode {
+	return &varianceNode{
+		isStdDev:          isStdDev,
+		aggregateMapping:  field.AggregateTargets,
+		virtualFieldIndex: field.Index,
+		docMapper:         docMapper{&field.DocumentMapping},
+	}
+}
+
+func (n *varianceNode) Kind() string {
+	if n.isStdDev {
+		return "stdDevNode"
+	}
+	return "varianceNode"
+}
+
+func (n *varianceNode) Init() error {
+	return n.plan.Init()
+}
+
+func (n *varianceNode) Start() error { return n.plan.Start() }
+
+func (n *varianceNode) Spans(spans core.Spans) { n.plan.Spans(spans) }
+
+func (n *varianceNode) Close() error { return n.plan.Close() }
+
+func (n *varianceNode) Source() planNode { return n.plan }
+
+// Explain method returns a map containing all attributes of this node that
+// are to be explained, subscribes / opts-in this node to be an explainablePlanNode.
+func (n *varianceNode) Explain(explainType request.ExplainType) (map[string]any, error) {
+	switch explainType {
+	case request.SimpleExplain:
+		return explainAggregateTargets(n.aggregateMapping), nil
+
+	case request.ExecuteExplain:
+		return map[string]any{
+			"iterations": n.execInfo.iterations,
+		}, nil
+
+	default:
+		return nil, ErrUnknownExplainRequestType
+	}
+}
+
+func (n *varianceNode) Next() (bool, error) {
+	n.execInfo.iterations++
+
+	hasNext, err := n.plan.Next()
+	if err != nil || !hasNext {
+		return hasNext, err
+	}
+
+	n.currentValue = n.plan.Value()
+
+	values, err := aggregateTargetValues(n.currentValue, n.aggregateMapping)
+	if err != nil {
+		return false, err
+	}
+
+	numbers, err := numericValues(values)
+	if err != nil {
+		return false, err
+	}
+
+	if len(numbers) == 0 {
+		n.currentValue.Fields[n.virtualFieldIndex] = nil
+		return true, nil
+	}
+
+	var sum float64
+	for _, number := range numbers {
+		sum += number
+	}
+	mean := sum / float64(len(numbers))
+
+	var squaredDifferences float64
+	for _, number := range numbers {
+		squaredDifferences += (number - mean) * (number - mean)
+	}
+	variance := squaredDifferences / float64(len(numbers))
+
+	if n.isStdDev {
+		n.currentValue.Fields[n.virtualFieldIndex] = math.Sqrt(variance)
+	} else {
+		n.currentValue.Fields[n.virtualFieldIndex] = variance
+	}
+	return true, nil
+}
+
+func (n *varianceNode) SetPlan(p planNode) { n.plan = p }
@@ -0,0 +1,60 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package parser
+
+import (
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/client/request"
+)
+
+func parseDiffSelect(schema gql.Schema, parent *gql.Object, field *ast.Field) (*request.DiffSelect, error) {
+	diff := &request.DiffSelect{
+		Field: request.Field{
+			Name:  field.Name.Value,
+			Alias: getFieldAlias(field),
+		},
+		// the new version defaults to the current state of the document
+		To: client.LatestVersion,
+	}
+
+	for _, argument := range field.Arguments {
+		prop := argument.Name.Value
+		if prop == request.DocKey {
+			raw := argument.Value.(*ast.StringValue)
+			diff.DocKey = raw.Value
+		} else if prop == request.FromArgName {
+			raw := argument.Value.(*ast.StringValue)
+			diff.From = raw.Value
+		} else if prop == request.ToArgName {
+			raw := argument.Value.(*ast.StringValue)
+			diff.To = raw.Value
+		}
+	}
+
+	// no sub fields (unlikely)
+	if field.SelectionSet == nil {
+		return diff, nil
+	}
+
+	fieldDef := gql.GetFieldDef(schema, parent, field.Name.Value)
+
+	fieldObject, err := typeFromFieldDef(fieldDef)
+	if err != nil {
+		return nil, err
+	}
+
+	diff.Fields, err = parseSelectFields(schema, request.DiffSelection, fieldObject, field.SelectionSet)
+
+	return diff, err
+}
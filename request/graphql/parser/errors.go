@@ -12,6 +12,11 @@ package parser
 
 import "github.com/sourcenetwork/defradb/errors"
 
+const (
+	errInvalidConflictArgument string = "invalid argument for the conflicts subscription"
+	errInvalidConflictField    string = "invalid field for the conflicts subscription"
+)
+
 var (
 	ErrFilterMissingArgumentType      = errors.New("couldn't find filter argument type")
 	ErrInvalidOrderDirection          = errors.New("invalid order direction string")
@@ -23,4 +28,18 @@ var (
 	ErrInvalidNumberOfExplainArgs     = errors.New("invalid number of arguments to an explain request")
 	ErrUnknownExplainType             = errors.New("invalid / unknown explain type")
 	ErrUnknownGQLOperation            = errors.New("unknown GraphQL operation type")
+	ErrInvalidConflictArgument        = errors.New(errInvalidConflictArgument)
+	ErrInvalidConflictField           = errors.New(errInvalidConflictField)
 )
+
+// NewErrInvalidConflictArgument returns an error indicating that the given argument
+// is not supported by the conflicts subscription.
+func NewErrInvalidConflictArgument(name string) error {
+	return errors.New(errInvalidConflictArgument, errors.NewKV("Name", name))
+}
+
+// NewErrInvalidConflictField returns an error indicating that the given field
+// does not exist on the Conflict type.
+func NewErrInvalidConflictField(name string) error {
+	return errors.New(errInvalidConflictField, errors.NewKV("Name", name))
+}
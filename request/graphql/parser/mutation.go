@@ -26,6 +26,7 @@ var (
 		"create": request.CreateObjects,
 		"update": request.UpdateObjects,
 		"delete": request.DeleteObjects,
+		"revert": request.RevertObjects,
 	}
 )
 
@@ -117,6 +118,9 @@ func parseMutation(schema gql.Schema, parent *gql.Object, field *ast.Field) (*re
 			}
 
 			mut.Filter = filter
+		} else if prop == request.Cid {
+			raw := argument.Value.(*ast.StringValue)
+			mut.Cid = raw.Value
 		} else if prop == request.Id {
 			raw := argument.Value.(*ast.StringValue)
 			mut.IDs = immutable.Some([]string{raw.Value})
@@ -12,6 +12,7 @@ package parser
 
 import (
 	"strconv"
+	"time"
 
 	gql "github.com/graphql-go/graphql"
 	"github.com/graphql-go/graphql/language/ast"
@@ -42,6 +43,13 @@ func parseQueryOperationDefinition(
 					return nil, []error{err}
 				}
 
+				parsedSelection = parsed
+			} else if node.Name.Value == request.DiffName {
+				parsed, err := parseDiffSelect(schema, schema.QueryType(), node)
+				if err != nil {
+					return nil, []error{err}
+				}
+
 				parsedSelection = parsed
 			} else if _, isAggregate := request.Aggregates[node.Name.Value]; isAggregate {
 				parsed, err := parseAggregate(schema, schema.QueryType(), node, i)
@@ -137,6 +145,13 @@ func parseSelect(
 		case request.Cid: // parse single CID query field
 			val := astValue.(*ast.StringValue)
 			slct.CID = immutable.Some(val.Value)
+		case request.AsOf:
+			val := astValue.(*ast.StringValue)
+			asOf, err := time.Parse(time.RFC3339, val.Value)
+			if err != nil {
+				return nil, err
+			}
+			slct.AsOf = immutable.Some(asOf)
 		case request.LimitClause: // parse limit/offset
 			val := astValue.(*ast.IntValue)
 			limit, err := strconv.ParseUint(val.Value, 10, 64)
@@ -174,6 +189,24 @@ func parseSelect(
 					Fields: fields,
 				},
 			)
+		case request.DistinctClause:
+			obj := astValue.(*ast.ListValue)
+			fields := make([]string, 0)
+			for _, v := range obj.Values {
+				fields = append(fields, v.GetValue().(string))
+			}
+
+			slct.Distinct = immutable.Some(
+				request.Distinct{
+					Fields: fields,
+				},
+			)
+		case request.AfterClause:
+			val := astValue.(*ast.StringValue)
+			slct.After = immutable.Some(val.Value)
+		case request.BeforeClause:
+			val := astValue.(*ast.StringValue)
+			slct.Before = immutable.Some(val.Value)
 		case request.ShowDeleted:
 			val := astValue.(*ast.BooleanValue)
 			slct.ShowDeleted = val.Value
@@ -200,14 +233,25 @@ func parseSelect(
 }
 
 func parseAggregate(schema gql.Schema, parent *gql.Object, field *ast.Field, index int) (*request.Aggregate, error) {
-	targets := make([]*request.AggregateTarget, len(field.Arguments))
+	targets := make([]*request.AggregateTarget, 0, len(field.Arguments))
+	var percentile immutable.Option[float64]
+
+	for _, argument := range field.Arguments {
+		if argument.Name.Value == request.PercentileArgName {
+			// The percentile will be either an IntValue or a FloatValue, both of which hold a string
+			percentileValue, err := strconv.ParseFloat(argument.Value.GetValue().(string), 64)
+			if err != nil {
+				return nil, err
+			}
+			percentile = immutable.Some(percentileValue)
+			continue
+		}
 
-	for i, argument := range field.Arguments {
 		switch argumentValue := argument.Value.GetValue().(type) {
 		case string:
-			targets[i] = &request.AggregateTarget{
+			targets = append(targets, &request.AggregateTarget{
 				HostName: argumentValue,
-			}
+			})
 		case []*ast.ObjectField:
 			hostName := argument.Name.Value
 			var childName string
@@ -215,6 +259,7 @@ func parseAggregate(schema gql.Schema, parent *gql.Object, field *ast.Field, ind
 			var limit immutable.Option[uint64]
 			var offset immutable.Option[uint64]
 			var order immutable.Option[request.OrderBy]
+			var distinct immutable.Option[request.Distinct]
 
 			fieldArg, hasFieldArg := tryGet(argumentValue, request.FieldName)
 			if hasFieldArg {
@@ -303,14 +348,36 @@ func parseAggregate(schema gql.Schema, parent *gql.Object, field *ast.Field, ind
 				}
 			}
 
-			targets[i] = &request.AggregateTarget{
+			distinctArg, hasDistinctArg := tryGet(argumentValue, request.DistinctClause)
+			if hasDistinctArg {
+				switch distinctArgValue := distinctArg.Value.(type) {
+				case *ast.BooleanValue:
+					// For inline arrays distinct is a simple flag declaring that the items should be
+					// de-duplicated
+					if distinctArgValue.Value {
+						distinct = immutable.Some(request.Distinct{})
+					}
+
+				case *ast.ListValue:
+					// For relations distinct is the set of fields by which the child objects should be
+					// de-duplicated, as used by the host object for non-aggregate selections
+					fields := make([]string, 0)
+					for _, v := range distinctArgValue.Values {
+						fields = append(fields, v.GetValue().(string))
+					}
+					distinct = immutable.Some(request.Distinct{Fields: fields})
+				}
+			}
+
+			targets = append(targets, &request.AggregateTarget{
 				HostName:  hostName,
 				ChildName: immutable.Some(childName),
 				Filter:    filter,
 				Limit:     limit,
 				Offset:    offset,
 				OrderBy:   order,
-			}
+				Distinct:  distinct,
+			})
 		}
 	}
 
@@ -319,6 +386,7 @@ func parseAggregate(schema gql.Schema, parent *gql.Object, field *ast.Field, ind
 			Name:  field.Name.Value,
 			Alias: getFieldAlias(field),
 		},
-		Targets: targets,
+		Targets:    targets,
+		Percentile: percentile,
 	}, nil
 }
@@ -13,6 +13,7 @@ package parser
 import (
 	gql "github.com/graphql-go/graphql"
 	"github.com/graphql-go/graphql/language/ast"
+	"github.com/sourcenetwork/immutable"
 
 	"github.com/sourcenetwork/defradb/client/request"
 )
@@ -30,6 +31,16 @@ func parseSubscriptionOperationDefinition(
 	for i, selection := range def.SelectionSet.Selections {
 		switch node := selection.(type) {
 		case *ast.Field:
+			if node.Name.Value == request.ConflictsName {
+				sub, err := parseConflictSubscription(node)
+				if err != nil {
+					return nil, err
+				}
+
+				sdef.Selections[i] = sub
+				continue
+			}
+
 			sub, err := parseSubscription(schema, node)
 			if err != nil {
 				return nil, err
@@ -82,3 +93,58 @@ func parseSubscription(schema gql.Schema, field *ast.Field) (*request.ObjectSubs
 	sub.Fields, err = parseSelectFields(schema, request.ObjectSelection, fieldObject, field.SelectionSet)
 	return sub, err
 }
+
+// parseConflictSubscription parses a subscription to the conflicts
+// that occur when merging remote updates, which may be limited
+// to a collection and/or a document.
+func parseConflictSubscription(field *ast.Field) (*request.ConflictSubscription, error) {
+	sub := &request.ConflictSubscription{
+		Field: request.Field{
+			Name:  field.Name.Value,
+			Alias: getFieldAlias(field),
+		},
+	}
+
+	for _, argument := range field.Arguments {
+		prop := argument.Name.Value
+		raw, ok := argument.Value.(*ast.StringValue)
+		if !ok {
+			return nil, NewErrInvalidConflictArgument(prop)
+		}
+		if prop == request.ConflictCollectionArgName {
+			sub.Collection = immutable.Some(raw.Value)
+		} else if prop == request.DocKey {
+			sub.DocKey = immutable.Some(raw.Value)
+		} else {
+			return nil, NewErrInvalidConflictArgument(prop)
+		}
+	}
+
+	if field.SelectionSet == nil {
+		return sub, nil
+	}
+	for _, selection := range field.SelectionSet.Selections {
+		node, ok := selection.(*ast.Field)
+		if !ok {
+			continue
+		}
+		if !isConflictField(node.Name.Value) {
+			return nil, NewErrInvalidConflictField(node.Name.Value)
+		}
+		sub.Fields = append(sub.Fields, request.Field{
+			Name:  node.Name.Value,
+			Alias: getFieldAlias(node),
+		})
+	}
+
+	return sub, nil
+}
+
+func isConflictField(name string) bool {
+	for _, conflictField := range request.ConflictFields {
+		if conflictField == name {
+			return true
+		}
+	}
+	return false
+}
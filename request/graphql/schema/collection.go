@@ -17,6 +17,7 @@ import (
 
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/client/request"
+	schemaTypes "github.com/sourcenetwork/defradb/request/graphql/schema/types"
 
 	"github.com/graphql-go/graphql/language/ast"
 	gqlp "github.com/graphql-go/graphql/language/parser"
@@ -136,13 +137,24 @@ func fromAstDefinition(
 			}
 		}
 
+		cType, err := getCRDTType(field, kind)
+		if err != nil {
+			return client.CollectionDescription{}, err
+		}
+
+		indexType, err := getIndexType(field, kind)
+		if err != nil {
+			return client.CollectionDescription{}, err
+		}
+
 		fieldDescription := client.FieldDescription{
 			Name:         field.Name.Value,
 			Kind:         kind,
-			Typ:          defaultCRDTForFieldKind[kind],
+			Typ:          cType,
 			Schema:       schema,
 			RelationName: relationName,
 			RelationType: relationType,
+			Index:        indexType,
 		}
 
 		fieldDescriptions = append(fieldDescriptions, fieldDescription)
@@ -245,6 +257,75 @@ func findDirective(field *ast.FieldDefinition, directiveName string) (*ast.Direc
 	return nil, false
 }
 
+// getCRDTType returns the CRDT type specified by the @crdt directive on the given field,
+// or the default CRDT type for the given field kind if the directive is not present.
+func getCRDTType(field *ast.FieldDefinition, kind client.FieldKind) (client.CType, error) {
+	directive, exists := findDirective(field, schemaTypes.CRDTLabel)
+	if !exists {
+		return defaultCRDTForFieldKind[kind], nil
+	}
+
+	for _, argument := range directive.Arguments {
+		if argument.Name.Value != schemaTypes.CRDTArgNameType {
+			continue
+		}
+
+		// The argument may be given as either an enum value or a string.
+		name, isString := argument.Value.GetValue().(string)
+		if !isString {
+			return 0, client.NewErrUnexpectedType[string]("CRDT type", argument.Value.GetValue())
+		}
+
+		// the other CRDT types, such as composite, can not be given to fields yet
+		cType, ok := schemaTypes.CRDTTypeFromName(name)
+		if !ok {
+			return 0, NewErrCRDTTypeNotSupported(field.Name.Value, name, schemaTypes.CRDTTypeNames())
+		}
+
+		if !cType.IsCompatibleWith(kind) {
+			return 0, NewErrCRDTKindMismatch(field.Name.Value, name, kind)
+		}
+
+		return cType, nil
+	}
+
+	return 0, NewErrCRDTTypeMissing(field.Name.Value)
+}
+
+// getIndexType returns the index type specified by the @index directive on the given field,
+// or no index if the directive is not present.
+func getIndexType(field *ast.FieldDefinition, kind client.FieldKind) (client.IndexType, error) {
+	directive, exists := findDirective(field, schemaTypes.IndexLabel)
+	if !exists {
+		return client.NONE_INDEX, nil
+	}
+
+	for _, argument := range directive.Arguments {
+		if argument.Name.Value != schemaTypes.IndexArgNameType {
+			continue
+		}
+
+		// The argument may be given as either an enum value or a string.
+		name, isString := argument.Value.GetValue().(string)
+		if !isString {
+			return 0, client.NewErrUnexpectedType[string]("index type", argument.Value.GetValue())
+		}
+
+		indexType, ok := schemaTypes.IndexTypeFromName(name)
+		if !ok {
+			return 0, NewErrIndexTypeNotFound(field.Name.Value, name)
+		}
+
+		if !indexType.IsCompatibleWith(kind) {
+			return 0, NewErrIndexKindMismatch(field.Name.Value, name, kind)
+		}
+
+		return indexType, nil
+	}
+
+	return 0, NewErrIndexTypeMissing(field.Name.Value)
+}
+
 // Gets the name of the relationship. Will return the provided name if one is specified,
 // otherwise will generate one
 func getRelationshipName(
@@ -93,6 +93,12 @@ An optional value that specifies the commit ID of the document to return.
  corresponds to an older version of a document the document will be returned
  at the state it was in at the time of that commit. If a matching commit is
  not found then an empty set will be returned.
+`
+	asOfArgDescription string = `
+An optional value that specifies the time at which to read the documents. Each
+ document is returned at the state of its latest commit made at or before that
+ time, documents created after that time are not returned. The related documents
+ are read at the same time. It cannot be used together with the 'cid' argument.
 `
 	singleFieldFilterArgDescription string = `
 An optional filter for this join, if the related record does
@@ -165,6 +171,17 @@ An optional set of dockey values that will limit the delete to documents with
 An optional filter for this delete that will limit the delete to documents
  matching the given criteria. If no matching documents are found, the operation
  will succeed, but no documents will be deleted.
+`
+	revertDocumentDescription string = `
+Restores a single document of this type to its state at the given version. The
+ history is not rewritten, a new version restoring the field values of the given
+ version is written on top of the current one.
+`
+	revertIDArgDescription string = `
+The dockey of the document to revert. Required.
+`
+	revertCidArgDescription string = `
+The CID of the composite commit of the document to restore the state of. Required.
 `
 	keyFieldDescription string = `
 The immutable primary key (dockey) value for this document.
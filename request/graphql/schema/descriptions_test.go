@@ -578,6 +578,78 @@ func TestSingleSimpleType(t *testing.T) {
 	}
 }
 
+func TestSingleSimpleTypeWithCRDTDirective(t *testing.T) {
+	cases := []descriptionTestCase{
+		{
+			description: "Single simple type with crdt directive",
+			sdl: `
+			type User {
+				name: String @crdt(type: lww)
+				age: Int
+			}
+			`,
+			targetDescs: []client.CollectionDescription{
+				{
+					Name: "User",
+					Schema: client.SchemaDescription{
+						Name: "User",
+						Fields: []client.FieldDescription{
+							{
+								Name: "_key",
+								Kind: client.FieldKind_DocKey,
+								Typ:  client.NONE_CRDT,
+							},
+							{
+								Name: "age",
+								Kind: client.FieldKind_INT,
+								Typ:  client.LWW_REGISTER,
+							},
+							{
+								Name: "name",
+								Kind: client.FieldKind_STRING,
+								Typ:  client.LWW_REGISTER,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range cases {
+		runCreateDescriptionTest(t, test)
+	}
+}
+
+func TestSingleSimpleTypeWithInvalidCRDTDirectiveErrors(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := FromString(ctx, `
+	type User {
+		name: String @crdt(type: composite)
+	}
+	`)
+	assert.ErrorIs(t, err, NewErrCRDTTypeNotSupported("name", "composite", []string{"lww"}))
+
+	_, err = FromString(ctx, `
+	type User {
+		name: String @crdt
+	}
+	`)
+	assert.ErrorIs(t, err, NewErrCRDTTypeMissing("name"))
+
+	_, err = FromString(ctx, `
+	type Book {
+		author: Author @crdt(type: lww)
+	}
+
+	type Author {
+		books: [Book]
+	}
+	`)
+	assert.ErrorIs(t, err, NewErrCRDTKindMismatch("author", "lww", client.FieldKind_FOREIGN_OBJECT))
+}
+
 func runCreateDescriptionTest(t *testing.T, testcase descriptionTestCase) {
 	ctx := context.Background()
 
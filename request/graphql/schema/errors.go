@@ -10,7 +10,10 @@
 
 package schema
 
-import "github.com/sourcenetwork/defradb/errors"
+import (
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/errors"
+)
 
 const (
 	errDuplicateField             string = "duplicate field"
@@ -22,6 +25,12 @@ const (
 	errTypeNotFound               string = "no type found for given name"
 	errRelationNotFound           string = "no relation found"
 	errNonNullForTypeNotSupported string = "NonNull variants for type are not supported"
+	errCRDTTypeNotSupported       string = "CRDT type is not supported for fields"
+	errCRDTKindMismatch           string = "CRDT type is not valid for the field type"
+	errCRDTTypeMissing            string = "the @crdt directive requires a type argument"
+	errIndexTypeNotFound          string = "no index type found for given name"
+	errIndexKindMismatch          string = "index type is not valid for the field type"
+	errIndexTypeMissing           string = "the @index directive requires a type argument"
 )
 
 var (
@@ -34,6 +43,12 @@ var (
 	ErrTypeNotFound               = errors.New(errTypeNotFound)
 	ErrRelationNotFound           = errors.New(errRelationNotFound)
 	ErrNonNullForTypeNotSupported = errors.New(errNonNullForTypeNotSupported)
+	ErrCRDTTypeNotSupported       = errors.New(errCRDTTypeNotSupported)
+	ErrCRDTKindMismatch           = errors.New(errCRDTKindMismatch)
+	ErrCRDTTypeMissing            = errors.New(errCRDTTypeMissing)
+	ErrIndexTypeNotFound          = errors.New(errIndexTypeNotFound)
+	ErrIndexKindMismatch          = errors.New(errIndexKindMismatch)
+	ErrIndexTypeMissing           = errors.New(errIndexTypeMissing)
 	ErrRelationMutlipleTypes      = errors.New("relation type can only be either One or Many, not both")
 	ErrRelationMissingTypes       = errors.New("relation is missing its defined types and fields")
 	ErrRelationInvalidType        = errors.New("relation has an invalid type to be finalize")
@@ -110,3 +125,52 @@ func NewErrRelationNotFound(relationName string) error {
 		errors.NewKV("RelationName", relationName),
 	)
 }
+
+func NewErrCRDTTypeNotSupported(fieldName string, crdtName string, supported []string) error {
+	return errors.New(
+		errCRDTTypeNotSupported,
+		errors.NewKV("Field", fieldName),
+		errors.NewKV("CRDTType", crdtName),
+		errors.NewKV("Supported", supported),
+	)
+}
+
+func NewErrCRDTKindMismatch(fieldName string, crdtName string, kind client.FieldKind) error {
+	return errors.New(
+		errCRDTKindMismatch,
+		errors.NewKV("Field", fieldName),
+		errors.NewKV("CRDTType", crdtName),
+		errors.NewKV("Kind", kind),
+	)
+}
+
+func NewErrCRDTTypeMissing(fieldName string) error {
+	return errors.New(
+		errCRDTTypeMissing,
+		errors.NewKV("Field", fieldName),
+	)
+}
+
+func NewErrIndexTypeNotFound(fieldName string, indexName string) error {
+	return errors.New(
+		errIndexTypeNotFound,
+		errors.NewKV("Field", fieldName),
+		errors.NewKV("IndexType", indexName),
+	)
+}
+
+func NewErrIndexKindMismatch(fieldName string, indexName string, kind client.FieldKind) error {
+	return errors.New(
+		errIndexKindMismatch,
+		errors.NewKV("Field", fieldName),
+		errors.NewKV("IndexType", indexName),
+		errors.NewKV("Kind", kind),
+	)
+}
+
+func NewErrIndexTypeMissing(fieldName string) error {
+	return errors.New(
+		errIndexTypeMissing,
+		errors.NewKV("Field", fieldName),
+	)
+}
@@ -256,6 +256,11 @@ func (g *Generator) createExpandedFieldAggregate(
 ) error {
 	for _, aggregateTarget := range f.Args {
 		target := aggregateTarget.Name()
+		if target == request.PercentileArgName {
+			// The percentile is an argument of the aggregate, not a target
+			continue
+		}
+
 		var filterTypeName string
 		if target == request.GroupFieldName {
 			filterTypeName = obj.Name() + "FilterArg"
@@ -332,6 +337,10 @@ func (g *Generator) createExpandedFieldList(
 				gql.NewList(gql.NewNonNull(g.manager.schema.TypeMap()[typeName+"Fields"])),
 				schemaTypes.GroupByArgDescription,
 			),
+			request.DistinctClause: schemaTypes.NewArgConfig(
+				gql.NewList(gql.NewNonNull(g.manager.schema.TypeMap()[typeName+"Fields"])),
+				schemaTypes.DistinctArgDescription,
+			),
 			"order": schemaTypes.NewArgConfig(
 				g.manager.schema.TypeMap()[typeName+"OrderArg"],
 				schemaTypes.OrderArgDescription,
@@ -418,8 +427,9 @@ func (g *Generator) buildTypes(
 				}
 
 				fields[field.Name] = &gql.Field{
-					Name: field.Name,
-					Type: ttype,
+					Name:        field.Name,
+					Description: schemaTypes.CRDTTypeDescription(field.Typ),
+					Type:        ttype,
 				}
 			}
 
@@ -463,8 +473,25 @@ func (g *Generator) buildTypes(
 func (g *Generator) genAggregateFields(ctx context.Context) error {
 	topLevelCountInputs := map[string]*gql.InputObject{}
 	topLevelNumericAggInputs := map[string]*gql.InputObject{}
+	topLevelMinMaxInputs := map[string]*gql.InputObject{}
 
 	for _, t := range g.typeDefs {
+		// Types without comparable fields have no min/max selector, and can not be aggregated over
+		if hasComparableFields(t) {
+			minMaxArg := g.genMinMaxBaseArgInputs(t)
+			topLevelMinMaxInputs[t.Name()] = minMaxArg
+			// All base types need to be appended to the schema before calling genMinMaxFieldConfig
+			if err := g.appendIfNotExists(minMaxArg); err != nil {
+				return err
+			}
+		}
+
+		for _, obj := range g.genMinMaxInlineArraySelectorObject(t) {
+			if err := g.appendIfNotExists(obj); err != nil {
+				return err
+			}
+		}
+
 		numArg := g.genNumericAggregateBaseArgInputs(t)
 		topLevelNumericAggInputs[t.Name()] = numArg
 		// All base types need to be appended to the schema before calling genSumFieldConfig
@@ -515,6 +542,21 @@ func (g *Generator) genAggregateFields(ctx context.Context) error {
 			return err
 		}
 		t.AddFieldConfig(averageField.Name, &averageField)
+
+		statisticFields, err := g.genStatisticFieldConfigs(t)
+		if err != nil {
+			return err
+		}
+		for _, statisticField := range statisticFields {
+			statisticField := statisticField
+			t.AddFieldConfig(statisticField.Name, &statisticField)
+		}
+
+		minField := g.genMinMaxFieldConfig(t, request.MinFieldName, schemaTypes.MinFieldDescription)
+		t.AddFieldConfig(minField.Name, &minField)
+
+		maxField := g.genMinMaxFieldConfig(t, request.MaxFieldName, schemaTypes.MaxFieldDescription)
+		t.AddFieldConfig(maxField.Name, &maxField)
 	}
 
 	queryType := g.manager.schema.QueryType()
@@ -526,6 +568,10 @@ func (g *Generator) genAggregateFields(ctx context.Context) error {
 		queryType.AddFieldConfig(topLevelAgg.Name, topLevelAgg)
 	}
 
+	for _, topLevelAgg := range genTopLevelMinMaxAggregates(topLevelMinMaxInputs) {
+		queryType.AddFieldConfig(topLevelAgg.Name, topLevelAgg)
+	}
+
 	return nil
 }
 
@@ -564,7 +610,86 @@ func genTopLevelNumericAggregates(topLevelNumericAggInputs map[string]*gql.Input
 		topLevelAverageField.Args[name] = schemaTypes.NewArgConfig(inputObject, inputObject.Description())
 	}
 
-	return []*gql.Field{&topLevelSumField, &topLevelAverageField}
+	fields := []*gql.Field{&topLevelSumField, &topLevelAverageField}
+	for _, statisticField := range genStatisticFields(topLevelSumField.Args) {
+		statisticField := statisticField
+		fields = append(fields, &statisticField)
+	}
+
+	return fields
+}
+
+// genStatisticFields returns the median, percentile, standard deviation and variance
+// aggregate fields, each with a copy of the given aggregate target args.
+func genStatisticFields(targetArgs gql.FieldConfigArgument) []gql.Field {
+	fields := []gql.Field{
+		{
+			Name:        request.MedianFieldName,
+			Description: schemaTypes.MedianFieldDescription,
+		},
+		{
+			Name:        request.PercentileFieldName,
+			Description: schemaTypes.PercentileFieldDescription,
+		},
+		{
+			Name:        request.StdDevFieldName,
+			Description: schemaTypes.StdDevFieldDescription,
+		},
+		{
+			Name:        request.VarianceFieldName,
+			Description: schemaTypes.VarianceFieldDescription,
+		},
+	}
+
+	for i := range fields {
+		fields[i].Type = gql.Float
+		fields[i].Args = gql.FieldConfigArgument{}
+		for name, arg := range targetArgs {
+			fields[i].Args[name] = arg
+		}
+		if fields[i].Name == request.PercentileFieldName {
+			fields[i].Args[request.PercentileArgName] = schemaTypes.NewArgConfig(
+				gql.NewNonNull(gql.Float),
+				schemaTypes.PercentileArgDescription,
+			)
+		}
+	}
+
+	return fields
+}
+
+// genStatisticFieldConfigs returns the median, percentile, standard deviation and variance
+// aggregate fields of the given object, these take the same targets as the sum aggregate.
+func (g *Generator) genStatisticFieldConfigs(obj *gql.Object) ([]gql.Field, error) {
+	sumField, err := g.genSumFieldConfig(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return genStatisticFields(sumField.Args), nil
+}
+
+func genTopLevelMinMaxAggregates(topLevelMinMaxInputs map[string]*gql.InputObject) []*gql.Field {
+	topLevelMinField := gql.Field{
+		Name:        request.MinFieldName,
+		Description: schemaTypes.MinFieldDescription,
+		Type:        schemaTypes.ComparableValue,
+		Args:        gql.FieldConfigArgument{},
+	}
+
+	topLevelMaxField := gql.Field{
+		Name:        request.MaxFieldName,
+		Description: schemaTypes.MaxFieldDescription,
+		Type:        schemaTypes.ComparableValue,
+		Args:        gql.FieldConfigArgument{},
+	}
+
+	for name, inputObject := range topLevelMinMaxInputs {
+		topLevelMinField.Args[name] = schemaTypes.NewArgConfig(inputObject, inputObject.Description())
+		topLevelMaxField.Args[name] = schemaTypes.NewArgConfig(inputObject, inputObject.Description())
+	}
+
+	return []*gql.Field{&topLevelMinField, &topLevelMaxField}
 }
 
 func (g *Generator) genCountFieldConfig(obj *gql.Object) (gql.Field, error) {
@@ -684,6 +809,48 @@ func (g *Generator) genAverageFieldConfig(obj *gql.Object) (gql.Field, error) {
 	return field, nil
 }
 
+// genMinMaxFieldConfig generates the min or max aggregate field of the given object, with
+// the given name.
+func (g *Generator) genMinMaxFieldConfig(obj *gql.Object, name string, description string) gql.Field {
+	childTypesByFieldName := map[string]gql.Type{}
+
+	for _, field := range obj.Fields() {
+		// we can only take the min or max of list items
+		listType, isList := field.Type.(*gql.List)
+		if !isList {
+			continue
+		}
+
+		var inputObjectName string
+		if gql.IsLeafType(listType.OfType) {
+			inputObjectName = genMinMaxInlineArraySelectorName(obj.Name(), field.Name)
+		} else {
+			inputObjectName = genMinMaxObjectSelectorName(listType.OfType.Name())
+		}
+
+		subType, isSubTypeComparable := g.manager.schema.TypeMap()[inputObjectName]
+		// If the item is not in the type map, it must contain no comparable
+		//  fields (e.g. no Int/Float/DateTime/Strings)
+		if !isSubTypeComparable {
+			continue
+		}
+		childTypesByFieldName[field.Name] = subType
+	}
+
+	field := gql.Field{
+		Name:        name,
+		Description: description,
+		Type:        schemaTypes.ComparableValue,
+		Args:        gql.FieldConfigArgument{},
+	}
+
+	for name, inputObject := range childTypesByFieldName {
+		field.Args[name] = schemaTypes.NewArgConfig(inputObject, inputObject.Description())
+	}
+
+	return field
+}
+
 func (g *Generator) genNumericInlineArraySelectorObject(obj *gql.Object) []*gql.InputObject {
 	objects := []*gql.InputObject{}
 	for _, field := range obj.Fields() {
@@ -720,6 +887,50 @@ func (g *Generator) genNumericInlineArraySelectorObject(obj *gql.Object) []*gql.
 	return objects
 }
 
+// genMinMaxInlineArraySelectorObject generates the min and max aggregate input objects
+// of the inline arrays of comparable values of the given object.
+func (g *Generator) genMinMaxInlineArraySelectorObject(obj *gql.Object) []*gql.InputObject {
+	objects := []*gql.InputObject{}
+	for _, field := range obj.Fields() {
+		// we can only act on list items
+		listType, isList := field.Type.(*gql.List)
+		if !isList || !isComparableArray(listType) {
+			continue
+		}
+
+		// If it is an inline scalar array then we require an empty
+		//  object as an argument due to the lack of union input types
+		selectorObject := gql.NewInputObject(gql.InputObjectConfig{
+			Name: genMinMaxInlineArraySelectorName(obj.Name(), field.Name),
+			Fields: gql.InputObjectConfigFieldMap{
+				request.LimitClause: &gql.InputObjectFieldConfig{
+					Type:        gql.Int,
+					Description: schemaTypes.LimitArgDescription,
+				},
+				request.OffsetClause: &gql.InputObjectFieldConfig{
+					Type:        gql.Int,
+					Description: schemaTypes.OffsetArgDescription,
+				},
+				request.OrderClause: &gql.InputObjectFieldConfig{
+					Type:        g.manager.schema.TypeMap()["Ordering"],
+					Description: schemaTypes.OrderArgDescription,
+				},
+			},
+		})
+
+		objects = append(objects, selectorObject)
+	}
+	return objects
+}
+
+func genMinMaxObjectSelectorName(hostName string) string {
+	return fmt.Sprintf("%s__%s", hostName, "MinMaxSelector")
+}
+
+func genMinMaxInlineArraySelectorName(hostName string, fieldName string) string {
+	return fmt.Sprintf("%s__%s__%s", hostName, fieldName, "MinMaxSelector")
+}
+
 func genNumericObjectSelectorName(hostName string) string {
 	return fmt.Sprintf("%s__%s", hostName, "NumericSelector")
 }
@@ -740,6 +951,10 @@ func (g *Generator) genCountBaseArgInputs(obj *gql.Object) *gql.InputObject {
 				Type:        gql.Int,
 				Description: schemaTypes.OffsetArgDescription,
 			},
+			request.DistinctClause: &gql.InputObjectFieldConfig{
+				Type:        gql.NewList(gql.NewNonNull(g.manager.schema.TypeMap()[genTypeName(obj, "Fields")])),
+				Description: schemaTypes.DistinctArgDescription,
+			},
 		},
 	})
 
@@ -750,25 +965,40 @@ func (g *Generator) genCountInlineArrayInputs(obj *gql.Object) []*gql.InputObjec
 	objects := []*gql.InputObject{}
 	for _, field := range obj.Fields() {
 		// we can only act on list items
-		_, isList := field.Type.(*gql.List)
+		list, isList := field.Type.(*gql.List)
 		if !isList {
 			continue
 		}
 
+		fields := gql.InputObjectConfigFieldMap{
+			request.LimitClause: &gql.InputObjectFieldConfig{
+				Type:        gql.Int,
+				Description: schemaTypes.LimitArgDescription,
+			},
+			request.OffsetClause: &gql.InputObjectFieldConfig{
+				Type:        gql.Int,
+				Description: schemaTypes.OffsetArgDescription,
+			},
+		}
+
+		// Only leaf items may be compared as a whole, object items (such as `_version`)
+		// have no single value to be distinct on.
+		itemType := list.OfType
+		if notNull, isNotNull := itemType.(*gql.NonNull); isNotNull {
+			itemType = notNull.OfType
+		}
+		if _, isObject := itemType.(*gql.Object); !isObject {
+			fields[request.DistinctClause] = &gql.InputObjectFieldConfig{
+				Type:        gql.Boolean,
+				Description: schemaTypes.DistinctItemsArgDescription,
+			}
+		}
+
 		// If it is an inline scalar array then we require an empty
 		//  object as an argument due to the lack of union input types
 		selectorObject := gql.NewInputObject(gql.InputObjectConfig{
-			Name: genNumericInlineArrayCountName(obj.Name(), field.Name),
-			Fields: gql.InputObjectConfigFieldMap{
-				request.LimitClause: &gql.InputObjectFieldConfig{
-					Type:        gql.Int,
-					Description: schemaTypes.LimitArgDescription,
-				},
-				request.OffsetClause: &gql.InputObjectFieldConfig{
-					Type:        gql.Int,
-					Description: schemaTypes.OffsetArgDescription,
-				},
-			},
+			Name:   genNumericInlineArrayCountName(obj.Name(), field.Name),
+			Fields: fields,
 		})
 
 		objects = append(objects, selectorObject)
@@ -855,6 +1085,59 @@ func (g *Generator) genNumericAggregateBaseArgInputs(obj *gql.Object) *gql.Input
 	})
 }
 
+// Generates the base min and max aggregate input object-type for the given gql object,
+// declaring which fields are available for aggregation.
+func (g *Generator) genMinMaxBaseArgInputs(obj *gql.Object) *gql.InputObject {
+	var fieldThunk gql.InputObjectConfigFieldMapThunk = func() (gql.InputObjectConfigFieldMap, error) {
+		fieldsEnum, enumExists := g.manager.schema.TypeMap()[genTypeName(obj, "ComparableFieldsArg")]
+		if !enumExists {
+			fieldsEnumCfg := gql.EnumConfig{
+				Name:   genTypeName(obj, "ComparableFieldsArg"),
+				Values: gql.EnumValueConfigMap{},
+			}
+
+			for f, field := range obj.Fields() {
+				if _, ok := request.ReservedFields[f]; ok {
+					continue
+				}
+				if isComparableType(field.Type) {
+					fieldsEnumCfg.Values[field.Name] = &gql.EnumValueConfig{Value: field.Name}
+				}
+			}
+
+			fieldsEnum = gql.NewEnum(fieldsEnumCfg)
+
+			err := g.manager.schema.AppendType(fieldsEnum)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return gql.InputObjectConfigFieldMap{
+			"field": &gql.InputObjectFieldConfig{
+				Type: gql.NewNonNull(fieldsEnum),
+			},
+			request.LimitClause: &gql.InputObjectFieldConfig{
+				Type:        gql.Int,
+				Description: schemaTypes.LimitArgDescription,
+			},
+			request.OffsetClause: &gql.InputObjectFieldConfig{
+				Type:        gql.Int,
+				Description: schemaTypes.OffsetArgDescription,
+			},
+			request.OrderClause: &gql.InputObjectFieldConfig{
+				Type:        g.manager.schema.TypeMap()[genTypeName(obj, "OrderArg")],
+				Description: schemaTypes.OrderArgDescription,
+			},
+		}, nil
+	}
+
+	return gql.NewInputObject(gql.InputObjectConfig{
+		Name:   genMinMaxObjectSelectorName(obj.Name()),
+		Fields: fieldThunk,
+	})
+}
+
 func appendCommitChildGroupField() {
 	schemaTypes.CommitObject.Fields()[request.GroupFieldName] = &gql.FieldDefinition{
 		Name:        request.GroupFieldName,
@@ -875,6 +1158,7 @@ func (g *Generator) GenerateQueryInputForGQLType(
 	}
 	types := queryInputTypeConfig{}
 	types.filter = g.genTypeFilterArgInput(obj)
+	types.listFilter = g.genTypeListFilterArgInput(obj, types.filter)
 
 	// @todo: Don't add sub fields to filter/order for object list types
 	types.groupBy = g.genTypeFieldsEnum(obj)
@@ -918,7 +1202,11 @@ func (g *Generator) genTypeMutationFields(
 	if err != nil {
 		return nil, err
 	}
-	return []*gql.Field{create, update, delete}, nil
+	revert, err := g.genTypeMutationRevertField(obj)
+	if err != nil {
+		return nil, err
+	}
+	return []*gql.Field{create, update, delete, revert}, nil
 }
 
 func (g *Generator) genTypeMutationCreateField(obj *gql.Object) (*gql.Field, error) {
@@ -968,6 +1256,19 @@ func (g *Generator) genTypeMutationDeleteField(
 	return field, nil
 }
 
+func (g *Generator) genTypeMutationRevertField(obj *gql.Object) (*gql.Field, error) {
+	field := &gql.Field{
+		Name:        "revert_" + obj.Name(),
+		Description: revertDocumentDescription,
+		Type:        obj,
+		Args: gql.FieldConfigArgument{
+			"id":  schemaTypes.NewArgConfig(gql.NewNonNull(gql.ID), revertIDArgDescription),
+			"cid": schemaTypes.NewArgConfig(gql.NewNonNull(gql.String), revertCidArgDescription),
+		},
+	}
+	return field, nil
+}
+
 func (g *Generator) genTypeFieldsEnum(obj *gql.Object) *gql.Enum {
 	enumFieldsCfg := gql.EnumConfig{
 		Name:   genTypeName(obj, "Fields"),
@@ -1013,11 +1314,12 @@ func (g *Generator) genTypeFilterArgInput(obj *gql.Object) *gql.InputObject {
 				}
 				// scalars (leafs)
 				if gql.IsLeafType(field.Type) {
-					if _, isList := field.Type.(*gql.List); isList {
-						// Filtering by inline array value is currently not supported
-						continue
+					operatorBlockName := field.Type.Name() + "OperatorBlock"
+					if list, isList := field.Type.(*gql.List); isList {
+						// inline arrays are filtered with the array operators
+						operatorBlockName = schemaTypes.ListOperatorBlockName(list.OfType)
 					}
-					operatorType, isFilterable := g.manager.schema.TypeMap()[field.Type.Name()+"OperatorBlock"]
+					operatorType, isFilterable := g.manager.schema.TypeMap()[operatorBlockName]
 					if !isFilterable {
 						continue
 					}
@@ -1026,11 +1328,14 @@ func (g *Generator) genTypeFilterArgInput(obj *gql.Object) *gql.InputObject {
 					}
 				} else { // objects (relations)
 					fieldType := field.Type
+					filterTypeName := "FilterArg"
 					if l, isList := field.Type.(*gql.List); isList {
-						// We want the FilterArg for the object, not the list of objects.
+						// We want the ListFilterArg for the object, which also holds the
+						// `_some`, `_every` and `_none` relation filters, not the list of objects.
 						fieldType = l.OfType
+						filterTypeName = "ListFilterArg"
 					}
-					filterType, isFilterable := g.manager.schema.TypeMap()[genTypeName(fieldType, "FilterArg")]
+					filterType, isFilterable := g.manager.schema.TypeMap()[genTypeName(fieldType, filterTypeName)]
 					if !isFilterable {
 						filterType = &gql.InputObjectField{}
 					}
@@ -1040,6 +1345,24 @@ func (g *Generator) genTypeFilterArgInput(obj *gql.Object) *gql.InputObject {
 				}
 			}
 
+			// aggregates are only filterable if the object has something to aggregate
+			if countFilter := g.genTypeCountFilterArgInput(obj); countFilter != nil {
+				fields[request.CountFieldName] = &gql.InputObjectFieldConfig{
+					Description: schemaTypes.CountFilterDescription,
+					Type:        countFilter,
+				}
+			}
+			if numericFilter := g.genTypeNumericAggregateFilterArgInput(obj); numericFilter != nil {
+				fields[request.SumFieldName] = &gql.InputObjectFieldConfig{
+					Description: schemaTypes.SumFilterDescription,
+					Type:        numericFilter,
+				}
+				fields[request.AverageFieldName] = &gql.InputObjectFieldConfig{
+					Description: schemaTypes.AverageFilterDescription,
+					Type:        numericFilter,
+				}
+			}
+
 			return fields, nil
 		},
 	)
@@ -1050,6 +1373,159 @@ func (g *Generator) genTypeFilterArgInput(obj *gql.Object) *gql.InputObject {
 	return selfRefType
 }
 
+// genTypeCountFilterArgInput generates the input object used to filter on the count of the
+// child sets of the given object, adding it to the type map.
+//
+// Returns nil if the object has no child sets.
+func (g *Generator) genTypeCountFilterArgInput(obj *gql.Object) *gql.InputObject {
+	name := genTypeName(obj, "CountFilterArg")
+	if existing, exists := g.manager.schema.TypeMap()[name]; exists {
+		return existing.(*gql.InputObject)
+	}
+
+	fields := gql.InputObjectConfigFieldMap{}
+	for f, field := range obj.Fields() {
+		if _, ok := request.ReservedFields[f]; ok {
+			continue
+		}
+		if _, isList := field.Type.(*gql.List); isList {
+			fields[field.Name] = &gql.InputObjectFieldConfig{
+				Type: schemaTypes.NotNullIntOperatorBlock,
+			}
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+
+	countFilter := gql.NewInputObject(gql.InputObjectConfig{
+		Name:   name,
+		Fields: fields,
+	})
+	g.manager.schema.TypeMap()[name] = countFilter
+	return countFilter
+}
+
+// genTypeNumericAggregateFilterArgInput generates the input object used to filter on the sum
+// or average of the numeric inline arrays, and of the numeric fields of the child sets, of the
+// given object, adding it to the type map.
+//
+// Returns nil if the object has nothing to sum.
+func (g *Generator) genTypeNumericAggregateFilterArgInput(obj *gql.Object) *gql.InputObject {
+	name := genTypeName(obj, "NumericAggregateFilterArg")
+	if existing, exists := g.manager.schema.TypeMap()[name]; exists {
+		return existing.(*gql.InputObject)
+	}
+
+	fields := gql.InputObjectConfigFieldMap{}
+	for f, field := range obj.Fields() {
+		if _, ok := request.ReservedFields[f]; ok {
+			continue
+		}
+		list, isList := field.Type.(*gql.List)
+		if !isList {
+			continue
+		}
+		if isNumericArray(list) {
+			fields[field.Name] = &gql.InputObjectFieldConfig{
+				Type: schemaTypes.NotNullFloatOperatorBlock,
+			}
+			continue
+		}
+		if child, isObject := list.OfType.(*gql.Object); isObject {
+			if childFilter := g.genTypeNumericFieldsFilterArgInput(child); childFilter != nil {
+				fields[field.Name] = &gql.InputObjectFieldConfig{
+					Type: childFilter,
+				}
+			}
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+
+	numericFilter := gql.NewInputObject(gql.InputObjectConfig{
+		Name:   name,
+		Fields: fields,
+	})
+	g.manager.schema.TypeMap()[name] = numericFilter
+	return numericFilter
+}
+
+// genTypeNumericFieldsFilterArgInput generates the input object used to filter on the sum or
+// average of the numeric fields of the given child object, adding it to the type map.
+//
+// Returns nil if the object has no numeric fields.
+func (g *Generator) genTypeNumericFieldsFilterArgInput(obj *gql.Object) *gql.InputObject {
+	name := genTypeName(obj, "NumericFieldsFilterArg")
+	if existing, exists := g.manager.schema.TypeMap()[name]; exists {
+		return existing.(*gql.InputObject)
+	}
+
+	fields := gql.InputObjectConfigFieldMap{}
+	for f, field := range obj.Fields() {
+		if _, ok := request.ReservedFields[f]; ok {
+			continue
+		}
+		if field.Type == gql.Float || field.Type == gql.Int {
+			fields[field.Name] = &gql.InputObjectFieldConfig{
+				Type: schemaTypes.NotNullFloatOperatorBlock,
+			}
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+
+	numericFilter := gql.NewInputObject(gql.InputObjectConfig{
+		Name:   name,
+		Fields: fields,
+	})
+	g.manager.schema.TypeMap()[name] = numericFilter
+	return numericFilter
+}
+
+// input {Type.Name}ListFilterArg { ... }
+//
+// The filter of the many side of one-to-many relations, it holds the fields of the
+// {Type.Name}FilterArg, which match if any of the related documents match, and the
+// relation filters matching the related documents as a whole.
+func (g *Generator) genTypeListFilterArgInput(obj *gql.Object, filter *gql.InputObject) *gql.InputObject {
+	inputCfg := gql.InputObjectConfig{
+		Name: genTypeName(obj, "ListFilterArg"),
+	}
+	fieldThunk := (gql.InputObjectConfigFieldMapThunk)(
+		func() (gql.InputObjectConfigFieldMap, error) {
+			fields := gql.InputObjectConfigFieldMap{}
+
+			for f, field := range filter.Fields() {
+				fields[f] = &gql.InputObjectFieldConfig{
+					Description: field.Description(),
+					Type:        field.Type,
+				}
+			}
+
+			fields["_some"] = &gql.InputObjectFieldConfig{
+				Description: schemaTypes.SomeOperatorDescription,
+				Type:        filter,
+			}
+			fields["_every"] = &gql.InputObjectFieldConfig{
+				Description: schemaTypes.EveryOperatorDescription,
+				Type:        filter,
+			}
+			fields["_none"] = &gql.InputObjectFieldConfig{
+				Description: schemaTypes.NoneRelationOperatorDescription,
+				Type:        filter,
+			}
+
+			return fields, nil
+		},
+	)
+
+	inputCfg.Fields = fieldThunk
+	return gql.NewInputObject(inputCfg)
+}
+
 func (g *Generator) genLeafFilterArgInput(obj gql.Type) *gql.InputObject {
 	var selfRefType *gql.InputObject
 
@@ -1075,6 +1551,9 @@ func (g *Generator) genLeafFilterArgInput(obj gql.Type) *gql.InputObject {
 
 		fields["_and"] = compoundListType
 		fields["_or"] = compoundListType
+		fields["_not"] = &gql.InputObjectFieldConfig{
+			Type: selfRefType,
+		}
 
 		operatorBlockName := fmt.Sprintf("%s%s", filterTypeName, "OperatorBlock")
 		operatorType, hasOperatorType := g.manager.schema.TypeMap()[operatorBlockName]
@@ -1134,6 +1613,51 @@ func (g *Generator) genTypeOrderArgInput(obj *gql.Object) *gql.InputObject {
 				}
 			}
 
+			// aggregates are only orderable if the object has something to aggregate
+			isList := func(*gql.List) bool { return true }
+			if countOrder := g.genTypeAggregateOrderArgInput(obj, "CountOrderArg", isList, nil); countOrder != nil {
+				fields[request.CountFieldName] = &gql.InputObjectFieldConfig{
+					Description: schemaTypes.CountOrderDescription,
+					Type:        countOrder,
+				}
+			}
+			numericOrder := g.genTypeAggregateOrderArgInput(
+				obj,
+				"NumericAggregateOrderArg",
+				isNumericArray,
+				func(child *gql.Object) *gql.InputObject {
+					return g.genTypeAggregateFieldsOrderArgInput(child, "NumericFieldsOrderArg", isNumericType)
+				},
+			)
+			if numericOrder != nil {
+				fields[request.SumFieldName] = &gql.InputObjectFieldConfig{
+					Description: schemaTypes.SumOrderDescription,
+					Type:        numericOrder,
+				}
+				fields[request.AverageFieldName] = &gql.InputObjectFieldConfig{
+					Description: schemaTypes.AverageOrderDescription,
+					Type:        numericOrder,
+				}
+			}
+			minMaxOrder := g.genTypeAggregateOrderArgInput(
+				obj,
+				"MinMaxAggregateOrderArg",
+				isComparableArray,
+				func(child *gql.Object) *gql.InputObject {
+					return g.genTypeAggregateFieldsOrderArgInput(child, "ComparableFieldsOrderArg", isComparableType)
+				},
+			)
+			if minMaxOrder != nil {
+				fields[request.MinFieldName] = &gql.InputObjectFieldConfig{
+					Description: schemaTypes.MinOrderDescription,
+					Type:        minMaxOrder,
+				}
+				fields[request.MaxFieldName] = &gql.InputObjectFieldConfig{
+					Description: schemaTypes.MaxOrderDescription,
+					Type:        minMaxOrder,
+				}
+			}
+
 			return fields, nil
 		},
 	)
@@ -1142,10 +1666,101 @@ func (g *Generator) genTypeOrderArgInput(obj *gql.Object) *gql.InputObject {
 	return gql.NewInputObject(inputCfg)
 }
 
+// genTypeAggregateOrderArgInput generates the input object used to order by an aggregate of the
+// child sets of the given object, adding it to the type map.
+//
+// Inline arrays accepted by isInlineTarget may be ordered by directly, and the child objects by
+// the fields of the input object returned by genChildOrder, if any.
+//
+// Returns nil if the object has nothing to aggregate.
+func (g *Generator) genTypeAggregateOrderArgInput(
+	obj *gql.Object,
+	suffix string,
+	isInlineTarget func(*gql.List) bool,
+	genChildOrder func(*gql.Object) *gql.InputObject,
+) *gql.InputObject {
+	name := genTypeName(obj, suffix)
+	if existing, exists := g.manager.schema.TypeMap()[name]; exists {
+		return existing.(*gql.InputObject)
+	}
+
+	fields := gql.InputObjectConfigFieldMap{}
+	for f, field := range obj.Fields() {
+		if _, ok := request.ReservedFields[f]; ok {
+			continue
+		}
+		list, isList := field.Type.(*gql.List)
+		if !isList {
+			continue
+		}
+		if child, isObject := list.OfType.(*gql.Object); isObject && genChildOrder != nil {
+			if childOrder := genChildOrder(child); childOrder != nil {
+				fields[field.Name] = &gql.InputObjectFieldConfig{
+					Type: childOrder,
+				}
+			}
+			continue
+		}
+		if isInlineTarget(list) {
+			fields[field.Name] = &gql.InputObjectFieldConfig{
+				Type: g.manager.schema.TypeMap()["Ordering"],
+			}
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+
+	aggregateOrder := gql.NewInputObject(gql.InputObjectConfig{
+		Name:   name,
+		Fields: fields,
+	})
+	g.manager.schema.TypeMap()[name] = aggregateOrder
+	return aggregateOrder
+}
+
+// genTypeAggregateFieldsOrderArgInput generates the input object used to order by an aggregate
+// of the fields of the given child object accepted by isTarget, adding it to the type map.
+//
+// Returns nil if the object has no such fields.
+func (g *Generator) genTypeAggregateFieldsOrderArgInput(
+	obj *gql.Object,
+	suffix string,
+	isTarget func(gql.Type) bool,
+) *gql.InputObject {
+	name := genTypeName(obj, suffix)
+	if existing, exists := g.manager.schema.TypeMap()[name]; exists {
+		return existing.(*gql.InputObject)
+	}
+
+	fields := gql.InputObjectConfigFieldMap{}
+	for f, field := range obj.Fields() {
+		if _, ok := request.ReservedFields[f]; ok {
+			continue
+		}
+		if isTarget(field.Type) {
+			fields[field.Name] = &gql.InputObjectFieldConfig{
+				Type: g.manager.schema.TypeMap()["Ordering"],
+			}
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+
+	fieldsOrder := gql.NewInputObject(gql.InputObjectConfig{
+		Name:   name,
+		Fields: fields,
+	})
+	g.manager.schema.TypeMap()[name] = fieldsOrder
+	return fieldsOrder
+}
+
 type queryInputTypeConfig struct {
-	filter  *gql.InputObject
-	groupBy *gql.Enum
-	order   *gql.InputObject
+	filter     *gql.InputObject
+	listFilter *gql.InputObject
+	groupBy    *gql.Enum
+	order      *gql.InputObject
 }
 
 func (g *Generator) genTypeQueryableFieldList(
@@ -1157,6 +1772,7 @@ func (g *Generator) genTypeQueryableFieldList(
 
 	// add the generated types to the type map
 	g.manager.schema.TypeMap()[config.filter.Name()] = config.filter
+	g.manager.schema.TypeMap()[config.listFilter.Name()] = config.listFilter
 	g.manager.schema.TypeMap()[config.groupBy.Name()] = config.groupBy
 	g.manager.schema.TypeMap()[config.order.Name()] = config.order
 
@@ -1168,15 +1784,22 @@ func (g *Generator) genTypeQueryableFieldList(
 			"dockey":  schemaTypes.NewArgConfig(gql.String, dockeyArgDescription),
 			"dockeys": schemaTypes.NewArgConfig(gql.NewList(gql.NewNonNull(gql.String)), dockeysArgDescription),
 			"cid":     schemaTypes.NewArgConfig(gql.String, cidArgDescription),
+			"asOf":    schemaTypes.NewArgConfig(gql.DateTime, asOfArgDescription),
 			"filter":  schemaTypes.NewArgConfig(config.filter, selectFilterArgDescription),
 			"groupBy": schemaTypes.NewArgConfig(
 				gql.NewList(gql.NewNonNull(config.groupBy)),
 				schemaTypes.GroupByArgDescription,
 			),
+			request.DistinctClause: schemaTypes.NewArgConfig(
+				gql.NewList(gql.NewNonNull(config.groupBy)),
+				schemaTypes.DistinctArgDescription,
+			),
 			"order":              schemaTypes.NewArgConfig(config.order, schemaTypes.OrderArgDescription),
 			request.ShowDeleted:  schemaTypes.NewArgConfig(gql.Boolean, showDeletedArgDescription),
 			request.LimitClause:  schemaTypes.NewArgConfig(gql.Int, schemaTypes.LimitArgDescription),
 			request.OffsetClause: schemaTypes.NewArgConfig(gql.Int, schemaTypes.OffsetArgDescription),
+			request.AfterClause:  schemaTypes.NewArgConfig(gql.String, schemaTypes.AfterArgDescription),
+			request.BeforeClause: schemaTypes.NewArgConfig(gql.String, schemaTypes.BeforeArgDescription),
 		},
 	}
 
@@ -1214,6 +1837,38 @@ func isNumericArray(list *gql.List) bool {
 		list.OfType == gql.Float
 }
 
+// isNumericType returns true if the given type can be summed and averaged.
+func isNumericType(t gql.Type) bool {
+	return t == gql.Int || t == gql.Float
+}
+
+// isComparableType returns true if the min and max of values of the given type can be taken.
+func isComparableType(t gql.Type) bool {
+	if notNull, isNotNull := t.(*gql.NonNull); isNotNull {
+		t = notNull.OfType
+	}
+	return t == gql.Int || t == gql.Float || t == gql.DateTime || t == gql.String
+}
+
+// hasComparableFields returns true if the given object has any non-reserved fields
+// whose min and max can be taken.
+func hasComparableFields(obj *gql.Object) bool {
+	for f, field := range obj.Fields() {
+		if _, ok := request.ReservedFields[f]; ok {
+			continue
+		}
+		if isComparableType(field.Type) {
+			return true
+		}
+	}
+	return false
+}
+
+// isComparableArray returns true if the min and max of the items of the given list can be taken.
+func isComparableArray(list *gql.List) bool {
+	return isComparableType(list.OfType)
+}
+
 /* Example
 
 typeDefs := ` ... `
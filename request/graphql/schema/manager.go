@@ -92,6 +92,7 @@ func defaultQueryType() *gql.Object {
 			// database API queries
 			schemaTypes.QueryCommits.Name:       schemaTypes.QueryCommits,
 			schemaTypes.QueryLatestCommits.Name: schemaTypes.QueryLatestCommits,
+			schemaTypes.QueryDiff.Name:          schemaTypes.QueryDiff,
 		},
 	})
 }
@@ -112,6 +113,8 @@ func defaultMutationType() *gql.Object {
 func defaultDirectivesType() []*gql.Directive {
 	return []*gql.Directive{
 		schemaTypes.ExplainDirective,
+		schemaTypes.CRDTDirective,
+		schemaTypes.IndexDirective,
 	}
 }
 
@@ -144,6 +147,9 @@ func defaultTypes() []gql.Type {
 		// Sort/Order enum
 		schemaTypes.OrderingEnum,
 
+		// Min/Max value scalar
+		schemaTypes.ComparableValue,
+
 		// Filter scalar blocks
 		schemaTypes.BooleanOperatorBlock,
 		schemaTypes.NotNullBooleanOperatorBlock,
@@ -155,11 +161,22 @@ func defaultTypes() []gql.Type {
 		schemaTypes.NotNullIntOperatorBlock,
 		schemaTypes.StringOperatorBlock,
 		schemaTypes.NotNullstringOperatorBlock,
+		schemaTypes.BooleanListOperatorBlock,
+		schemaTypes.NotNullBooleanListOperatorBlock,
+		schemaTypes.FloatListOperatorBlock,
+		schemaTypes.NotNullFloatListOperatorBlock,
+		schemaTypes.IntListOperatorBlock,
+		schemaTypes.NotNullIntListOperatorBlock,
+		schemaTypes.StringListOperatorBlock,
+		schemaTypes.NotNullStringListOperatorBlock,
 
 		schemaTypes.CommitsOrderArg,
 		schemaTypes.CommitLinkObject,
 		schemaTypes.CommitObject,
+		schemaTypes.ConflictObject,
+		schemaTypes.DiffObject,
 
 		schemaTypes.ExplainEnum,
+		schemaTypes.CRDTEnum,
 	}
 }
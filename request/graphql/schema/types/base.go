@@ -11,6 +11,8 @@
 package types
 
 import (
+	"fmt"
+
 	gql "github.com/graphql-go/graphql"
 )
 
@@ -291,6 +293,26 @@ var StringOperatorBlock = gql.NewInputObject(gql.InputObjectConfig{
 			Description: nlikeStringOperatorDescription,
 			Type:        gql.String,
 		},
+		"_ilike": &gql.InputObjectFieldConfig{
+			Description: ilikeStringOperatorDescription,
+			Type:        gql.String,
+		},
+		"_nilike": &gql.InputObjectFieldConfig{
+			Description: nilikeStringOperatorDescription,
+			Type:        gql.String,
+		},
+		"_regex": &gql.InputObjectFieldConfig{
+			Description: regexStringOperatorDescription,
+			Type:        gql.String,
+		},
+		"_nregex": &gql.InputObjectFieldConfig{
+			Description: nregexStringOperatorDescription,
+			Type:        gql.String,
+		},
+		"_search": &gql.InputObjectFieldConfig{
+			Description: searchStringOperatorDescription,
+			Type:        gql.String,
+		},
 	},
 })
 
@@ -323,6 +345,26 @@ var NotNullstringOperatorBlock = gql.NewInputObject(gql.InputObjectConfig{
 			Description: nlikeStringOperatorDescription,
 			Type:        gql.String,
 		},
+		"_ilike": &gql.InputObjectFieldConfig{
+			Description: ilikeStringOperatorDescription,
+			Type:        gql.String,
+		},
+		"_nilike": &gql.InputObjectFieldConfig{
+			Description: nilikeStringOperatorDescription,
+			Type:        gql.String,
+		},
+		"_regex": &gql.InputObjectFieldConfig{
+			Description: regexStringOperatorDescription,
+			Type:        gql.String,
+		},
+		"_nregex": &gql.InputObjectFieldConfig{
+			Description: nregexStringOperatorDescription,
+			Type:        gql.String,
+		},
+		"_search": &gql.InputObjectFieldConfig{
+			Description: searchStringOperatorDescription,
+			Type:        gql.String,
+		},
 	},
 })
 
@@ -349,3 +391,67 @@ var IdOperatorBlock = gql.NewInputObject(gql.InputObjectConfig{
 		},
 	},
 })
+
+// BooleanListOperatorBlock filter block for [Boolean] types.
+var BooleanListOperatorBlock = newListOperatorBlock(BooleanOperatorBlock, gql.Boolean)
+
+// NotNullBooleanListOperatorBlock filter block for [Boolean!] types.
+var NotNullBooleanListOperatorBlock = newListOperatorBlock(NotNullBooleanOperatorBlock, gql.NewNonNull(gql.Boolean))
+
+// FloatListOperatorBlock filter block for [Float] types.
+var FloatListOperatorBlock = newListOperatorBlock(FloatOperatorBlock, gql.Float)
+
+// NotNullFloatListOperatorBlock filter block for [Float!] types.
+var NotNullFloatListOperatorBlock = newListOperatorBlock(NotNullFloatOperatorBlock, gql.NewNonNull(gql.Float))
+
+// IntListOperatorBlock filter block for [Int] types.
+var IntListOperatorBlock = newListOperatorBlock(IntOperatorBlock, gql.Int)
+
+// NotNullIntListOperatorBlock filter block for [Int!] types.
+var NotNullIntListOperatorBlock = newListOperatorBlock(NotNullIntOperatorBlock, gql.NewNonNull(gql.Int))
+
+// StringListOperatorBlock filter block for [String] types.
+var StringListOperatorBlock = newListOperatorBlock(StringOperatorBlock, gql.String)
+
+// NotNullStringListOperatorBlock filter block for [String!] types.
+var NotNullStringListOperatorBlock = newListOperatorBlock(NotNullstringOperatorBlock, gql.NewNonNull(gql.String))
+
+// ListOperatorBlockName returns the name of the filter block for inline arrays of
+// the given item type, e.g. `NotNullStringListOperatorBlock` for [String!].
+func ListOperatorBlockName(itemType gql.Type) string {
+	if notNull, isNotNull := itemType.(*gql.NonNull); isNotNull {
+		return fmt.Sprintf("NotNull%sListOperatorBlock", notNull.OfType.Name())
+	}
+	return fmt.Sprintf("%sListOperatorBlock", itemType.Name())
+}
+
+// newListOperatorBlock returns the filter block for inline arrays of the given item
+// type, the conditions on the items are given with the item operator block.
+func newListOperatorBlock(itemOperatorBlock *gql.InputObject, itemType gql.Type) *gql.InputObject {
+	return gql.NewInputObject(gql.InputObjectConfig{
+		Name:        ListOperatorBlockName(itemType),
+		Description: fmt.Sprintf(listOperatorBlockDescription, gql.NewList(itemType)),
+		Fields: gql.InputObjectConfigFieldMap{
+			"_any": &gql.InputObjectFieldConfig{
+				Description: anyOperatorDescription,
+				Type:        itemOperatorBlock,
+			},
+			"_all": &gql.InputObjectFieldConfig{
+				Description: allOperatorDescription,
+				Type:        itemOperatorBlock,
+			},
+			"_none": &gql.InputObjectFieldConfig{
+				Description: noneOperatorDescription,
+				Type:        itemOperatorBlock,
+			},
+			"_contains": &gql.InputObjectFieldConfig{
+				Description: containsOperatorDescription,
+				Type:        gql.NewList(itemType),
+			},
+			"_overlaps": &gql.InputObjectFieldConfig{
+				Description: overlapsOperatorDescription,
+				Type:        gql.NewList(itemType),
+			},
+		},
+	})
+}
@@ -37,6 +37,9 @@ var (
 	// 	CollectionID: Int
 	// 	SchemaVersionID: String
 	// 	Delta: String
+	// 	Signer: String
+	// 	Author: String
+	// 	Time: DateTime
 	// 	Previous: [Commit]
 	//  Links: [Commit]
 	// }
@@ -79,6 +82,18 @@ var (
 				Description: commitDeltaFieldDescription,
 				Type:        gql.String,
 			},
+			"signer": &gql.Field{
+				Description: commitSignerFieldDescription,
+				Type:        gql.String,
+			},
+			"author": &gql.Field{
+				Description: commitAuthorFieldDescription,
+				Type:        gql.String,
+			},
+			"time": &gql.Field{
+				Description: commitTimeFieldDescription,
+				Type:        gql.DateTime,
+			},
 			"links": &gql.Field{
 				Description: commitLinksDescription,
 				Type:        gql.NewList(CommitLinkObject),
@@ -137,6 +152,8 @@ var (
 		},
 	)
 
+	CommitsFilterArg = newCommitsFilterArg()
+
 	commitFields = gql.NewEnum(
 		gql.EnumConfig{
 			Name:        "commitFields",
@@ -179,6 +196,7 @@ var (
 			request.FieldIDName: NewArgConfig(gql.String, commitFieldIDArgDescription),
 			"order":             NewArgConfig(CommitsOrderArg, OrderArgDescription),
 			"cid":               NewArgConfig(gql.ID, commitCIDArgDescription),
+			"filter":            NewArgConfig(CommitsFilterArg, commitsFilterArgDescription),
 			"groupBy": NewArgConfig(
 				gql.NewList(
 					gql.NewNonNull(
@@ -198,8 +216,68 @@ var (
 		Description: latestCommitsQueryDescription,
 		Type:        gql.NewList(CommitObject),
 		Args: gql.FieldConfigArgument{
-			"dockey":            NewArgConfig(gql.NewNonNull(gql.ID), commitDockeyArgDescription),
+			"dockey":            NewArgConfig(gql.ID, commitDockeyArgDescription),
 			request.FieldIDName: NewArgConfig(gql.String, commitFieldIDArgDescription),
+			"filter":            NewArgConfig(CommitsFilterArg, commitsFilterArgDescription),
 		},
 	}
 )
+
+// newCommitsFilterArg returns the filter input object of the commit queries.
+//
+// The filter refers to itself in its compound operators, so its fields have to
+// be defined by a thunk.
+func newCommitsFilterArg() *gql.InputObject {
+	var filterArg *gql.InputObject
+	filterArg = gql.NewInputObject(
+		gql.InputObjectConfig{
+			Name:        "commitsFilterArg",
+			Description: commitsFilterArgDescription,
+			Fields: (gql.InputObjectConfigFieldMapThunk)(func() (gql.InputObjectConfigFieldMap, error) {
+				return gql.InputObjectConfigFieldMap{
+					"_and": &gql.InputObjectFieldConfig{
+						Description: AndOperatorDescription,
+						Type:        gql.NewList(filterArg),
+					},
+					"_or": &gql.InputObjectFieldConfig{
+						Description: OrOperatorDescription,
+						Type:        gql.NewList(filterArg),
+					},
+					"_not": &gql.InputObjectFieldConfig{
+						Description: NotOperatorDescription,
+						Type:        filterArg,
+					},
+					request.HeightFieldName: &gql.InputObjectFieldConfig{
+						Description: commitHeightFieldDescription,
+						Type:        IntOperatorBlock,
+					},
+					request.FieldNameFieldName: &gql.InputObjectFieldConfig{
+						Description: commitFieldNameFieldDescription,
+						Type:        StringOperatorBlock,
+					},
+					request.CollectionIDFieldName: &gql.InputObjectFieldConfig{
+						Description: commitCollectionIDFieldDescription,
+						Type:        IntOperatorBlock,
+					},
+					request.SchemaVersionIDFieldName: &gql.InputObjectFieldConfig{
+						Description: commitSchemaVersionIDFieldDescription,
+						Type:        StringOperatorBlock,
+					},
+					request.SignerFieldName: &gql.InputObjectFieldConfig{
+						Description: commitSignerFieldDescription,
+						Type:        StringOperatorBlock,
+					},
+					request.AuthorFieldName: &gql.InputObjectFieldConfig{
+						Description: commitAuthorFieldDescription,
+						Type:        StringOperatorBlock,
+					},
+					request.TimeFieldName: &gql.InputObjectFieldConfig{
+						Description: commitTimeFieldDescription,
+						Type:        DateTimeOperatorBlock,
+					},
+				}, nil
+			}),
+		},
+	)
+	return filterArg
+}
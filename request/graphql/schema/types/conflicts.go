@@ -0,0 +1,59 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package types
+
+import (
+	gql "github.com/graphql-go/graphql"
+
+	"github.com/sourcenetwork/defradb/client/request"
+)
+
+// ConflictObject represents a remote update that has overwritten a concurrent
+// local update of a document field.
+//
+//	type Conflict {
+//		dockey: String
+//		fieldName: String
+//		losingCid: String
+//		winningCid: String
+//		losingValue: String
+//		winningValue: String
+//	}
+var ConflictObject = gql.NewObject(gql.ObjectConfig{
+	Name:        request.ConflictTypeName,
+	Description: conflictDescription,
+	Fields: gql.Fields{
+		request.DockeyFieldName: &gql.Field{
+			Description: conflictDockeyFieldDescription,
+			Type:        gql.String,
+		},
+		request.FieldNameFieldName: &gql.Field{
+			Description: conflictFieldNameFieldDescription,
+			Type:        gql.String,
+		},
+		request.LosingCidFieldName: &gql.Field{
+			Description: conflictLosingCidFieldDescription,
+			Type:        gql.String,
+		},
+		request.WinningCidFieldName: &gql.Field{
+			Description: conflictWinningCidFieldDescription,
+			Type:        gql.String,
+		},
+		request.LosingValueFieldName: &gql.Field{
+			Description: conflictLosingValueFieldDescription,
+			Type:        gql.String,
+		},
+		request.WinningValueFieldName: &gql.Field{
+			Description: conflictWinningValueFieldDescription,
+			Type:        gql.String,
+		},
+	},
+})
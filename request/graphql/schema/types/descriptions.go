@@ -22,6 +22,14 @@ An optional set of fields for which to group the contents of this field by.
  the '_group' selector within the immediate child selector. If an empty set
  is provided, the restrictions mentioned still apply, although all results
  will appear within the same group.
+`
+	DistinctArgDescription string = `
+An optional set of fields by which to de-duplicate the results. Only the first
+ of each set of results sharing the same values for all of the given fields
+ will be returned.
+`
+	DistinctItemsArgDescription string = `
+An optional flag, if true only distinct items will be aggregated.
 `
 	LimitArgDescription string = `
 An optional value that caps the number of results to the number provided.
@@ -31,6 +39,17 @@ An optional value that caps the number of results to the number provided.
 An optional value that skips the given number of results that would have
  otherwise been returned.  Commonly used alongside the 'limit' argument,
  this argument will still work on its own.
+`
+	AfterArgDescription string = `
+An optional cursor, as returned in the page info of a previous request, that
+ restricts the results to those after the document it was taken from. Commonly
+ used alongside the 'limit' argument to fetch the next page of results.
+`
+	BeforeArgDescription string = `
+An optional cursor, as returned in the page info of a previous request, that
+ restricts the results to those before the document it was taken from. If used
+ alongside the 'limit' argument, the results immediately preceding the cursor
+ are returned.
 `
 	commitDescription string = `
 Commit represents an individual commit to a MerkleCRDT, every mutation to a
@@ -52,6 +71,11 @@ An optional field ID parameter for this commit query. Only commits for a fields
 	commitCIDArgDescription string = `
 An optional value that specifies the commit ID of the commits to return. If a
  matching commit is not found then an empty set will be returned.
+`
+	commitsFilterArgDescription string = `
+An optional filter for this commits query, only commits matching the given criteria
+ will be returned. If no 'dockey' argument is provided the commits of all documents
+ are filtered, for example '{collectionID: {_eq: 1}}' lists the commits of a collection.
 `
 	commitDepthArgDescription string = `
 An optional value that specifies the maximum depth to which the commit DAG graph
@@ -91,6 +115,18 @@ The id of the field that this commit was committed against. If this is a composi
 `
 	commitDeltaFieldDescription string = `
 The CBOR encoded representation of the value that is saved as part of this commit.
+`
+	commitSignerFieldDescription string = `
+The peer ID of the node that signed this commit, derived from the public key stored in
+ the commit. Null if the commit is not signed.
+`
+	commitAuthorFieldDescription string = `
+The identity of the user that made the document level change this commit is part of, as
+ provided with the request. Null if no identity was provided, and for field commits.
+`
+	commitTimeFieldDescription string = `
+The time at which this commit was created, as recorded by the node that created it.
+ Null if the time of the commit has not been recorded.
 `
 	commitLinkNameFieldDescription string = `
 The Name of the field that this linked commit mutated.
@@ -110,6 +146,59 @@ Returns a set of head commits matching any provided criteria. If no arguments ar
  provided all head commits in the system will be returned. If no 'field' argument
  is provided only composite commits will be returned. This is equivalent to
  a 'commits' query with Depth: 1, and a differing 'field' default value.
+`
+	conflictDescription string = `
+Conflict represents a remote update of a document field that has won over a concurrent
+ local update of the same field when merged. Conflicts are only available via the
+ 'conflicts' subscription.
+`
+	conflictDockeyFieldDescription string = `
+The dockey of the document that the conflict occurred on.
+`
+	conflictFieldNameFieldDescription string = `
+The name of the field that the conflict occurred on.
+`
+	conflictLosingCidFieldDescription string = `
+The CID of the local commit that has been overwritten by the remote update.
+`
+	conflictWinningCidFieldDescription string = `
+The CID of the remote commit that has won the merge.
+`
+	conflictLosingValueFieldDescription string = `
+The JSON encoded value of the field that has been overwritten.
+`
+	conflictWinningValueFieldDescription string = `
+The JSON encoded value of the field after the merge.
+`
+	diffDescription string = `
+FieldDiff represents the change of a single field between two versions of a document.
+`
+	diffFieldNameFieldDescription string = `
+The name of the changed field.
+`
+	diffKindFieldDescription string = `
+The kind of the change, one of 'added', 'removed' or 'changed'.
+`
+	diffOldValueFieldDescription string = `
+The JSON encoded value of the field in the 'from' version, null if it was added.
+`
+	diffNewValueFieldDescription string = `
+The JSON encoded value of the field in the 'to' version, null if it was removed.
+`
+	diffQueryDescription string = `
+Returns the field-level changes of a document between two versions. Only the fields
+ that differ between the two versions are returned.
+`
+	diffDockeyArgDescription string = `
+The dockey of the document to compare the versions of.
+`
+	diffFromArgDescription string = `
+The CID of the composite commit of the old version, or 'latest' for the current
+ state of the document.
+`
+	diffToArgDescription string = `
+The CID of the composite commit of the new version, or 'latest' for the current
+ state of the document. Defaults to 'latest'.
 `
 	CountFieldDescription string = `
 Returns the total number of items within the specified child sets. If multiple child
@@ -124,6 +213,44 @@ Returns the total sum of the specified field values within the specified child s
 Returns the average of the specified field values within the specified child sets. If
  multiple fields/sets are specified, the combined average of all items within each set
  (true average, not an average of averages) will be returned as a single value.
+`
+	MinFieldDescription string = `
+Returns the smallest of the specified field values within the specified child sets. If
+ multiple fields/sets are specified, the smallest of all of them will be returned as a
+ single value. Null values are ignored.
+`
+	MaxFieldDescription string = `
+Returns the largest of the specified field values within the specified child sets. If
+ multiple fields/sets are specified, the largest of all of them will be returned as a
+ single value. Null values are ignored.
+`
+	MedianFieldDescription string = `
+Returns the median of the specified field values within the specified child sets. If
+ multiple fields/sets are specified, the median of all items within each set will be
+ returned as a single value. Null values are ignored.
+`
+	PercentileFieldDescription string = `
+Returns the given percentile of the specified field values within the specified child
+ sets, interpolating linearly between the closest ranks. If multiple fields/sets are
+ specified, the percentile of all items within each set will be returned as a single
+ value. Null values are ignored.
+`
+	PercentileArgDescription string = `
+The percentile to return, between 0 and 100.
+`
+	StdDevFieldDescription string = `
+Returns the population standard deviation of the specified field values within the
+ specified child sets. If multiple fields/sets are specified, the standard deviation of
+ all items within each set will be returned as a single value. Null values are ignored.
+`
+	VarianceFieldDescription string = `
+Returns the population variance of the specified field values within the specified
+ child sets. If multiple fields/sets are specified, the variance of all items within
+ each set will be returned as a single value. Null values are ignored.
+`
+	comparableValueDescription string = `
+The value of a min or max aggregate, which has the type of the aggregated field values:
+ an integer, a float, a string or a date time.
 `
 	booleanOperatorBlockDescription string = `
 These are the set of filter operators available for use when filtering on Boolean
@@ -164,6 +291,10 @@ These are the set of filter operators available for use when filtering on String
 	idOperatorBlockDescription string = `
 These are the set of filter operators available for use when filtering on ID
  values.
+`
+	listOperatorBlockDescription string = `
+These are the set of filter operators available for use when filtering on %s
+ values.
 `
 	eqOperatorDescription string = `
 The equality operator - if the target matches the value the check will pass.
@@ -202,6 +333,93 @@ The like operator - if the target value contains the given sub-string the check
 The not-like operator - if the target value does not contain the given sub-string the check will
  pass. '%' characters may be used as wildcards, for example '_nlike: "%Ritchie"' would match on
  the string 'Quentin Tarantino'.
+`
+	ilikeStringOperatorDescription string = `
+The case-insensitive like operator - if the target value contains the given sub-string, ignoring
+ case, the check will pass. '%' characters may be used as wildcards, for example
+ '_ilike: "%ritchie"' would match on strings ending in 'Ritchie'.
+`
+	nilikeStringOperatorDescription string = `
+The case-insensitive not-like operator - if the target value does not contain the given sub-string,
+ ignoring case, the check will pass. '%' characters may be used as wildcards, for example
+ '_nilike: "%ritchie"' would match on the string 'Quentin Tarantino'.
+`
+	regexStringOperatorDescription string = `
+The regex operator - if the target value matches the given RE2 regular expression the check will
+ pass, for example '_regex: "^Q.*o$"' would match on the string 'Quentin Tarantino'.
+`
+	nregexStringOperatorDescription string = `
+The not-regex operator - if the target value does not match the given RE2 regular expression the
+ check will pass, for example '_nregex: "^Q.*o$"' would match on the string 'Dennis Ritchie'.
+`
+	searchStringOperatorDescription string = `
+The full-text search operator - if the target value contains any of the words of the given
+ text, once lower-cased and reduced to their stems, the check will pass, for example
+ '_search: "directing"' would match on the string 'Directed by Quentin Tarantino'. The field
+ must have a full-text index, and the results are ranked by their relevance to the given text.
+ The check can not be nested within another operator or a relation.
+`
+	anyOperatorDescription string = `
+The any operator - if any of the items of the target array pass the checks within it the check
+ will pass.
+`
+	allOperatorDescription string = `
+The all operator - if all of the items of the target array pass the checks within it the check
+ will pass. Empty and null arrays always pass this check.
+`
+	noneOperatorDescription string = `
+The none operator - if none of the items of the target array pass the checks within it the check
+ will pass. Empty and null arrays always pass this check.
+`
+	containsOperatorDescription string = `
+The contains operator - if the target array contains all of the given values the check will pass.
+`
+	overlapsOperatorDescription string = `
+The overlaps operator - if the target array contains any of the given values the check will pass.
+`
+	SomeOperatorDescription string = `
+The some operator - if any of the related documents pass the checks within it the check will
+ pass. This is the default for filters on the related documents.
+`
+	EveryOperatorDescription string = `
+The every operator - if all of the related documents pass the checks within it the check will
+ pass. Documents without related documents always pass this check.
+`
+	NoneRelationOperatorDescription string = `
+The none operator - if none of the related documents pass the checks within it the check will
+ pass. Documents without related documents always pass this check, '_none: {}' only passes
+ for them.
+`
+	CountFilterDescription string = `
+The count filter - the checks within it are applied to the number of items within the
+ specified child sets.
+`
+	SumFilterDescription string = `
+The sum filter - the checks within it are applied to the sum of the specified field values
+ within the specified child sets.
+`
+	AverageFilterDescription string = `
+The average filter - the checks within it are applied to the average of the specified field
+ values within the specified child sets.
+`
+	CountOrderDescription string = `
+The count order - orders the results by the number of items within the specified child set.
+`
+	SumOrderDescription string = `
+The sum order - orders the results by the sum of the specified field values within the
+ specified child set.
+`
+	AverageOrderDescription string = `
+The average order - orders the results by the average of the specified field values within
+ the specified child set.
+`
+	MinOrderDescription string = `
+The min order - orders the results by the smallest of the specified field values within the
+ specified child set.
+`
+	MaxOrderDescription string = `
+The max order - orders the results by the largest of the specified field values within the
+ specified child set.
 `
 	AndOperatorDescription string = `
 The and operator - all checks within this clause must pass in order for this check to pass.
@@ -210,7 +428,7 @@ The and operator - all checks within this clause must pass in order for this che
 The or operator - only one check within this clause must pass in order for this check to pass.
 `
 	NotOperatorDescription string = `
-The negative operator - this check will only pass if all checks within it fail.
+The negative operator - this check will only pass if the checks within it do not all pass.
 `
 	ascOrderDescription string = `
 Sort the results in ascending order, e.g. null,1,2,3,a,b,c.
@@ -227,5 +445,36 @@ Allows the explicit definition of relationship attributes instead of using the s
 `
 	relationDirectiveNameArgDescription string = `
 Explicitly define the name of the relationship instead of using the system generated defaults.
+`
+	crdtDirectiveDescription string = `
+Allows the explicit definition of the CRDT type used to store and merge the values of
+ a field instead of using the default for the field's type.  The CRDT type of a field
+ cannot be changed once the field has been created.
+`
+	crdtDirectiveTypeArgDescription string = `
+The CRDT type to use for this field, it must be valid for the type of the field.
+`
+	crdtEnumDescription string = `
+The CRDT types that may be selected for a field using the @crdt directive.
+`
+	indexDirectiveDescription string = `
+Declares an index on the values of a field, which is maintained as documents are
+ written. The index of a field cannot be changed once the field has been created.
+`
+	indexDirectiveTypeArgDescription string = `
+The type of index to maintain on this field, it must be valid for the type of the field.
+`
+	indexEnumDescription string = `
+The index types that may be declared on a field using the @index directive.
+`
+	fullTextIndexDescription string = `
+Full-text index - the values of the field are split into words, which are lower-cased
+ and reduced to their stems, allowing the field to be searched using the _search operator.
+ Only valid for String fields.
+`
+	lwwCRDTDescription string = `
+Last Writer Wins register - the value of the field is replaced on every update, concurrent
+ updates are resolved by the priority (height) of the update, and then deterministically by
+ comparing the values.
 `
 )
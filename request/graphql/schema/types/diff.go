@@ -0,0 +1,62 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package types
+
+import (
+	gql "github.com/graphql-go/graphql"
+
+	"github.com/sourcenetwork/defradb/client/request"
+)
+
+var (
+	// DiffObject represents the change of a single field between two versions
+	// of a document.
+	//
+	//	type FieldDiff {
+	//		fieldName: String
+	//		kind: String
+	//		oldValue: String
+	//		newValue: String
+	//	}
+	DiffObject = gql.NewObject(gql.ObjectConfig{
+		Name:        request.DiffTypeName,
+		Description: diffDescription,
+		Fields: gql.Fields{
+			request.FieldNameFieldName: &gql.Field{
+				Description: diffFieldNameFieldDescription,
+				Type:        gql.String,
+			},
+			request.KindFieldName: &gql.Field{
+				Description: diffKindFieldDescription,
+				Type:        gql.String,
+			},
+			request.OldValueFieldName: &gql.Field{
+				Description: diffOldValueFieldDescription,
+				Type:        gql.String,
+			},
+			request.NewValueFieldName: &gql.Field{
+				Description: diffNewValueFieldDescription,
+				Type:        gql.String,
+			},
+		},
+	})
+
+	QueryDiff = &gql.Field{
+		Name:        request.DiffName,
+		Description: diffQueryDescription,
+		Type:        gql.NewList(DiffObject),
+		Args: gql.FieldConfigArgument{
+			request.DocKey:      NewArgConfig(gql.NewNonNull(gql.ID), diffDockeyArgDescription),
+			request.FromArgName: NewArgConfig(gql.NewNonNull(gql.String), diffFromArgDescription),
+			request.ToArgName:   NewArgConfig(gql.String, diffToArgDescription),
+		},
+	}
+)
@@ -11,13 +11,25 @@
 package types
 
 import (
+	"sort"
+
 	gql "github.com/graphql-go/graphql"
+
+	"github.com/sourcenetwork/defradb/client"
 )
 
 const (
 	ExplainLabel  string = "explain"
 	PrimaryLabel  string = "primary"
 	RelationLabel string = "relation"
+	CRDTLabel     string = "crdt"
+	IndexLabel    string = "index"
+
+	CRDTArgNameType string = "type"
+	CRDTArgLWW      string = "lww"
+
+	IndexArgNameType string = "type"
+	IndexArgFullText string = "fulltext"
 
 	ExplainArgNameType string = "type"
 	ExplainArgSimple   string = "simple"
@@ -40,6 +52,16 @@ var (
 		},
 	})
 
+	// ComparableValue is the type of the values returned by the min and max aggregates,
+	// which may be of any of the comparable field types.
+	ComparableValue = gql.NewScalar(gql.ScalarConfig{
+		Name:        "ComparableValue",
+		Description: comparableValueDescription,
+		Serialize: func(value any) any {
+			return value
+		},
+	})
+
 	ExplainEnum = gql.NewEnum(gql.EnumConfig{
 		Name:        "ExplainType",
 		Description: "ExplainType is an enum selecting the type of explanation done by the @explain directive.",
@@ -100,8 +122,105 @@ var (
 			gql.DirectiveLocationFieldDefinition,
 		},
 	})
+
+	// CRDTEnum is an enum of the CRDT types that may be selected for a field
+	// using the @crdt directive.
+	CRDTEnum = gql.NewEnum(gql.EnumConfig{
+		Name:        "CRDTType",
+		Description: crdtEnumDescription,
+		Values: gql.EnumValueConfigMap{
+			CRDTArgLWW: &gql.EnumValueConfig{
+				Value:       client.LWW_REGISTER,
+				Description: lwwCRDTDescription,
+			},
+		},
+	})
+
+	// CRDTDirective @crdt is used to explicitly define the CRDT type
+	// used to store and merge the values of a field.
+	CRDTDirective = gql.NewDirective(gql.DirectiveConfig{
+		Name:        CRDTLabel,
+		Description: crdtDirectiveDescription,
+		Args: gql.FieldConfigArgument{
+			CRDTArgNameType: &gql.ArgumentConfig{
+				Description: crdtDirectiveTypeArgDescription,
+				Type:        CRDTEnum,
+			},
+		},
+		Locations: []string{
+			gql.DirectiveLocationFieldDefinition,
+		},
+	})
+
+	// IndexEnum is an enum of the index types that may be declared on a field
+	// using the @index directive.
+	IndexEnum = gql.NewEnum(gql.EnumConfig{
+		Name:        "IndexType",
+		Description: indexEnumDescription,
+		Values: gql.EnumValueConfigMap{
+			IndexArgFullText: &gql.EnumValueConfig{
+				Value:       client.FULLTEXT_INDEX,
+				Description: fullTextIndexDescription,
+			},
+		},
+	})
+
+	// IndexDirective @index is used to declare an index on the
+	// values of a field.
+	IndexDirective = gql.NewDirective(gql.DirectiveConfig{
+		Name:        IndexLabel,
+		Description: indexDirectiveDescription,
+		Args: gql.FieldConfigArgument{
+			IndexArgNameType: &gql.ArgumentConfig{
+				Description: indexDirectiveTypeArgDescription,
+				Type:        IndexEnum,
+			},
+		},
+		Locations: []string{
+			gql.DirectiveLocationFieldDefinition,
+		},
+	})
 )
 
+// CRDTTypeDescription returns the description of the given CRDT type, as
+// declared on [CRDTEnum].
+//
+// An empty string will be returned if the CRDT type is not selectable via
+// the @crdt directive.
+func CRDTTypeDescription(crdtType client.CType) string {
+	for _, value := range CRDTEnum.Values() {
+		if value.Value == crdtType {
+			return value.Description
+		}
+	}
+	return ""
+}
+
+// CRDTTypeFromName returns the CRDT type matching the given @crdt directive
+// argument value, and true if it was found.
+func CRDTTypeFromName(name string) (client.CType, bool) {
+	value, ok := CRDTEnum.ParseValue(name).(client.CType)
+	return value, ok
+}
+
+// CRDTTypeNames returns the sorted names of the CRDT types that may be selected
+// using the @crdt directive.
+func CRDTTypeNames() []string {
+	names := []string{}
+	for _, value := range CRDTEnum.Values() {
+		names = append(names, value.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IndexTypeFromName returns the index type matching the given @index directive
+// argument value, and true if it was found.
+func IndexTypeFromName(name string) (client.IndexType, bool) {
+	value, ok := IndexEnum.ParseValue(name).(client.IndexType)
+	return value, ok
+}
+
 func NewArgConfig(t gql.Type, description string) *gql.ArgumentConfig {
 	return &gql.ArgumentConfig{
 		Type:        t,
@@ -0,0 +1,41 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tests
+
+import (
+	"sync"
+	"time"
+)
+
+// commitClock is the clock used by the test databases to record the time of commits.
+//
+// It yields the zero time, so that no time is recorded and the Cids of commits remain
+// deterministic, unless a time has been set by a SetCommitTime action.
+var commitClock = &testClock{}
+
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// Now returns the time last set on the clock.
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Set sets the time returned by the clock.
+func (c *testClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
@@ -244,7 +244,7 @@ func TestDefaultExplainLatestCommitsDagScanQueryOpWithoutField(t *testing.T) {
 	runExplainTest(t, test)
 }
 
-func TestDefaultExplainLatestCommitsDagScanWithoutDocKey_Failure(t *testing.T) {
+func TestDefaultExplainLatestCommitsDagScanWithoutDocKey(t *testing.T) {
 	test := explainUtils.ExplainRequestTestCase{
 
 		Description: "Explain (default) latestCommits query without DocKey.",
@@ -258,13 +258,25 @@ func TestDefaultExplainLatestCommitsDagScanWithoutDocKey_Failure(t *testing.T) {
 			}
 		}`,
 
-		ExpectedError: "Field \"latestCommits\" argument \"dockey\" of type \"ID!\" is required but not provided.",
+		ExpectedPatterns: []dataMap{dagScanPattern},
+
+		ExpectedTargets: []explainUtils.PlanNodeTargetCase{
+			{
+				TargetNodeName:    "dagScanNode",
+				IncludeChildNodes: true, // Shouldn't have any as this is the last node in the chain.
+				ExpectedAttributes: dataMap{
+					"cid":     nil,
+					"fieldId": "1",
+					"spans":   []dataMap{},
+				},
+			},
+		},
 	}
 
 	runExplainTest(t, test)
 }
 
-func TestDefaultExplainLatestCommitsDagScanWithoutAnyArguments_Failure(t *testing.T) {
+func TestDefaultExplainLatestCommitsDagScanWithoutAnyArguments(t *testing.T) {
 	test := explainUtils.ExplainRequestTestCase{
 
 		Description: "Explain (default) latestCommits query without any arguments.",
@@ -278,7 +290,19 @@ func TestDefaultExplainLatestCommitsDagScanWithoutAnyArguments_Failure(t *testin
 			}
 		}`,
 
-		ExpectedError: "Field \"latestCommits\" argument \"dockey\" of type \"ID!\" is required but not provided.",
+		ExpectedPatterns: []dataMap{dagScanPattern},
+
+		ExpectedTargets: []explainUtils.PlanNodeTargetCase{
+			{
+				TargetNodeName:    "dagScanNode",
+				IncludeChildNodes: true, // Shouldn't have any as this is the last node in the chain.
+				ExpectedAttributes: dataMap{
+					"cid":     nil,
+					"fieldId": "C",
+					"spans":   []dataMap{},
+				},
+			},
+		},
 	}
 
 	runExplainTest(t, test)
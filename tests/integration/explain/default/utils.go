@@ -34,7 +34,7 @@ var bookAuthorGQLSchema = (`
 	}
 
 	type Author {
-		name: String
+		name: String @index(type: fulltext)
 		age: Int
 		verified: Boolean
 		books: [Book]
@@ -0,0 +1,190 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package test_explain_default
+
+import (
+	"testing"
+
+	explainUtils "github.com/sourcenetwork/defradb/tests/integration/explain"
+)
+
+var cursorPattern = dataMap{
+	"explain": dataMap{
+		"selectTopNode": dataMap{
+			"cursorNode": dataMap{
+				"selectNode": dataMap{
+					"scanNode": dataMap{},
+				},
+			},
+		},
+	},
+}
+
+var cursorWithOrderPattern = dataMap{
+	"explain": dataMap{
+		"selectTopNode": dataMap{
+			"cursorNode": dataMap{
+				"orderNode": dataMap{
+					"selectNode": dataMap{
+						"scanNode": dataMap{},
+					},
+				},
+			},
+		},
+	},
+}
+
+var cursorWithOrderAndLimitPattern = dataMap{
+	"explain": dataMap{
+		"selectTopNode": dataMap{
+			"limitNode": dataMap{
+				"cursorNode": dataMap{
+					"orderNode": dataMap{
+						"selectNode": dataMap{
+							"scanNode": dataMap{},
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+func TestDefaultExplainRequestWithAfterAndBeforeCursors(t *testing.T) {
+	test := explainUtils.ExplainRequestTestCase{
+
+		Description: "Explain (default) request with after and before cursors seeks the scan to the after cursor.",
+
+		Request: `query @explain {
+			Author(
+				after: "gngoYmFlLTQxNTk4ZjBjLTE5YmMtNWRhNi04MTNiLWU4MGYxNGExMGRmM4A",
+				before: "gngoYmFlLWU3ZTg3YmJiLTEwNzktNTlkYi1iNGI5LTBlMTRiMjRkNWI2OYA"
+			) {
+				name
+			}
+		}`,
+
+		ExpectedPatterns: []dataMap{cursorPattern},
+
+		ExpectedTargets: []explainUtils.PlanNodeTargetCase{
+			{
+				TargetNodeName:    "cursorNode",
+				IncludeChildNodes: false,
+				ExpectedAttributes: dataMap{
+					"after":  true,
+					"before": true,
+				},
+			},
+			{
+				TargetNodeName:    "scanNode",
+				IncludeChildNodes: true, // should be last node, so will have no child nodes.
+				ExpectedAttributes: dataMap{
+					"collectionID":   "3",
+					"collectionName": "Author",
+					"filter":         nil,
+					"spans": []dataMap{
+						{
+							"start": "/3/bae-41598f0c-19bc-5da6-813b-e80f14a10df4",
+							"end":   "/4",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	runExplainTest(t, test)
+}
+
+func TestDefaultExplainRequestWithKeyOrderAndAfterCursor(t *testing.T) {
+	test := explainUtils.ExplainRequestTestCase{
+
+		Description: "Explain (default) request with key order and after cursor seeks the scan.",
+
+		Request: `query @explain {
+			Author(
+				order: {_key: ASC},
+				after: "gngoYmFlLTQxNTk4ZjBjLTE5YmMtNWRhNi04MTNiLWU4MGYxNGExMGRmM4F4KGJhZS00MTU5OGYwYy0xOWJjLTVkYTYtODEzYi1lODBmMTRhMTBkZjM"
+			) {
+				name
+			}
+		}`,
+
+		ExpectedPatterns: []dataMap{cursorWithOrderPattern},
+
+		ExpectedTargets: []explainUtils.PlanNodeTargetCase{
+			{
+				TargetNodeName:    "scanNode",
+				IncludeChildNodes: true, // should be last node, so will have no child nodes.
+				ExpectedAttributes: dataMap{
+					"collectionID":   "3",
+					"collectionName": "Author",
+					"filter":         nil,
+					"spans": []dataMap{
+						{
+							"start": "/3/bae-41598f0c-19bc-5da6-813b-e80f14a10df4",
+							"end":   "/4",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	runExplainTest(t, test)
+}
+
+func TestDefaultExplainRequestWithOrderAfterCursorAndLimit(t *testing.T) {
+	test := explainUtils.ExplainRequestTestCase{
+
+		Description: "Explain (default) request with order, after cursor and limit.",
+
+		Request: `query @explain {
+			Author(
+				order: {age: ASC},
+				after: "gngoYmFlLTQxNTk4ZjBjLTE5YmMtNWRhNi04MTNiLWU4MGYxNGExMGRmM4EYQQ",
+				limit: 2
+			) {
+				name
+			}
+		}`,
+
+		ExpectedPatterns: []dataMap{cursorWithOrderAndLimitPattern},
+
+		ExpectedTargets: []explainUtils.PlanNodeTargetCase{
+			{
+				TargetNodeName:    "cursorNode",
+				IncludeChildNodes: false,
+				ExpectedAttributes: dataMap{
+					"after":  true,
+					"before": false,
+				},
+			},
+			{
+				TargetNodeName:    "scanNode",
+				IncludeChildNodes: true, // should be last node, so will have no child nodes.
+				ExpectedAttributes: dataMap{
+					"collectionID":   "3",
+					"collectionName": "Author",
+					"filter":         nil,
+					"spans": []dataMap{
+						{
+							"start": "/3",
+							"end":   "/4",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	runExplainTest(t, test)
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package test_explain_default
+
+import (
+	"testing"
+
+	explainUtils "github.com/sourcenetwork/defradb/tests/integration/explain"
+)
+
+func TestDefaultExplainRequestWithDistinct(t *testing.T) {
+	test := explainUtils.ExplainRequestTestCase{
+
+		Description: "Explain (default) request with distinct.",
+
+		Request: `query @explain {
+			Author(distinct: [age]) {
+				name
+			}
+		}`,
+
+		ExpectedPatterns: []dataMap{
+			{
+				"explain": dataMap{
+					"selectTopNode": dataMap{
+						"distinctNode": dataMap{
+							"selectNode": dataMap{
+								"scanNode": dataMap{},
+							},
+						},
+					},
+				},
+			},
+		},
+
+		ExpectedTargets: []explainUtils.PlanNodeTargetCase{
+			{
+				TargetNodeName:    "distinctNode",
+				IncludeChildNodes: false,
+				ExpectedAttributes: dataMap{
+					"fields":   []string{"age"},
+					"strategy": "hashed",
+				},
+			},
+		},
+	}
+
+	runExplainTest(t, test)
+}
+
+func TestDefaultExplainRequestWithDistinctOrderedAndLimited(t *testing.T) {
+	test := explainUtils.ExplainRequestTestCase{
+
+		Description: "Explain (default) request with distinct on the ordered field, with limit.",
+
+		Request: `query @explain {
+			Author(distinct: [age], order: {age: ASC}, limit: 2) {
+				name
+			}
+		}`,
+
+		ExpectedPatterns: []dataMap{
+			{
+				"explain": dataMap{
+					"selectTopNode": dataMap{
+						"limitNode": dataMap{
+							"distinctNode": dataMap{
+								"orderNode": dataMap{
+									"selectNode": dataMap{
+										"scanNode": dataMap{},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+
+		ExpectedTargets: []explainUtils.PlanNodeTargetCase{
+			{
+				TargetNodeName:    "distinctNode",
+				IncludeChildNodes: false,
+				ExpectedAttributes: dataMap{
+					"fields":   []string{"age"},
+					"strategy": "sorted",
+				},
+			},
+		},
+	}
+
+	runExplainTest(t, test)
+}
@@ -0,0 +1,89 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package test_explain_default
+
+import (
+	"testing"
+
+	explainUtils "github.com/sourcenetwork/defradb/tests/integration/explain"
+)
+
+var searchPattern = dataMap{
+	"explain": dataMap{
+		"selectTopNode": dataMap{
+			"selectNode": dataMap{
+				"searchNode": dataMap{
+					"scanNode": dataMap{},
+				},
+			},
+		},
+	},
+}
+
+func TestDefaultExplainRequestWithSearchOnIndexedField(t *testing.T) {
+	test := explainUtils.ExplainRequestTestCase{
+
+		Description: "Explain (default) request with full-text search on a field with an index.",
+
+		Request: `query @explain {
+			Author(filter: {name: {_search: "John"}}) {
+				name
+			}
+		}`,
+
+		ExpectedPatterns: []dataMap{searchPattern},
+
+		ExpectedTargets: []explainUtils.PlanNodeTargetCase{
+			{
+				TargetNodeName:    "searchNode",
+				IncludeChildNodes: false,
+				ExpectedAttributes: dataMap{
+					"fieldName": "name",
+					"search":    "John",
+				},
+			},
+			{
+				TargetNodeName:    "scanNode",
+				IncludeChildNodes: true, // should be last node, so will have no child nodes.
+				ExpectedAttributes: dataMap{
+					"collectionID":   "3",
+					"collectionName": "Author",
+					"filter": dataMap{
+						"name": dataMap{
+							"_search": "John",
+						},
+					},
+					// there are no documents in the index, so there is nothing to scan
+					"spans": []dataMap{},
+				},
+			},
+		},
+	}
+
+	runExplainTest(t, test)
+}
+
+func TestDefaultExplainRequestWithSearchOnFieldWithoutIndexErrors(t *testing.T) {
+	test := explainUtils.ExplainRequestTestCase{
+
+		Description: "Explain (default) request with full-text search on a field without an index, errors.",
+
+		Request: `query @explain {
+			Book(filter: {name: {_search: "Painted"}}) {
+				name
+			}
+		}`,
+
+		ExpectedError: "a full-text search requires a full-text index on the field. Field: name",
+	}
+
+	runExplainTest(t, test)
+}
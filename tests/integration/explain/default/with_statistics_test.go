@@ -0,0 +1,134 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package test_explain_default
+
+import (
+	"testing"
+
+	explainUtils "github.com/sourcenetwork/defradb/tests/integration/explain"
+)
+
+func TestDefaultExplainRequestWithPercentileOnInlineArrayField(t *testing.T) {
+	test := explainUtils.ExplainRequestTestCase{
+
+		Description: "Explain (default) request with percentile on an inline array field.",
+
+		Request: `query @explain {
+			Book {
+				name
+				_percentile(p: 90, chapterPages: {})
+			}
+		}`,
+
+		ExpectedPatterns: []dataMap{
+			{
+				"explain": dataMap{
+					"selectTopNode": dataMap{
+						"percentileNode": dataMap{
+							"selectNode": dataMap{
+								"scanNode": dataMap{},
+							},
+						},
+					},
+				},
+			},
+		},
+
+		ExpectedTargets: []explainUtils.PlanNodeTargetCase{
+			{
+				TargetNodeName:    "percentileNode",
+				IncludeChildNodes: false,
+				ExpectedAttributes: dataMap{
+					"percentile": float64(90),
+					"sources": []dataMap{
+						{
+							"fieldName":      "chapterPages",
+							"childFieldName": nil,
+							"filter":         nil,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	runExplainTest(t, test)
+}
+
+func TestDefaultExplainRequestWithMedianAndStdDevOnJoinedField(t *testing.T) {
+	test := explainUtils.ExplainRequestTestCase{
+
+		Description: "Explain (default) request with median and standard deviation on joined field.",
+
+		Request: `query @explain {
+			Author {
+				name
+				_median(books: {field: pages, filter: {pages: {_gt: 10}}})
+				_stddev(books: {field: pages, filter: {pages: {_gt: 10}}})
+			}
+		}`,
+
+		ExpectedPatterns: []dataMap{
+			{
+				"explain": dataMap{
+					"selectTopNode": dataMap{
+						"medianNode": dataMap{
+							"stdDevNode": dataMap{
+								"selectNode": dataMap{
+									"typeIndexJoin": normalTypeJoinPattern,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+
+		ExpectedTargets: []explainUtils.PlanNodeTargetCase{
+			{
+				TargetNodeName:    "medianNode",
+				IncludeChildNodes: false,
+				ExpectedAttributes: dataMap{
+					"sources": []dataMap{
+						{
+							"fieldName":      "books",
+							"childFieldName": "pages",
+							"filter": dataMap{
+								"pages": dataMap{
+									"_gt": int(10),
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				TargetNodeName:    "stdDevNode",
+				IncludeChildNodes: false,
+				ExpectedAttributes: dataMap{
+					"sources": []dataMap{
+						{
+							"fieldName":      "books",
+							"childFieldName": "pages",
+							"filter": dataMap{
+								"pages": dataMap{
+									"_gt": int(10),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	runExplainTest(t, test)
+}
@@ -35,7 +35,7 @@ func gqlSchemaExecuteExplain() testUtils.SchemaUpdate {
 			}
 
 			type Author {
-				name: String
+				name: String @index(type: fulltext)
 				age: Int
 				verified: Boolean
 				books: [Book]
@@ -0,0 +1,67 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package test_explain_execute
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestExecuteExplainRequestWithAfterCursorSeeksScan(t *testing.T) {
+	test := testUtils.TestCase{
+
+		Description: "Explain (execute) with after cursor, the documents before it are not scanned.",
+
+		Actions: []any{
+			gqlSchemaExecuteExplain(),
+
+			// Authors
+			create2AuthorDocuments(),
+
+			testUtils.Request{
+				// Cursor of the author "bae-68cb395d-df73-5bcb-b623-615a140dee12".
+				Request: `query @explain(type: execute) {
+					Author(after: "gngoYmFlLTY4Y2IzOTVkLWRmNzMtNWJjYi1iNjIzLTYxNWExNDBkZWUxMoA") {
+						name
+					}
+				}`,
+
+				Results: []dataMap{
+					{
+						"explain": dataMap{
+							"executionSuccess": true,
+							"sizeOfResult":     1,
+							"planExecutions":   uint64(2),
+							"selectTopNode": dataMap{
+								"cursorNode": dataMap{
+									"iterations": uint64(2),
+									"skipped":    uint64(0),
+									"selectNode": dataMap{
+										"iterations":    uint64(2),
+										"filterMatches": uint64(1),
+										"scanNode": dataMap{
+											"iterations":    uint64(2),
+											"docFetches":    uint64(2),
+											"filterMatches": uint64(1),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
@@ -0,0 +1,66 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package test_explain_execute
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestExecuteExplainRequestWithSearch(t *testing.T) {
+	test := testUtils.TestCase{
+
+		Description: "Explain (execute) request with full-text search on a field with an index.",
+
+		Actions: []any{
+			gqlSchemaExecuteExplain(),
+
+			// Authors
+			create2AuthorDocuments(),
+
+			testUtils.Request{
+				Request: `query @explain(type: execute) {
+					Author(filter: {name: {_search: "john"}}) {
+						name
+					}
+				}`,
+
+				Results: []dataMap{
+					{
+						"explain": dataMap{
+							"executionSuccess": true,
+							"sizeOfResult":     1,
+							"planExecutions":   uint64(2),
+							"selectTopNode": dataMap{
+								"selectNode": dataMap{
+									"iterations":    uint64(2),
+									"filterMatches": uint64(1),
+									"searchNode": dataMap{
+										"iterations":   uint64(2),
+										"indexMatches": uint64(1),
+										"scanNode": dataMap{
+											"iterations":    uint64(2),
+											"docFetches":    uint64(2),
+											"filterMatches": uint64(1),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
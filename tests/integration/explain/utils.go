@@ -39,27 +39,36 @@ var (
 		"subType": {},
 
 		// These are all valid nodes.
-		"averageNode":   {},
-		"countNode":     {},
-		"createNode":    {},
-		"dagScanNode":   {},
-		"deleteNode":    {},
-		"groupNode":     {},
-		"limitNode":     {},
-		"multiScanNode": {},
-		"orderNode":     {},
-		"parallelNode":  {},
-		"pipeNode":      {},
-		"scanNode":      {},
-		"selectNode":    {},
-		"selectTopNode": {},
-		"sumNode":       {},
-		"topLevelNode":  {},
-		"typeIndexJoin": {},
-		"typeJoinMany":  {},
-		"typeJoinOne":   {},
-		"updateNode":    {},
-		"valuesNode":    {},
+		"averageNode":    {},
+		"countNode":      {},
+		"createNode":     {},
+		"cursorNode":     {},
+		"dagScanNode":    {},
+		"deleteNode":     {},
+		"distinctNode":   {},
+		"groupNode":      {},
+		"limitNode":      {},
+		"maxNode":        {},
+		"medianNode":     {},
+		"minNode":        {},
+		"multiScanNode":  {},
+		"orderNode":      {},
+		"parallelNode":   {},
+		"percentileNode": {},
+		"pipeNode":       {},
+		"scanNode":       {},
+		"searchNode":     {},
+		"selectNode":     {},
+		"selectTopNode":  {},
+		"stdDevNode":     {},
+		"sumNode":        {},
+		"topLevelNode":   {},
+		"typeIndexJoin":  {},
+		"typeJoinMany":   {},
+		"typeJoinOne":    {},
+		"updateNode":     {},
+		"valuesNode":     {},
+		"varianceNode":   {},
 	}
 )
 
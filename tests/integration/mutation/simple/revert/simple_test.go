@@ -0,0 +1,278 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package revert
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+	simpleTests "github.com/sourcenetwork/defradb/tests/integration/mutation/simple"
+)
+
+func TestRevertMutationToPreviousVersion(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple revert mutation to the first version of a document",
+		Actions: []any{
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"age": 21
+				}`,
+			},
+			testUtils.UpdateDoc{
+				Doc: `{
+					"age": 22,
+					"verified": true
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					revert_User(
+						id: "bae-f54b9689-e06e-5e3a-89b3-f3aee8e64ca7",
+						cid: "bafybeidxie3wptbvimo7q2h6od7bym2zhhbv6koecsgaimtjeqimzj5ubm"
+					) {
+						name
+						age
+						verified
+						_version {
+							height
+						}
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name":     "John",
+						"age":      uint64(21),
+						"verified": nil,
+						"_version": []map[string]any{
+							{
+								"height": int64(3),
+							},
+							{
+								"height": int64(2),
+							},
+							{
+								"height": int64(1),
+							},
+						},
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `query {
+					User {
+						name
+						age
+						verified
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name":     "John",
+						"age":      uint64(21),
+						"verified": nil,
+					},
+				},
+			},
+		},
+	}
+
+	simpleTests.Execute(t, test)
+}
+
+func TestRevertMutationKeepsHistory(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple revert mutation appends a commit on top of the current heads",
+		Actions: []any{
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"age": 21
+				}`,
+			},
+			testUtils.UpdateDoc{
+				Doc: `{
+					"age": 22,
+					"verified": true
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					revert_User(
+						id: "bae-f54b9689-e06e-5e3a-89b3-f3aee8e64ca7",
+						cid: "bafybeidxie3wptbvimo7q2h6od7bym2zhhbv6koecsgaimtjeqimzj5ubm"
+					) {
+						_key
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"_key": "bae-f54b9689-e06e-5e3a-89b3-f3aee8e64ca7",
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `query {
+					commits(dockey: "bae-f54b9689-e06e-5e3a-89b3-f3aee8e64ca7", fieldId: "C") {
+						height
+						links {
+							name
+						}
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"height": int64(3),
+						"links": []map[string]any{
+							{
+								"name": "_head",
+							},
+							{
+								"name": "age",
+							},
+							{
+								"name": "verified",
+							},
+						},
+					},
+					{
+						"height": int64(2),
+						"links": []map[string]any{
+							{
+								"name": "_head",
+							},
+							{
+								"name": "age",
+							},
+							{
+								"name": "verified",
+							},
+						},
+					},
+					{
+						"height": int64(1),
+						"links": []map[string]any{
+							{
+								"name": "age",
+							},
+							{
+								"name": "name",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	simpleTests.Execute(t, test)
+}
+
+func TestRevertMutationWithVersionOfOtherDocument(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple revert mutation with a version of another document, errors",
+		Actions: []any{
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"age": 21
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "Fred",
+					"age": 30
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					revert_User(
+						id: "bae-f54b9689-e06e-5e3a-89b3-f3aee8e64ca7",
+						cid: "bafybeibnqq6w3tl6aoyfmnpji3gavlvnuiibz34wu2mmekxmwjiyv6u5g4"
+					) {
+						name
+					}
+				}`,
+				ExpectedError: "the given version is not a commit of the document",
+			},
+		},
+	}
+
+	simpleTests.Execute(t, test)
+}
+
+func TestRevertMutationOfDeletedDocument(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple revert mutation of a deleted document, errors",
+		Actions: []any{
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"age": 21
+				}`,
+			},
+			testUtils.DeleteDoc{},
+			testUtils.Request{
+				Request: `mutation {
+					revert_User(
+						id: "bae-f54b9689-e06e-5e3a-89b3-f3aee8e64ca7",
+						cid: "bafybeidxie3wptbvimo7q2h6od7bym2zhhbv6koecsgaimtjeqimzj5ubm"
+					) {
+						name
+					}
+				}`,
+				ExpectedError: "a document with the given dockey has been deleted",
+			},
+		},
+	}
+
+	simpleTests.Execute(t, test)
+}
+
+func TestRevertMutationToCompactedVersion(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple revert mutation to a version folded by a history compaction, errors",
+		Actions: []any{
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"age": 21
+				}`,
+			},
+			testUtils.UpdateDoc{
+				Doc: `{
+					"age": 22
+				}`,
+			},
+			testUtils.UpdateDoc{
+				Doc: `{
+					"age": 23
+				}`,
+			},
+			testUtils.CompactDocs{
+				Depth: 1,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					revert_User(
+						id: "bae-f54b9689-e06e-5e3a-89b3-f3aee8e64ca7",
+						cid: "bafybeidxie3wptbvimo7q2h6od7bym2zhhbv6koecsgaimtjeqimzj5ubm"
+					) {
+						name
+					}
+				}`,
+				ExpectedError: "the requested version has been compacted",
+			},
+		},
+	}
+
+	simpleTests.Execute(t, test)
+}
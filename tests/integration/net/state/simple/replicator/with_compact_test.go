@@ -0,0 +1,162 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package replicator
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/immutable"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestP2POneToOneReplicatorSyncsExistingCompactedDocument(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.RandomNetworkingConfig(),
+			testUtils.RandomNetworkingConfig(),
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+						Age: Int
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				// Create John on the first (source) node only
+				NodeID: immutable.Some(0),
+				Doc: `{
+					"Name": "John",
+					"Age": 21
+				}`,
+			},
+			testUtils.UpdateDoc{
+				// Only the heads of existing documents are pushed to the replicator
+				NodeID:   immutable.Some(0),
+				DontSync: true,
+				Doc: `{
+					"Age": 22
+				}`,
+			},
+			testUtils.UpdateDoc{
+				// Only the heads of existing documents are pushed to the replicator
+				NodeID:   immutable.Some(0),
+				DontSync: true,
+				Doc: `{
+					"Name": "Johnny",
+					"Age": 23
+				}`,
+			},
+			testUtils.CompactDocs{
+				// Fold the first two commits of John, the retained commit still links to them
+				NodeID: immutable.Some(0),
+				Height: 2,
+			},
+			// Once configured the replicator should sync the compacted document
+			testUtils.ConfigureReplicator{
+				SourceNodeID: 0,
+				TargetNodeID: 1,
+			},
+			testUtils.WaitForSync{},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						Name
+						Age
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "Johnny",
+						"Age":  uint64(23),
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestP2POneToOneReplicatorWithUpdateOfCompactedDocument(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.RandomNetworkingConfig(),
+			testUtils.RandomNetworkingConfig(),
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						Name: String
+						Age: Int
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				// Create John on the first (source) node only
+				NodeID: immutable.Some(0),
+				Doc: `{
+					"Name": "John",
+					"Age": 21
+				}`,
+			},
+			testUtils.UpdateDoc{
+				// Only the heads of existing documents are pushed to the replicator
+				NodeID:   immutable.Some(0),
+				DontSync: true,
+				Doc: `{
+					"Age": 22
+				}`,
+			},
+			testUtils.UpdateDoc{
+				// Only the heads of existing documents are pushed to the replicator
+				NodeID:   immutable.Some(0),
+				DontSync: true,
+				Doc: `{
+					"Age": 23
+				}`,
+			},
+			testUtils.CompactDocs{
+				NodeID: immutable.Some(0),
+				Height: 2,
+			},
+			testUtils.ConfigureReplicator{
+				SourceNodeID: 0,
+				TargetNodeID: 1,
+			},
+			testUtils.UpdateDoc{
+				// Update John's Name on the first node only, after the replicator has been
+				// configured, and allow the value to sync
+				NodeID: immutable.Some(0),
+				Doc: `{
+					"Name": "Johnny"
+				}`,
+			},
+			testUtils.WaitForSync{},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						Name
+						Age
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "Johnny",
+						"Age":  uint64(23),
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
@@ -0,0 +1,95 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package replicator
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/immutable"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestP2POneToOneReplicatorDoesNotRecreatePurgedDoc(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.RandomNetworkingConfig(),
+			testUtils.RandomNetworkingConfig(),
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						age: Int
+					}
+				`,
+			},
+			testUtils.ConfigureReplicator{
+				SourceNodeID: 0,
+				TargetNodeID: 1,
+			},
+			testUtils.CreateDoc{
+				NodeID: immutable.Some(0),
+				Doc: `{
+					"name": "John",
+					"age": 21
+				}`,
+			},
+			testUtils.WaitForSync{},
+			testUtils.PurgeDoc{
+				// Erase John from the second node only
+				NodeID: immutable.Some(1),
+				DocID:  0,
+			},
+			testUtils.UpdateDoc{
+				// The update is pushed to the second node, but must not re-create John there
+				NodeID: immutable.Some(0),
+				DocID:  0,
+				Doc: `{
+					"age": 22
+				}`,
+			},
+			testUtils.WaitForSync{},
+			testUtils.Request{
+				NodeID: immutable.Some(0),
+				Request: `query {
+					Users {
+						age
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"age": uint64(22),
+					},
+				},
+			},
+			testUtils.Request{
+				NodeID: immutable.Some(1),
+				Request: `query {
+					Users(showDeleted: true) {
+						age
+					}
+				}`,
+				Results: []map[string]any{},
+			},
+			testUtils.Request{
+				NodeID: immutable.Some(1),
+				Request: `query {
+					commits(dockey: "bae-f54b9689-e06e-5e3a-89b3-f3aee8e64ca7") {
+						cid
+					}
+				}`,
+				Results: []map[string]any{},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
@@ -337,11 +337,11 @@ func configureReplicator(
 
 		case UpdateDoc:
 			if _, shouldSyncFromTarget := docIDsSyncedToSource[action.DocID]; shouldSyncFromTarget &&
-				action.NodeID.HasValue() && action.NodeID.Value() == cfg.TargetNodeID {
+				!action.DontSync && action.NodeID.HasValue() && action.NodeID.Value() == cfg.TargetNodeID {
 				targetToSourceEvents[waitIndex] += 1
 			}
 
-			if action.NodeID.HasValue() && action.NodeID.Value() == cfg.SourceNodeID {
+			if !action.DontSync && action.NodeID.HasValue() && action.NodeID.Value() == cfg.SourceNodeID {
 				sourceToTargetEvents[waitIndex] += 1
 			}
 
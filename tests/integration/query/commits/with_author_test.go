@@ -0,0 +1,172 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package commits
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/immutable"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryCommitsWithAuthor(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple all commits query with author",
+		Actions: []any{
+			updateUserCollectionSchema(),
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Identity:     immutable.Some("alice"),
+				Doc: `{
+						"name":	"John",
+						"age":	21
+					}`,
+			},
+			testUtils.UpdateDoc{
+				CollectionID: 0,
+				DocID:        0,
+				Identity:     immutable.Some("bob"),
+				Doc: `{
+						"age":	22
+					}`,
+			},
+			testUtils.Request{
+				Request: `query {
+						commits {
+							cid
+							height
+							fieldName
+							author
+						}
+					}`,
+				Results: []map[string]any{
+					{
+						"cid":       "bafybeibvzg7f2p772ev3srlzt4w5jjwlo3nw4chtd6ewuvbrnlidzqtmr4",
+						"height":    int64(2),
+						"fieldName": "age",
+						"author":    nil,
+					},
+					{
+						"cid":       "bafybeic5oodfpnixl6uf4bi63m3eouuhj3gafudlsd4tqryhx2wy7rczoe",
+						"height":    int64(1),
+						"fieldName": "age",
+						"author":    nil,
+					},
+					{
+						"cid":       "bafybeifukwb3t73k7pph3ctp5khosoycp53ywjl6btravzk6decggkjtl4",
+						"height":    int64(1),
+						"fieldName": "name",
+						"author":    nil,
+					},
+					{
+						"cid":       "bafybeib4pcbypezj5znfppfj57m44oxuvzozv2n6gfxut7ntbj66n6rtw4",
+						"height":    int64(2),
+						"fieldName": nil,
+						"author":    "bob",
+					},
+					{
+						"cid":       "bafybeiay66qjaegqz4lcuyrazi7fyzp3zoc7efelbmmgmwmzc5uke7zohi",
+						"height":    int64(1),
+						"fieldName": nil,
+						"author":    "alice",
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQueryCommitsWithAuthorFilter(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple all commits query with author filter",
+		Actions: []any{
+			updateUserCollectionSchema(),
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Identity:     immutable.Some("alice"),
+				Doc: `{
+						"name":	"John",
+						"age":	21
+					}`,
+			},
+			testUtils.UpdateDoc{
+				CollectionID: 0,
+				DocID:        0,
+				Identity:     immutable.Some("bob"),
+				Doc: `{
+						"age":	22
+					}`,
+			},
+			testUtils.UpdateDoc{
+				CollectionID: 0,
+				DocID:        0,
+				Doc: `{
+						"age":	23
+					}`,
+			},
+			testUtils.Request{
+				Request: `query {
+						commits(filter: {author: {_eq: "bob"}}) {
+							height
+							author
+						}
+					}`,
+				Results: []map[string]any{
+					{
+						"height": int64(2),
+						"author": "bob",
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQueryCommitsWithAuthorFromMutationRequest(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple commits query with author of a document created by a mutation request",
+		Actions: []any{
+			updateUserCollectionSchema(),
+			testUtils.Request{
+				Identity: immutable.Some("alice"),
+				Request: `mutation {
+						create_Users(data: "{\"name\": \"John\", \"age\": 21}") {
+							_key
+						}
+					}`,
+				Results: []map[string]any{
+					{
+						"_key": "bae-f54b9689-e06e-5e3a-89b3-f3aee8e64ca7",
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `query {
+						latestCommits(dockey: "bae-f54b9689-e06e-5e3a-89b3-f3aee8e64ca7") {
+							author
+						}
+					}`,
+				Results: []map[string]any{
+					{
+						"author": "alice",
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
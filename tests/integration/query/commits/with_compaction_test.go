@@ -0,0 +1,283 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package commits
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryCommitsWithCompactedHistory(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple commits query of composite commits after compacting the history",
+		Actions: []any{
+			updateUserCollectionSchema(),
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+						"name":	"John",
+						"age":	21
+					}`,
+			},
+			testUtils.UpdateDoc{
+				CollectionID: 0,
+				DocID:        0,
+				Doc: `{
+					"age":	22
+				}`,
+			},
+			testUtils.UpdateDoc{
+				CollectionID: 0,
+				DocID:        0,
+				Doc: `{
+					"age":	23
+				}`,
+			},
+			testUtils.CompactDocs{
+				CollectionID: 0,
+				Depth:        1,
+			},
+			testUtils.Request{
+				Request: `query {
+						commits(dockey: "bae-f54b9689-e06e-5e3a-89b3-f3aee8e64ca7", fieldId: "C") {
+							cid
+							height
+							links {
+								name
+							}
+						}
+					}`,
+				Results: []map[string]any{
+					{
+						"cid":    "bafybeiegusf5ypa7htxwa6u4fvne3lqq2jafe4fxllh4lo6iw4xdsn4yyq",
+						"height": int64(3),
+						"links": []map[string]any{
+							{
+								"name": "_head",
+							},
+							{
+								"name": "age",
+							},
+						},
+					},
+					{
+						// The snapshot holding the full state of the document at height 2
+						"cid":    "bafybeihs66ko3yqxkd7pmxhdisc3q4ohxm24kjpxn3vp33x3h4i37xlzly",
+						"height": int64(2),
+						"links": []map[string]any{
+							{
+								"name": "age",
+							},
+							{
+								"name": "name",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQueryCommitsWithCompactedHistoryAndFieldId(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple commits query of field commits after compacting the history",
+		Actions: []any{
+			updateUserCollectionSchema(),
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+						"name":	"John",
+						"age":	21
+					}`,
+			},
+			testUtils.UpdateDoc{
+				CollectionID: 0,
+				DocID:        0,
+				Doc: `{
+					"age":	22
+				}`,
+			},
+			testUtils.UpdateDoc{
+				CollectionID: 0,
+				DocID:        0,
+				Doc: `{
+					"age":	23
+				}`,
+			},
+			testUtils.CompactDocs{
+				CollectionID: 0,
+				DocIDs:       []int{0},
+				Depth:        1,
+			},
+			testUtils.Request{
+				Request: `query {
+						commits(dockey: "bae-f54b9689-e06e-5e3a-89b3-f3aee8e64ca7", fieldId: "1") {
+							height
+						}
+					}`,
+				Results: []map[string]any{
+					{
+						"height": int64(3),
+					},
+					{
+						"height": int64(2),
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQueryWithCidOfCompactedHistory(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with the cid of the snapshot of a compacted history",
+		Actions: []any{
+			updateUserCollectionSchema(),
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+						"name":	"John",
+						"age":	21
+					}`,
+			},
+			testUtils.UpdateDoc{
+				CollectionID: 0,
+				DocID:        0,
+				Doc: `{
+					"age":	22
+				}`,
+			},
+			testUtils.UpdateDoc{
+				CollectionID: 0,
+				DocID:        0,
+				Doc: `{
+					"age":	23
+				}`,
+			},
+			testUtils.CompactDocs{
+				CollectionID: 0,
+				Height:       2,
+			},
+			testUtils.Request{
+				Request: `query {
+						Users (
+							cid: "bafybeihs66ko3yqxkd7pmxhdisc3q4ohxm24kjpxn3vp33x3h4i37xlzly",
+							dockey: "bae-f54b9689-e06e-5e3a-89b3-f3aee8e64ca7"
+						) {
+							name
+							age
+						}
+					}`,
+				Results: []map[string]any{
+					{
+						"name": "John",
+						"age":  uint64(22),
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `query {
+						Users (
+							cid: "bafybeiegusf5ypa7htxwa6u4fvne3lqq2jafe4fxllh4lo6iw4xdsn4yyq",
+							dockey: "bae-f54b9689-e06e-5e3a-89b3-f3aee8e64ca7"
+						) {
+							name
+							age
+						}
+					}`,
+				Results: []map[string]any{
+					{
+						"name": "John",
+						"age":  uint64(23),
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQueryWithCidOfCompactedVersionErrors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with the cid of a version folded into the snapshot of a compacted history",
+		Actions: []any{
+			updateUserCollectionSchema(),
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+						"name":	"John",
+						"age":	21
+					}`,
+			},
+			testUtils.UpdateDoc{
+				CollectionID: 0,
+				DocID:        0,
+				Doc: `{
+					"age":	22
+				}`,
+			},
+			testUtils.UpdateDoc{
+				CollectionID: 0,
+				DocID:        0,
+				Doc: `{
+					"age":	23
+				}`,
+			},
+			testUtils.CompactDocs{
+				CollectionID: 0,
+				Height:       2,
+			},
+			testUtils.Request{
+				Request: `query {
+						Users (
+							cid: "bafybeig3wrpwi6q7vjchizcwnenslasyxop6wey7jahbiszlubdglfq2fq",
+							dockey: "bae-f54b9689-e06e-5e3a-89b3-f3aee8e64ca7"
+						) {
+							name
+							age
+						}
+					}`,
+				ExpectedError: "the requested version has been compacted",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQueryCommitsWithCompactionWithoutHeightOrDepthErrors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Compacting the history without a height or depth returns an error",
+		Actions: []any{
+			updateUserCollectionSchema(),
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+						"name":	"John",
+						"age":	21
+					}`,
+			},
+			testUtils.CompactDocs{
+				CollectionID:  0,
+				ExpectedError: "a compaction height or depth is required",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
@@ -0,0 +1,368 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package commits
+
+import (
+	"testing"
+	"time"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryCommitsWithHeightFilter(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple commits query with height filter",
+		Actions: []any{
+			updateUserCollectionSchema(),
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+						"name":	"John",
+						"age":	21
+					}`,
+			},
+			testUtils.UpdateDoc{
+				CollectionID: 0,
+				DocID:        0,
+				Doc: `{
+					"age":	22
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+						commits(filter: {height: {_ge: 2}}) {
+							height
+							fieldName
+						}
+					}`,
+				Results: []map[string]any{
+					{
+						"height":    int64(2),
+						"fieldName": "age",
+					},
+					{
+						"height":    int64(2),
+						"fieldName": nil,
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQueryCommitsWithFieldNameFilter(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple commits query with field name filter",
+		Actions: []any{
+			updateUserCollectionSchema(),
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+						"name":	"John",
+						"age":	21
+					}`,
+			},
+			testUtils.UpdateDoc{
+				CollectionID: 0,
+				DocID:        0,
+				Doc: `{
+					"age":	22
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+						commits(filter: {fieldName: {_eq: "age"}}) {
+							height
+							fieldName
+						}
+					}`,
+				Results: []map[string]any{
+					{
+						"height":    int64(2),
+						"fieldName": "age",
+					},
+					{
+						"height":    int64(1),
+						"fieldName": "age",
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQueryCommitsWithDockeyAndOrFilter(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple commits query with dockey and compound filter",
+		Actions: []any{
+			updateUserCollectionSchema(),
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+						"name":	"John",
+						"age":	21
+					}`,
+			},
+			testUtils.UpdateDoc{
+				CollectionID: 0,
+				DocID:        0,
+				Doc: `{
+					"age":	22
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+						commits(
+							dockey: "bae-f54b9689-e06e-5e3a-89b3-f3aee8e64ca7",
+							filter: {_or: [{fieldName: {_eq: "name"}}, {height: {_eq: 2}}]}
+						) {
+							height
+							fieldName
+						}
+					}`,
+				Results: []map[string]any{
+					{
+						"height":    int64(2),
+						"fieldName": "age",
+					},
+					{
+						"height":    int64(1),
+						"fieldName": "name",
+					},
+					{
+						"height":    int64(2),
+						"fieldName": nil,
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQueryCommitsWithCollectionIDFilter(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple commits query listing the commits of a collection",
+		Actions: []any{
+			updateUserCollectionSchema(),
+			updateCompaniesCollectionSchema(),
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+						"name":	"John",
+						"age":	21
+					}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 1,
+				Doc: `{
+						"name":	"Source"
+					}`,
+			},
+			testUtils.Request{
+				Request: `query {
+						commits(filter: {collectionID: {_eq: 2}}) {
+							dockey
+							fieldName
+						}
+					}`,
+				Results: []map[string]any{
+					{
+						"dockey":    "bae-de8c99bf-ee0e-5655-8a72-919c2d459a30",
+						"fieldName": "name",
+					},
+					{
+						"dockey":    "bae-de8c99bf-ee0e-5655-8a72-919c2d459a30",
+						"fieldName": nil,
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users", "Companies"}, test)
+}
+
+func TestQueryCommitsWithSchemaVersionIDFilter(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple commits query with schema version id filter",
+		Actions: []any{
+			updateUserCollectionSchema(),
+			updateCompaniesCollectionSchema(),
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+						"name":	"John",
+						"age":	21
+					}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 1,
+				Doc: `{
+						"name":	"Source"
+					}`,
+			},
+			testUtils.Request{
+				Request: `query {
+						commits(
+							filter: {schemaVersionId: {_eq: "bafkreibkgq4h2tmuyozuto7jvprvlx4vgljecbmh364qdiz5o7kh4rtxgi"}}
+						) {
+							collectionID
+							fieldName
+						}
+					}`,
+				Results: []map[string]any{
+					{
+						"collectionID": int64(2),
+						"fieldName":    "name",
+					},
+					{
+						"collectionID": int64(2),
+						"fieldName":    nil,
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users", "Companies"}, test)
+}
+
+func TestQueryCommitsWithNotHeightFilter(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple commits query with not height filter",
+		Actions: []any{
+			updateUserCollectionSchema(),
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+						"name":	"John",
+						"age":	21
+					}`,
+			},
+			testUtils.UpdateDoc{
+				CollectionID: 0,
+				DocID:        0,
+				Doc: `{
+					"age":	22
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+						commits(filter: {_not: {height: {_eq: 1}}}) {
+							height
+							fieldName
+						}
+					}`,
+				Results: []map[string]any{
+					{
+						"height":    int64(2),
+						"fieldName": "age",
+					},
+					{
+						"height":    int64(2),
+						"fieldName": nil,
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQueryCommitsWithFieldNameAndTimeFilter(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple commits query with field name and time filter, commits touching a field since a day",
+		Actions: []any{
+			updateUserCollectionSchema(),
+			testUtils.SetCommitTime{
+				Time: time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC),
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+						"name":	"John",
+						"age":	21
+					}`,
+			},
+			testUtils.SetCommitTime{
+				Time: time.Date(2023, time.January, 2, 12, 0, 0, 0, time.UTC),
+			},
+			testUtils.UpdateDoc{
+				CollectionID: 0,
+				DocID:        0,
+				Doc: `{
+					"age":	22
+				}`,
+			},
+			testUtils.SetCommitTime{
+				Time: time.Date(2023, time.January, 2, 18, 0, 0, 0, time.UTC),
+			},
+			testUtils.UpdateDoc{
+				CollectionID: 0,
+				DocID:        0,
+				Doc: `{
+					"name":	"Johnny"
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+						commits(filter: {fieldName: {_eq: "age"}, time: {_ge: "2023-01-02T00:00:00Z"}}) {
+							height
+							fieldName
+							time
+						}
+					}`,
+				Results: []map[string]any{
+					{
+						"height":    int64(2),
+						"fieldName": "age",
+						"time":      "2023-01-02T12:00:00Z",
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQueryCommitsWithTimeFilterWithoutRecordedTime(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple commits query with time filter, time of commits not recorded",
+		Actions: []any{
+			updateUserCollectionSchema(),
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+						"name":	"John",
+						"age":	21
+					}`,
+			},
+			testUtils.Request{
+				Request: `query {
+						commits(filter: {time: {_ge: "2023-01-02T00:00:00Z"}}) {
+							fieldName
+						}
+					}`,
+				Results: []map[string]any{},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
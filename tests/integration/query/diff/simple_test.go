@@ -0,0 +1,198 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package diff
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryDiffBetweenVersions(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple diff query between two versions",
+		Actions: append(
+			userHistory(),
+			testUtils.Request{
+				Request: `query {
+					diff(
+						dockey: "bae-f54b9689-e06e-5e3a-89b3-f3aee8e64ca7",
+						from: "bafybeig3wrpwi6q7vjchizcwnenslasyxop6wey7jahbiszlubdglfq2fq",
+						to: "bafybeiagnd2vwv5e7wvabbj5d2mtpnaok2waiewwuyoclk6wqml4qp5nc4"
+					) {
+						fieldName
+						kind
+						oldValue
+						newValue
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"fieldName": "age",
+						"kind":      "changed",
+						"oldValue":  "21",
+						"newValue":  "22",
+					},
+					{
+						"fieldName": "verified",
+						"kind":      "added",
+						"oldValue":  nil,
+						"newValue":  "true",
+					},
+				},
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQueryDiffBetweenVersionsInReverse(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple diff query from a later to an earlier version",
+		Actions: append(
+			userHistory(),
+			testUtils.Request{
+				Request: `query {
+					diff(
+						dockey: "bae-f54b9689-e06e-5e3a-89b3-f3aee8e64ca7",
+						from: "bafybeiagnd2vwv5e7wvabbj5d2mtpnaok2waiewwuyoclk6wqml4qp5nc4",
+						to: "bafybeig3wrpwi6q7vjchizcwnenslasyxop6wey7jahbiszlubdglfq2fq"
+					) {
+						fieldName
+						kind
+						oldValue
+						newValue
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"fieldName": "age",
+						"kind":      "changed",
+						"oldValue":  "22",
+						"newValue":  "21",
+					},
+					{
+						"fieldName": "verified",
+						"kind":      "removed",
+						"oldValue":  "true",
+						"newValue":  nil,
+					},
+				},
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQueryDiffBetweenSameVersion(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple diff query between a version and itself",
+		Actions: append(
+			userHistory(),
+			testUtils.Request{
+				Request: `query {
+					diff(
+						dockey: "bae-f54b9689-e06e-5e3a-89b3-f3aee8e64ca7",
+						from: "bafybeiagnd2vwv5e7wvabbj5d2mtpnaok2waiewwuyoclk6wqml4qp5nc4",
+						to: "bafybeiagnd2vwv5e7wvabbj5d2mtpnaok2waiewwuyoclk6wqml4qp5nc4"
+					) {
+						fieldName
+					}
+				}`,
+				Results: []map[string]any{},
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQueryDiffWithAlias(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple diff query with aliased fields",
+		Actions: append(
+			userHistory(),
+			testUtils.Request{
+				Request: `query {
+					diff(
+						dockey: "bae-f54b9689-e06e-5e3a-89b3-f3aee8e64ca7",
+						from: "bafybeig3wrpwi6q7vjchizcwnenslasyxop6wey7jahbiszlubdglfq2fq",
+						to: "bafybeiagnd2vwv5e7wvabbj5d2mtpnaok2waiewwuyoclk6wqml4qp5nc4"
+					) {
+						field: fieldName
+						before: oldValue
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"field":  "age",
+						"before": "21",
+					},
+					{
+						"field":  "verified",
+						"before": nil,
+					},
+				},
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQueryDiffWithVersionOfOtherDocument(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple diff query with a version of another document, errors",
+		Actions: append(
+			userHistory(),
+			testUtils.Request{
+				Request: `query {
+					diff(
+						dockey: "bae-f54b9689-e06e-5e3a-89b3-f3aee8e64ca7",
+						from: "bafybeiey3q4mjqbm4nrz62bavtu6d3mh6msdxmfta5ija3ug5q665v4l6i"
+					) {
+						fieldName
+					}
+				}`,
+				ExpectedError: "the given version is not a commit of the document",
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQueryDiffWithCompactedVersion(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple diff query from a version folded by a history compaction, errors",
+		Actions: append(
+			userHistory(),
+			testUtils.CompactDocs{
+				Depth: 1,
+			},
+			testUtils.Request{
+				Request: `query {
+					diff(
+						dockey: "bae-f54b9689-e06e-5e3a-89b3-f3aee8e64ca7",
+						from: "bafybeig3wrpwi6q7vjchizcwnenslasyxop6wey7jahbiszlubdglfq2fq"
+					) {
+						fieldName
+					}
+				}`,
+				ExpectedError: "the requested version has been compacted",
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
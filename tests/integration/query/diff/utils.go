@@ -0,0 +1,65 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package diff
+
+import (
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+const userCollectionGQLSchema = (`
+	type Users {
+		name: String
+		age: Int
+		verified: Boolean
+	}
+`)
+
+func updateUserCollectionSchema() testUtils.SchemaUpdate {
+	return testUtils.SchemaUpdate{
+		Schema: userCollectionGQLSchema,
+	}
+}
+
+// userHistory creates two users and updates the first one twice. The first two
+// composite commits of the first user are:
+//
+//   - bafybeig3wrpwi6q7vjchizcwnenslasyxop6wey7jahbiszlubdglfq2fq: name John, age 21
+//   - bafybeiagnd2vwv5e7wvabbj5d2mtpnaok2waiewwuyoclk6wqml4qp5nc4: name John, age 22, verified
+//
+// Its current state is then name Johnny, age 22, verified.
+func userHistory() []any {
+	return []any{
+		updateUserCollectionSchema(),
+		testUtils.CreateDoc{
+			Doc: `{
+				"name":	"John",
+				"age":	21
+			}`,
+		},
+		testUtils.CreateDoc{
+			Doc: `{
+				"name":	"Fred",
+				"age":	30
+			}`,
+		},
+		testUtils.UpdateDoc{
+			Doc: `{
+				"age":	22,
+				"verified": true
+			}`,
+		},
+		testUtils.UpdateDoc{
+			Doc: `{
+				"name":	"Johnny"
+			}`,
+		},
+	}
+}
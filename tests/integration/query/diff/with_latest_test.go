@@ -0,0 +1,116 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package diff
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryDiffToLatestByDefault(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple diff query without to, compares with the current state",
+		Actions: append(
+			userHistory(),
+			testUtils.Request{
+				Request: `query {
+					diff(
+						dockey: "bae-f54b9689-e06e-5e3a-89b3-f3aee8e64ca7",
+						from: "bafybeig3wrpwi6q7vjchizcwnenslasyxop6wey7jahbiszlubdglfq2fq"
+					) {
+						fieldName
+						kind
+						oldValue
+						newValue
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"fieldName": "age",
+						"kind":      "changed",
+						"oldValue":  "21",
+						"newValue":  "22",
+					},
+					{
+						"fieldName": "name",
+						"kind":      "changed",
+						"oldValue":  "\"John\"",
+						"newValue":  "\"Johnny\"",
+					},
+					{
+						"fieldName": "verified",
+						"kind":      "added",
+						"oldValue":  nil,
+						"newValue":  "true",
+					},
+				},
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQueryDiffFromLatest(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple diff query from the current state to an earlier version",
+		Actions: append(
+			userHistory(),
+			testUtils.Request{
+				Request: `query {
+					diff(
+						dockey: "bae-f54b9689-e06e-5e3a-89b3-f3aee8e64ca7",
+						from: "latest",
+						to: "bafybeiagnd2vwv5e7wvabbj5d2mtpnaok2waiewwuyoclk6wqml4qp5nc4"
+					) {
+						fieldName
+						kind
+						oldValue
+						newValue
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"fieldName": "name",
+						"kind":      "changed",
+						"oldValue":  "\"Johnny\"",
+						"newValue":  "\"John\"",
+					},
+				},
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQueryDiffWithUnknownDockey(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple diff query with an unknown dockey, errors",
+		Actions: append(
+			userHistory(),
+			testUtils.Request{
+				Request: `query {
+					diff(
+						dockey: "bae-52b9170d-b77a-5887-b877-cbdbb99b009f",
+						from: "latest"
+					) {
+						fieldName
+					}
+				}`,
+				ExpectedError: "no document for the given key exists",
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package inline_array
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryInlineIntegerArrayWithCountDistinct(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, count distinct of integer array",
+		Request: `query {
+					Users {
+						name
+						_count(favouriteIntegers: {distinct: true})
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"name": "Shahzad",
+					"favouriteIntegers": [2, 4, 4, 4, 5, 5, 7, 2]
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"name":   "Shahzad",
+				"_count": 4,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineIntegerArrayWithCountDistinctWithFilterAndLimit(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, count distinct of filtered and limited integer array",
+		Request: `query {
+					Users {
+						name
+						_count(favouriteIntegers: {distinct: true, filter: {_gt: 2}, limit: 2})
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"name": "Shahzad",
+					"favouriteIntegers": [2, 4, 4, 4, 5, 5, 7, 2]
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"name":   "Shahzad",
+				"_count": 2,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineStringArrayWithCountDistinct(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, count distinct of string array",
+		Request: `query {
+					Users {
+						name
+						_count(preferredStrings: {distinct: true})
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"name": "Shahzad",
+					"preferredStrings": ["", "the previous", "the first", "the previous"]
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"name":   "Shahzad",
+				"_count": 3,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
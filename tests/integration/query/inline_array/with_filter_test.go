@@ -0,0 +1,243 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package inline_array
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+var arrayFilterDocs = map[int][]string{
+	0: {
+		`{
+			"name": "Shahzad",
+			"favouriteIntegers": [1, 2, 3],
+			"testScores": [80, null, 65],
+			"preferredStrings": ["urgent", "bug"],
+			"pageHeaders": ["Intro", null]
+		}`,
+		`{
+			"name": "John",
+			"favouriteIntegers": [-1, 5],
+			"testScores": [],
+			"preferredStrings": ["feature"]
+		}`,
+	},
+}
+
+func TestQueryInlineStringArrayWithContainsFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, filtered on string array containing values",
+		Request: `query {
+					Users(filter: {preferredStrings: {_contains: ["urgent"]}}) {
+						name
+					}
+				}`,
+		Docs: arrayFilterDocs,
+		Results: []map[string]any{
+			{
+				"name": "Shahzad",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineStringArrayWithContainsFilterOfMissingValue(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, filtered on string array containing all of the values",
+		Request: `query {
+					Users(filter: {preferredStrings: {_contains: ["urgent", "feature"]}}) {
+						name
+					}
+				}`,
+		Docs:    arrayFilterDocs,
+		Results: []map[string]any{},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineStringArrayWithOverlapsFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, filtered on string array overlapping values",
+		Request: `query {
+					Users(filter: {preferredStrings: {_overlaps: ["urgent", "feature"]}}) {
+						name
+					}
+				}`,
+		Docs: arrayFilterDocs,
+		Results: []map[string]any{
+			{
+				"name": "John",
+			},
+			{
+				"name": "Shahzad",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineIntegerArrayWithAnyFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, filtered on any item of integer array",
+		Request: `query {
+					Users(filter: {favouriteIntegers: {_any: {_lt: 0}}}) {
+						name
+					}
+				}`,
+		Docs: arrayFilterDocs,
+		Results: []map[string]any{
+			{
+				"name": "John",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineIntegerArrayWithAllFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, filtered on all items of integer array",
+		Request: `query {
+					Users(filter: {favouriteIntegers: {_all: {_gt: 0}}}) {
+						name
+					}
+				}`,
+		Docs: arrayFilterDocs,
+		Results: []map[string]any{
+			{
+				"name": "Shahzad",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineNillableIntegerArrayWithNoneFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, filtered on no items of nillable integer array, empty arrays match",
+		Request: `query {
+					Users(filter: {testScores: {_none: {_gt: 70}}}) {
+						name
+					}
+				}`,
+		Docs: arrayFilterDocs,
+		Results: []map[string]any{
+			{
+				"name": "John",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineNillableIntegerArrayWithAnyNullFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, filtered on null items of nillable integer array",
+		Request: `query {
+					Users(filter: {testScores: {_any: {_eq: null}}}) {
+						name
+					}
+				}`,
+		Docs: arrayFilterDocs,
+		Results: []map[string]any{
+			{
+				"name": "Shahzad",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineNillableStringArrayWithAllFilterOnNullArray(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, filtered on all items of nillable string array, null arrays match",
+		Request: `query {
+					Users(filter: {pageHeaders: {_all: {_like: "Intro%"}}}) {
+						name
+					}
+				}`,
+		Docs: arrayFilterDocs,
+		Results: []map[string]any{
+			{
+				"name": "John",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineNillableStringArrayWithContainsNullFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, filtered on nillable string array containing null",
+		Request: `query {
+					Users(filter: {pageHeaders: {_contains: [null, "Intro"]}}) {
+						name
+					}
+				}`,
+		Docs: arrayFilterDocs,
+		Results: []map[string]any{
+			{
+				"name": "Shahzad",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineIntegerArrayWithSumFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, filtered on the sum of an integer array",
+		Request: `query {
+					Users(filter: {_sum: {favouriteIntegers: {_gt: 5}}}) {
+						name
+					}
+				}`,
+		Docs: arrayFilterDocs,
+		Results: []map[string]any{
+			{
+				"name": "Shahzad",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineArrayWithCountFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, filtered on the count of an array",
+		Request: `query {
+					Users(filter: {_count: {testScores: {_eq: 0}}}) {
+						name
+					}
+				}`,
+		Docs: arrayFilterDocs,
+		Results: []map[string]any{
+			{
+				"name": "John",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
@@ -0,0 +1,192 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package inline_array
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryInlineIntegerArrayWithMinAndMax(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, min and max of integer array",
+		Request: `query {
+					Users {
+						name
+						_min(favouriteIntegers: {})
+						_max(favouriteIntegers: {})
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"name": "Shahzad",
+					"favouriteIntegers": [-1, 2, 5, 1, 0, 7]
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"name": "Shahzad",
+				"_min": int64(-1),
+				"_max": int64(7),
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineEmptyIntegerArrayWithMax(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, max of empty integer array",
+		Request: `query {
+					Users {
+						name
+						_max(favouriteIntegers: {})
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"name": "Shahzad",
+					"favouriteIntegers": []
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"name": "Shahzad",
+				"_max": nil,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineIntegerArrayWithMaxWithOffsetWithLimitWithOrderAsc(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, ordered offsetted limited max of integer array",
+		Request: `query {
+					Users {
+						name
+						_max(favouriteIntegers: {offset: 1, limit: 3, order: ASC})
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"name": "Shahzad",
+					"favouriteIntegers": [-1, 2, 5, 1, 0, 7]
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"name": "Shahzad",
+				// 0, 1, 2
+				"_max": int64(2),
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineIntegerArrayWithMinWithFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, filtered min of integer array",
+		Request: `query {
+					Users {
+						name
+						_min(favouriteIntegers: {filter: {_gt: 0}})
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"name": "Shahzad",
+					"favouriteIntegers": [-1, 2, 5, 1, 0, 7]
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"name": "Shahzad",
+				"_min": int64(1),
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineNillableFloatArrayWithMinAndMax(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, min and max of nillable float array, ignoring nil values",
+		Request: `query {
+					Users {
+						name
+						_min(pageRatings: {})
+						_max(pageRatings: {})
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"name": "Shahzad",
+					"pageRatings": [3.1425, null, -0.00000000001, 10]
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"name": "Shahzad",
+				"_min": float64(-0.00000000001),
+				"_max": float64(10),
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineNillableStringArrayWithMinAndMax(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, min and max of nillable string array, ignoring nil values",
+		Request: `query {
+					Users {
+						name
+						_min(pageHeaders: {})
+						_max(pageHeaders: {})
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"name": "Shahzad",
+					"pageHeaders": ["the first", null, "empty", "the last"]
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"name": "Shahzad",
+				"_min": "empty",
+				"_max": "the last",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
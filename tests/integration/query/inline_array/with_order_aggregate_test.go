@@ -0,0 +1,88 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package inline_array
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+var orderAggregateDocs = map[int][]string{
+	0: {
+		`{
+			"name": "Shahzad",
+			"favouriteIntegers": [1, 2, 3]
+		}`,
+		`{
+			"name": "Keenan",
+			"favouriteIntegers": [10]
+		}`,
+		`{
+			"name": "Andy",
+			"favouriteIntegers": [4, 5]
+		}`,
+	},
+}
+
+func TestQueryInlineIntegerArrayWithOrderByCount(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, ordered by count of integer array",
+		Request: `query {
+					Users(order: {_count: {favouriteIntegers: DESC}}) {
+						name
+					}
+				}`,
+		Docs: orderAggregateDocs,
+		Results: []map[string]any{
+			{
+				"name": "Shahzad",
+			},
+			{
+				"name": "Andy",
+			},
+			{
+				"name": "Keenan",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineIntegerArrayWithOrderBySum(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, ordered by sum of integer array",
+		Request: `query {
+					Users(order: {_sum: {favouriteIntegers: ASC}}) {
+						name
+						_sum(favouriteIntegers: {})
+					}
+				}`,
+		Docs: orderAggregateDocs,
+		Results: []map[string]any{
+			{
+				"name": "Shahzad",
+				"_sum": int64(6),
+			},
+			{
+				"name": "Andy",
+				"_sum": int64(9),
+			},
+			{
+				"name": "Keenan",
+				"_sum": int64(10),
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
@@ -0,0 +1,132 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package inline_array
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryInlineIntegerArrayWithVarianceAndStdDev(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, variance and standard deviation of integer array",
+		Request: `query {
+					Users {
+						name
+						_variance(favouriteIntegers: {})
+						_stddev(favouriteIntegers: {})
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"name": "Shahzad",
+					"favouriteIntegers": [2, 4, 4, 4, 5, 5, 7, 9]
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"name":      "Shahzad",
+				"_variance": float64(4),
+				"_stddev":   float64(2),
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineIntegerArrayWithVarianceWithOffsetWithLimitWithOrderAsc(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, ordered offsetted limited variance of integer array",
+		Request: `query {
+					Users {
+						name
+						_variance(favouriteIntegers: {offset: 1, limit: 3, order: ASC})
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"name": "Shahzad",
+					"favouriteIntegers": [-1, 2, 5, 1, 0, 7]
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"name": "Shahzad",
+				// 0, 1, 2
+				"_variance": float64(2) / 3,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineNillableFloatArrayWithStdDevWithFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, filtered standard deviation of nillable float array",
+		Request: `query {
+					Users {
+						name
+						_stddev(pageRatings: {filter: {_gt: 1}})
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"name": "Shahzad",
+					"pageRatings": [3.5, null, 0.5, 7.5]
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"name":    "Shahzad",
+				"_stddev": float64(2),
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineEmptyFloatArrayWithStdDev(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, standard deviation of empty float array",
+		Request: `query {
+					Users {
+						name
+						_stddev(favouriteFloats: {})
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"name": "Shahzad",
+					"favouriteFloats": []
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"name":    "Shahzad",
+				"_stddev": nil,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
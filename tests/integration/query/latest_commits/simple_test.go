@@ -16,8 +16,6 @@ import (
 	testUtils "github.com/sourcenetwork/defradb/tests/integration"
 )
 
-// This test is for documentation reasons only. This is not
-// desired behaviour (should return all latest commits).
 func TestQueryLatestCommits(t *testing.T) {
 	test := testUtils.RequestTestCase{
 		Description: "Simple latest commits query",
@@ -38,7 +36,21 @@ func TestQueryLatestCommits(t *testing.T) {
 				}`,
 			},
 		},
-		ExpectedError: "Field \"latestCommits\" argument \"dockey\" of type \"ID!\" is required but not provided.",
+		Results: []map[string]any{
+			{
+				"cid": "bafybeig3wrpwi6q7vjchizcwnenslasyxop6wey7jahbiszlubdglfq2fq",
+				"links": []map[string]any{
+					{
+						"cid":  "bafybeic5oodfpnixl6uf4bi63m3eouuhj3gafudlsd4tqryhx2wy7rczoe",
+						"name": "age",
+					},
+					{
+						"cid":  "bafybeifukwb3t73k7pph3ctp5khosoycp53ywjl6btravzk6decggkjtl4",
+						"name": "name",
+					},
+				},
+			},
+		},
 	}
 
 	executeTestCase(t, test)
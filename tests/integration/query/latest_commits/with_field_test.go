@@ -16,9 +16,6 @@ import (
 	testUtils "github.com/sourcenetwork/defradb/tests/integration"
 )
 
-// This test is for documentation reasons only. This is not
-// desired behaviour (should return all latest commits for given
-// field in the collection).
 func TestQueryLatestCommitsWithField(t *testing.T) {
 	test := testUtils.RequestTestCase{
 		Description: "Simple latest commits query with field",
@@ -39,15 +36,13 @@ func TestQueryLatestCommitsWithField(t *testing.T) {
 				}`,
 			},
 		},
-		ExpectedError: "Field \"latestCommits\" argument \"dockey\" of type \"ID!\" is required but not provided.",
+		// fieldId takes the id of the field, not its name
+		Results: []map[string]any{},
 	}
 
 	executeTestCase(t, test)
 }
 
-// This test is for documentation reasons only. This is not
-// desired behaviour (should return all latest commits for given
-// field in the collection).
 func TestQueryLatestCommitsWithFieldId(t *testing.T) {
 	test := testUtils.RequestTestCase{
 		Description: "Simple latest commits query with field",
@@ -68,7 +63,12 @@ func TestQueryLatestCommitsWithFieldId(t *testing.T) {
 				}`,
 			},
 		},
-		ExpectedError: "Field \"latestCommits\" argument \"dockey\" of type \"ID!\" is required but not provided.",
+		Results: []map[string]any{
+			{
+				"cid":   "bafybeic5oodfpnixl6uf4bi63m3eouuhj3gafudlsd4tqryhx2wy7rczoe",
+				"links": []map[string]any{},
+			},
+		},
 	}
 
 	executeTestCase(t, test)
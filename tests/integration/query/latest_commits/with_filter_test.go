@@ -0,0 +1,63 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package latest_commits
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryLatestCommitsWithCollectionIDFilter(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple latest commits query listing the heads of a collection",
+		Actions: []any{
+			updateUserCollectionSchema(),
+			updateCompaniesCollectionSchema(),
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"name":	"John",
+					"age":	21
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 1,
+				Doc: `{
+					"name":	"Source"
+				}`,
+			},
+			testUtils.UpdateDoc{
+				CollectionID: 0,
+				DocID:        0,
+				Doc: `{
+					"age":	22
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					latestCommits(filter: {collectionID: {_eq: 1}}) {
+						dockey
+						height
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"dockey": "bae-f54b9689-e06e-5e3a-89b3-f3aee8e64ca7",
+						"height": int64(2),
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users", "Companies"}, test)
+}
@@ -0,0 +1,154 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package one_to_many
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryOneToManyWithCountFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "One-to-many relation query from the many side, filter on the count of the children",
+		Request: `query {
+			Author(filter: {_count: {published: {_gt: 1}}}) {
+				name
+			}
+		}`,
+		Docs: relationFilterDocs,
+		Results: []map[string]any{
+			{
+				"name": "John Grisham",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryOneToManyWithCountFilterMatchingNoChildren(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "One-to-many relation query from the many side, filter on a zero count of the children",
+		Request: `query {
+			Author(filter: {_count: {published: {_eq: 0}}}) {
+				name
+			}
+		}`,
+		Docs: relationFilterDocs,
+		Results: []map[string]any{
+			{
+				"name": "Andrew Lone",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryOneToManyWithSumFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "One-to-many relation query from the many side, filter on the sum of a child field",
+		Request: `query {
+			Author(filter: {_sum: {published: {rating: {_gt: 5}}}}) {
+				name
+			}
+		}`,
+		Docs: relationFilterDocs,
+		Results: []map[string]any{
+			{
+				"name": "John Grisham",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryOneToManyWithAverageFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "One-to-many relation query from the many side, filter on the average of a child field",
+		Request: `query {
+			Author(filter: {_avg: {published: {rating: {_lt: 4.8}}}}) {
+				name
+			}
+		}`,
+		Docs: relationFilterDocs,
+		Results: []map[string]any{
+			{
+				"name": "John Grisham",
+			},
+			{
+				"name": "Andrew Lone",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryOneToManyWithCountFilterAndRenderedCount(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "One-to-many relation query from the many side, filter on the count, with order and limit",
+		Request: `query {
+			Author(filter: {_count: {published: {_gt: 0}}}, order: {age: ASC}, limit: 1) {
+				name
+				_count(published: {})
+			}
+		}`,
+		Docs: relationFilterDocs,
+		Results: []map[string]any{
+			{
+				"name":   "Cornelia Funke",
+				"_count": 1,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryOneToManyWithCountFilterWithinOr(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "One-to-many relation query from the many side, filter on the count within an or",
+		Request: `query {
+			Author(filter: {_or: [{_count: {published: {_gt: 1}}}, {age: {_lt: 40}}]}) {
+				name
+			}
+		}`,
+		Docs: relationFilterDocs,
+		Results: []map[string]any{
+			{
+				"name": "John Grisham",
+			},
+			{
+				"name": "Andrew Lone",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryOneToManyWithCountFilterOnRelatedObject(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "One-to-many relation query from the single side, filter on the count of a related object",
+		Request: `query {
+			Book(filter: {author: {_count: {published: {_gt: 1}}}}) {
+				name
+			}
+		}`,
+		Docs:          relationFilterDocs,
+		ExpectedError: "aggregates of related objects can not be filtered on",
+	}
+
+	executeTestCase(t, test)
+}
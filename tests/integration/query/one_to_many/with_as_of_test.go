@@ -0,0 +1,151 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package one_to_many
+
+import (
+	"testing"
+	"time"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+var (
+	january  = time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	february = time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func TestQueryOneToManyWithAsOfFromOneSide(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "One-to-many relation query from the one side with asOf, child updated later",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: bookAuthorGQLSchema,
+			},
+			testUtils.SetCommitTime{
+				Time: january,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 1,
+				// bae-41598f0c-19bc-5da6-813b-e80f14a10df3
+				Doc: `{
+					"name": "John Grisham",
+					"age": 65,
+					"verified": true
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"name": "Painted House",
+					"rating": 4.9,
+					"author_id": "bae-41598f0c-19bc-5da6-813b-e80f14a10df3"
+				}`,
+			},
+			testUtils.SetCommitTime{
+				Time: february,
+			},
+			testUtils.UpdateDoc{
+				CollectionID: 0,
+				DocID:        0,
+				Doc: `{
+					"rating": 4.5
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Author(asOf: "2023-01-15T00:00:00Z") {
+						name
+						published {
+							name
+							rating
+						}
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "John Grisham",
+						"published": []map[string]any{
+							{
+								"name":   "Painted House",
+								"rating": 4.9,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Book", "Author"}, test)
+}
+
+func TestQueryOneToManyWithAsOfFromManySide(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "One-to-many relation query from the many side with asOf, parent updated later",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: bookAuthorGQLSchema,
+			},
+			testUtils.SetCommitTime{
+				Time: january,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 1,
+				// bae-41598f0c-19bc-5da6-813b-e80f14a10df3
+				Doc: `{
+					"name": "John Grisham",
+					"age": 65,
+					"verified": true
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"name": "Painted House",
+					"rating": 4.9,
+					"author_id": "bae-41598f0c-19bc-5da6-813b-e80f14a10df3"
+				}`,
+			},
+			testUtils.SetCommitTime{
+				Time: february,
+			},
+			testUtils.UpdateDoc{
+				CollectionID: 1,
+				DocID:        0,
+				Doc: `{
+					"age": 66
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Book(asOf: "2023-01-15T00:00:00Z") {
+						name
+						author {
+							name
+							age
+						}
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "Painted House",
+						"author": map[string]any{
+							"name": "John Grisham",
+							"age":  uint64(65),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Book", "Author"}, test)
+}
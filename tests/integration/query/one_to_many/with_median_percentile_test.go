@@ -0,0 +1,81 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package one_to_many
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryOneToManyWithMedianAndPercentile(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "One-to-many relation query from many side with median and percentile",
+		Request: `query {
+				Author {
+					name
+					_median(published: {field: rating})
+					_percentile(p: 25, published: {field: rating})
+				}
+			}`,
+		Docs: minMaxDocs,
+		Results: []map[string]any{
+			{
+				"name": "John Grisham",
+				// 3.2, 4.2, 4.5, 4.9
+				"_median":     4.35,
+				"_percentile": 3.95,
+			},
+			{
+				"name":        "Simon Pelloutier",
+				"_median":     nil,
+				"_percentile": nil,
+			},
+			{
+				"name":        "Cornelia Funke",
+				"_median":     4.8,
+				"_percentile": 4.8,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryOneToManyWithMedianWithLimitWithOrder(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "One-to-many relation query from many side with ordered limited median",
+		Request: `query {
+				Author {
+					name
+					_median(published: {field: rating, limit: 3, order: {rating: DESC}})
+				}
+			}`,
+		Docs: minMaxDocs,
+		Results: []map[string]any{
+			{
+				"name": "John Grisham",
+				// 4.9, 4.5, 4.2
+				"_median": 4.5,
+			},
+			{
+				"name":    "Simon Pelloutier",
+				"_median": nil,
+			},
+			{
+				"name":    "Cornelia Funke",
+				"_median": 4.8,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
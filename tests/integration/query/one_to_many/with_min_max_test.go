@@ -0,0 +1,160 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package one_to_many
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+var minMaxDocs = map[int][]string{
+	//books
+	0: { // bae-fd541c25-229e-5280-b44b-e5c2af3e374d
+		`{
+			"name": "Painted House",
+			"rating": 4.9,
+			"author_id": "bae-41598f0c-19bc-5da6-813b-e80f14a10df3"
+		}`,
+		`{
+			"name": "A Time for Mercy",
+			"rating": 4.5,
+			"author_id": "bae-41598f0c-19bc-5da6-813b-e80f14a10df3"
+		}`,
+		`{
+			"name": "The Associate",
+			"rating": 4.2,
+			"author_id": "bae-41598f0c-19bc-5da6-813b-e80f14a10df3"
+		}`,
+		`{
+			"name": "Sooley",
+			"rating": 3.2,
+			"author_id": "bae-41598f0c-19bc-5da6-813b-e80f14a10df3"
+		}`,
+		`{
+			"name": "Theif Lord",
+			"rating": 4.8,
+			"author_id": "bae-b769708d-f552-5c3d-a402-ccfd7ac7fb04"
+		}`,
+	},
+	//authors
+	1: {
+		// bae-41598f0c-19bc-5da6-813b-e80f14a10df3
+		`{
+			"name": "John Grisham",
+			"age": 65,
+			"verified": true
+		}`,
+		// bae-b769708d-f552-5c3d-a402-ccfd7ac7fb04
+		`{
+			"name": "Cornelia Funke",
+			"age": 62,
+			"verified": false
+		}`,
+		`{
+			"name": "Simon Pelloutier",
+			"age": 90,
+			"verified": false
+		}`,
+	},
+}
+
+func TestQueryOneToManyWithMinAndMax(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "One-to-many relation query from many side with min and max",
+		Request: `query {
+				Author {
+					name
+					_min(published: {field: rating})
+					_max(published: {field: name})
+				}
+			}`,
+		Docs: minMaxDocs,
+		Results: []map[string]any{
+			{
+				"name": "John Grisham",
+				"_min": 3.2,
+				"_max": "The Associate",
+			},
+			{
+				"name": "Simon Pelloutier",
+				"_min": nil,
+				"_max": nil,
+			},
+			{
+				"name": "Cornelia Funke",
+				"_min": 4.8,
+				"_max": "Theif Lord",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryOneToManyWithMaxWithFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "One-to-many relation query from many side with filtered max",
+		Request: `query {
+				Author {
+					name
+					_max(published: {field: rating, filter: {rating: {_lt: 4.6}}})
+				}
+			}`,
+		Docs: minMaxDocs,
+		Results: []map[string]any{
+			{
+				"name": "John Grisham",
+				"_max": 4.5,
+			},
+			{
+				"name": "Simon Pelloutier",
+				"_max": nil,
+			},
+			{
+				"name": "Cornelia Funke",
+				"_max": nil,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryOneToManyWithMinWithLimitWithOrder(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "One-to-many relation query from many side with ordered limited min",
+		Request: `query {
+				Author {
+					name
+					_min(published: {field: rating, limit: 2, order: {name: DESC}})
+				}
+			}`,
+		Docs: minMaxDocs,
+		Results: []map[string]any{
+			{
+				"name": "John Grisham",
+				// The Associate, Sooley
+				"_min": 3.2,
+			},
+			{
+				"name": "Simon Pelloutier",
+				"_min": nil,
+			},
+			{
+				"name": "Cornelia Funke",
+				"_min": 4.8,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
@@ -0,0 +1,118 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package one_to_many
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+var notFilterDocs = map[int][]string{
+	//books
+	0: {
+		`{
+			"name": "Painted House",
+			"rating": 4.9,
+			"author_id": "bae-41598f0c-19bc-5da6-813b-e80f14a10df3"
+		}`,
+		`{
+			"name": "A Time for Mercy",
+			"rating": 4.5,
+			"author_id": "bae-41598f0c-19bc-5da6-813b-e80f14a10df3"
+		}`,
+		`{
+			"name": "Theif Lord",
+			"rating": 4.8,
+			"author_id": "bae-b769708d-f552-5c3d-a402-ccfd7ac7fb04"
+		}`,
+	},
+	//authors
+	1: {
+		// bae-41598f0c-19bc-5da6-813b-e80f14a10df3
+		`{
+			"name": "John Grisham",
+			"age": 65,
+			"verified": true
+		}`,
+		// bae-b769708d-f552-5c3d-a402-ccfd7ac7fb04
+		`{
+			"name": "Cornelia Funke",
+			"age": 62,
+			"verified": false
+		}`,
+	},
+}
+
+func TestQueryOneToManyWithNotFilterOnChild(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "One-to-many relation query from the many side, not filter on unrendered child",
+		Request: `query {
+			Author(filter: {_not: {published: {rating: {_gt: 4.8}}}}) {
+				name
+			}
+		}`,
+		Docs: notFilterDocs,
+		Results: []map[string]any{
+			{
+				"name": "Cornelia Funke",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryOneToManyWithNotFilterOnParentFromSingleSide(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "One-to-many relation query from the single side, not filter on unrendered parent",
+		Request: `query {
+			Book(filter: {_not: {author: {name: {_eq: "John Grisham"}}}}) {
+				name
+			}
+		}`,
+		Docs: notFilterDocs,
+		Results: []map[string]any{
+			{
+				"name": "Theif Lord",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryOneToManyWithNotAndFilterOnParentAndChild(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "One-to-many relation query from the many side, not filter on parent and rendered child",
+		Request: `query {
+			Author(filter: {_not: {_and: [{age: {_gt: 63}}, {published: {rating: {_gt: 4.8}}}]}}) {
+				name
+				published {
+					name
+				}
+			}
+		}`,
+		Docs: notFilterDocs,
+		Results: []map[string]any{
+			{
+				"name": "Cornelia Funke",
+				"published": []map[string]any{
+					{
+						"name": "Theif Lord",
+					},
+				},
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
@@ -0,0 +1,208 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package one_to_many
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryOneToManyWithOrderByCount(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "One-to-many relation query from many side, ordered by child count",
+		Request: `query {
+				Author(order: {_count: {published: DESC}}) {
+					name
+				}
+			}`,
+		Docs: minMaxDocs,
+		Results: []map[string]any{
+			{
+				"name": "John Grisham",
+			},
+			{
+				"name": "Cornelia Funke",
+			},
+			{
+				"name": "Simon Pelloutier",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryOneToManyWithOrderByCountWithLimitAndRequestedCount(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "One-to-many relation query from many side, limited and ordered by requested child count",
+		Request: `query {
+				Author(order: {_count: {published: ASC}}, limit: 2) {
+					name
+					_count(published: {})
+				}
+			}`,
+		Docs: minMaxDocs,
+		Results: []map[string]any{
+			{
+				"name":   "Simon Pelloutier",
+				"_count": 0,
+			},
+			{
+				"name":   "Cornelia Funke",
+				"_count": 1,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryOneToManyWithOrderByCountAndFilterByCount(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "One-to-many relation query from many side, filtered and ordered by child count",
+		Request: `query {
+				Author(filter: {_count: {published: {_gt: 0}}}, order: {_count: {published: ASC}}) {
+					name
+				}
+			}`,
+		Docs: minMaxDocs,
+		Results: []map[string]any{
+			{
+				"name": "Cornelia Funke",
+			},
+			{
+				"name": "John Grisham",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryOneToManyWithOrderBySum(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "One-to-many relation query from many side, ordered by sum of child field",
+		Request: `query {
+				Author(order: {_sum: {published: {rating: ASC}}}) {
+					name
+				}
+			}`,
+		Docs: minMaxDocs,
+		Results: []map[string]any{
+			{
+				"name": "Simon Pelloutier",
+			},
+			{
+				"name": "Cornelia Funke",
+			},
+			{
+				"name": "John Grisham",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryOneToManyWithOrderByAverage(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "One-to-many relation query from many side, ordered by average of child field",
+		Request: `query {
+				Author(order: {_avg: {published: {rating: DESC}}}) {
+					name
+				}
+			}`,
+		Docs: minMaxDocs,
+		Results: []map[string]any{
+			{
+				"name": "Cornelia Funke",
+			},
+			{
+				"name": "John Grisham",
+			},
+			{
+				"name": "Simon Pelloutier",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryOneToManyWithOrderByMax(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "One-to-many relation query from many side, ordered by max of child field",
+		Request: `query {
+				Author(order: {_max: {published: {rating: DESC}}}) {
+					name
+					_max(published: {field: rating})
+				}
+			}`,
+		Docs: minMaxDocs,
+		Results: []map[string]any{
+			{
+				"name": "John Grisham",
+				"_max": 4.9,
+			},
+			{
+				"name": "Cornelia Funke",
+				"_max": 4.8,
+			},
+			{
+				"name": "Simon Pelloutier",
+				"_max": nil,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryOneToManyWithOrderByMinOfString(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "One-to-many relation query from many side, ordered by min of child string field",
+		Request: `query {
+				Author(order: {_min: {published: {name: DESC}}}) {
+					name
+				}
+			}`,
+		Docs: minMaxDocs,
+		Results: []map[string]any{
+			{
+				"name": "Cornelia Funke",
+			},
+			{
+				"name": "John Grisham",
+			},
+			{
+				"name": "Simon Pelloutier",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryOneToManyWithOrderByCountOfRelation(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "One-to-one relation query, ordered by child count of related object",
+		Request: `query {
+				Book(order: {author: {_count: {published: DESC}}}) {
+					name
+				}
+			}`,
+		Docs:          minMaxDocs,
+		ExpectedError: "aggregates of related objects can not be ordered by",
+	}
+
+	executeTestCase(t, test)
+}
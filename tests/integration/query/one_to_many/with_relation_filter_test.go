@@ -0,0 +1,197 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package one_to_many
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+var relationFilterDocs = map[int][]string{
+	//books
+	0: {
+		`{
+			"name": "Painted House",
+			"rating": 4.9,
+			"author_id": "bae-41598f0c-19bc-5da6-813b-e80f14a10df3"
+		}`,
+		`{
+			"name": "A Time for Mercy",
+			"rating": 4.5,
+			"author_id": "bae-41598f0c-19bc-5da6-813b-e80f14a10df3"
+		}`,
+		`{
+			"name": "Theif Lord",
+			"rating": 4.8,
+			"author_id": "bae-b769708d-f552-5c3d-a402-ccfd7ac7fb04"
+		}`,
+	},
+	//authors
+	1: {
+		// bae-41598f0c-19bc-5da6-813b-e80f14a10df3
+		`{
+			"name": "John Grisham",
+			"age": 65,
+			"verified": true
+		}`,
+		// bae-b769708d-f552-5c3d-a402-ccfd7ac7fb04
+		`{
+			"name": "Cornelia Funke",
+			"age": 62,
+			"verified": false
+		}`,
+		`{
+			"name": "Andrew Lone",
+			"age": 30,
+			"verified": true
+		}`,
+	},
+}
+
+func TestQueryOneToManyWithSomeFilterOnChild(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "One-to-many relation query from the many side, some filter on unrendered child",
+		Request: `query {
+			Author(filter: {published: {_some: {rating: {_gt: 4.8}}}}) {
+				name
+			}
+		}`,
+		Docs: relationFilterDocs,
+		Results: []map[string]any{
+			{
+				"name": "John Grisham",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryOneToManyWithEveryFilterOnChild(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "One-to-many relation query from the many side, every filter on unrendered child",
+		Request: `query {
+			Author(filter: {published: {_every: {rating: {_gt: 4.6}}}}) {
+				name
+			}
+		}`,
+		Docs: relationFilterDocs,
+		Results: []map[string]any{
+			{
+				"name": "Cornelia Funke",
+			},
+			{
+				"name": "Andrew Lone",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryOneToManyWithEveryFilterOnRenderedChild(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "One-to-many relation query from the many side, every filter on rendered child",
+		Request: `query {
+			Author(filter: {published: {_every: {rating: {_gt: 4.4}}}, age: {_gt: 60}}) {
+				name
+				published {
+					name
+				}
+			}
+		}`,
+		Docs: relationFilterDocs,
+		Results: []map[string]any{
+			{
+				"name": "John Grisham",
+				"published": []map[string]any{
+					{
+						"name": "Painted House",
+					},
+					{
+						"name": "A Time for Mercy",
+					},
+				},
+			},
+			{
+				"name": "Cornelia Funke",
+				"published": []map[string]any{
+					{
+						"name": "Theif Lord",
+					},
+				},
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryOneToManyWithNoneFilterOnChild(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "One-to-many relation query from the many side, none filter on unrendered child",
+		Request: `query {
+			Author(filter: {published: {_none: {rating: {_lt: 4.6}}}}) {
+				name
+			}
+		}`,
+		Docs: relationFilterDocs,
+		Results: []map[string]any{
+			{
+				"name": "Cornelia Funke",
+			},
+			{
+				"name": "Andrew Lone",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryOneToManyWithEmptyNoneFilterOnChild(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "One-to-many relation query from the many side, empty none filter matches parents without children",
+		Request: `query {
+			Author(filter: {published: {_none: {}}}) {
+				name
+			}
+		}`,
+		Docs: relationFilterDocs,
+		Results: []map[string]any{
+			{
+				"name": "Andrew Lone",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryOneToManyWithEveryAndChildFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "One-to-many relation query from the many side, every filter along with an any child filter",
+		Request: `query {
+			Author(filter: {published: {_every: {rating: {_gt: 4.4}}, name: {_eq: "Painted House"}}}) {
+				name
+			}
+		}`,
+		Docs: relationFilterDocs,
+		Results: []map[string]any{
+			{
+				"name": "John Grisham",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
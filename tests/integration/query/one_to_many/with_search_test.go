@@ -0,0 +1,134 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package one_to_many
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryOneToManyWithSearchOnChild(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "One-to-many relation query from the many side, with full-text search on child",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Book {
+						name: String @index(type: fulltext)
+						author: Author
+					}
+
+					type Author {
+						name: String
+						published: [Book]
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 1,
+				// bae-2edb7fdd-cad7-5ad4-9c7d-6920245a96ed
+				Doc: `{
+					"name": "John Grisham"
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 1,
+				// bae-b6ea52b8-a5a5-5127-b9c0-5df4243457a3
+				Doc: `{
+					"name": "Cornelia Funke"
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"name": "Painted House",
+					"author_id": "bae-2edb7fdd-cad7-5ad4-9c7d-6920245a96ed"
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"name": "A Time for Mercy",
+					"author_id": "bae-2edb7fdd-cad7-5ad4-9c7d-6920245a96ed"
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"name": "The Thief Lord and the House of Houses",
+					"author_id": "bae-b6ea52b8-a5a5-5127-b9c0-5df4243457a3"
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Author(order: {name: DESC}) {
+						name
+						published(filter: {name: {_search: "houses"}}) {
+							name
+						}
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "John Grisham",
+						"published": []map[string]any{
+							{
+								"name": "Painted House",
+							},
+						},
+					},
+					{
+						"name": "Cornelia Funke",
+						"published": []map[string]any{
+							{
+								"name": "The Thief Lord and the House of Houses",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Book", "Author"}, test)
+}
+
+func TestQueryOneToManyWithSearchOnRelatedFieldErrors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "One-to-many relation query from the one side, with full-text search within the relation filter, errors",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Book {
+						name: String @index(type: fulltext)
+						author: Author
+					}
+
+					type Author {
+						name: String
+						published: [Book]
+					}
+				`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Author(filter: {published: {name: {_search: "houses"}}}) {
+						name
+					}
+				}`,
+				ExpectedError: "a full-text search can not be nested within another operator or a relation",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Book", "Author"}, test)
+}
@@ -0,0 +1,381 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package simple
+
+import (
+	"testing"
+	"time"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+var (
+	january  = time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	february = time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC)
+	march    = time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func TestQuerySimpleWithAsOfBeforeUpdate(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with asOf before an update",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: userCollectionGQLSchema,
+			},
+			testUtils.SetCommitTime{
+				Time: january,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "John",
+					"Age": 21
+				}`,
+			},
+			testUtils.SetCommitTime{
+				Time: february,
+			},
+			testUtils.UpdateDoc{
+				Doc: `{
+					"Age": 22
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(asOf: "2023-01-15T00:00:00Z") {
+						Name
+						Age
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "John",
+						"Age":  uint64(21),
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQuerySimpleWithAsOfAfterUpdate(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with asOf after an update",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: userCollectionGQLSchema,
+			},
+			testUtils.SetCommitTime{
+				Time: january,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "John",
+					"Age": 21
+				}`,
+			},
+			testUtils.SetCommitTime{
+				Time: february,
+			},
+			testUtils.UpdateDoc{
+				Doc: `{
+					"Age": 22
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(asOf: "2023-02-01T00:00:00Z") {
+						Name
+						Age
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "John",
+						"Age":  uint64(22),
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQuerySimpleWithAsOfBeforeCreate(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with asOf before the document was created",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: userCollectionGQLSchema,
+			},
+			testUtils.SetCommitTime{
+				Time: february,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "John",
+					"Age": 21
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(asOf: "2023-01-15T00:00:00Z") {
+						Name
+					}
+				}`,
+				Results: []map[string]any{},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQuerySimpleWithAsOfAndFilterOnMultipleDocuments(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with asOf and filter, matching documents by their past state",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: userCollectionGQLSchema,
+			},
+			testUtils.SetCommitTime{
+				Time: january,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "John",
+					"Age": 21
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "Fred",
+					"Age": 40
+				}`,
+			},
+			testUtils.SetCommitTime{
+				Time: february,
+			},
+			testUtils.UpdateDoc{
+				DocID: 0,
+				Doc: `{
+					"Age": 41
+				}`,
+			},
+			testUtils.UpdateDoc{
+				DocID: 1,
+				Doc: `{
+					"Age": 20
+				}`,
+			},
+			testUtils.SetCommitTime{
+				Time: march,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "Shahzad",
+					"Age": 50
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(asOf: "2023-01-15T00:00:00Z", filter: {Age: {_gt: 30}}) {
+						Name
+						Age
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "Fred",
+						"Age":  uint64(40),
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(filter: {Age: {_gt: 30}}) {
+						Name
+						Age
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "John",
+						"Age":  uint64(41),
+					},
+					{
+						"Name": "Shahzad",
+						"Age":  uint64(50),
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQuerySimpleWithAsOfBeforeDelete(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with asOf before the document was deleted",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: userCollectionGQLSchema,
+			},
+			testUtils.SetCommitTime{
+				Time: january,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "John",
+					"Age": 21
+				}`,
+			},
+			testUtils.SetCommitTime{
+				Time: february,
+			},
+			testUtils.DeleteDoc{},
+			testUtils.Request{
+				Request: `query {
+					Users(asOf: "2023-01-15T00:00:00Z") {
+						Name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "John",
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(asOf: "2023-02-15T00:00:00Z") {
+						Name
+					}
+				}`,
+				Results: []map[string]any{},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQuerySimpleWithAsOfAndDocKey(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with asOf and dockey",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: userCollectionGQLSchema,
+			},
+			testUtils.SetCommitTime{
+				Time: january,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "John",
+					"Age": 21
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "Fred",
+					"Age": 40
+				}`,
+			},
+			testUtils.SetCommitTime{
+				Time: february,
+			},
+			testUtils.UpdateDoc{
+				DocID: 0,
+				Doc: `{
+					"Age": 22
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(asOf: "2023-01-15T00:00:00Z", dockey: "bae-52b9170d-b77a-5887-b877-cbdbb99b009f") {
+						Name
+						Age
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "John",
+						"Age":  uint64(21),
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQuerySimpleWithAsOfAndNoRecordedCommitTime(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with asOf, documents without a recorded commit time are not returned",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: userCollectionGQLSchema,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "John",
+					"Age": 21
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(asOf: "2023-01-15T00:00:00Z") {
+						Name
+					}
+				}`,
+				Results: []map[string]any{},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQuerySimpleWithAsOfAndCid(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with asOf and cid",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: userCollectionGQLSchema,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"Name": "John",
+					"Age": 21
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(
+						asOf: "2023-01-15T00:00:00Z",
+						cid: "bafybeicloiyf5zl5k54cjuhfg6rpsj7rnhxswvnuizpagd2kwq4px6aqn4",
+						dockey: "bae-52b9170d-b77a-5887-b877-cbdbb99b009f"
+					) {
+						Name
+					}
+				}`,
+				ExpectedError: "asOf and cid can not be used together",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
@@ -0,0 +1,437 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package simple
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/immutable"
+
+	"github.com/sourcenetwork/defradb/client"
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+// Cursors of the documents created by cursorTestActions, taken from unordered results.
+const (
+	bobCursor   = "gngoYmFlLTE0OTk3YzliLTM1MzctNTQwYS04Y2NiLTBmMDI1YjgwZjFiMYA"
+	aliceCursor = "gngoYmFlLTMyYjEyNWE4LWY5ZGQtNWVlZi04YzBiLWNkNTdlNjZkODNiNIA"
+	johnCursor  = "gngoYmFlLTUyYjkxNzBkLWI3N2EtNTg4Ny1iODc3LWNiZGJiOTliMDA5ZoA"
+	carloCursor = "gngoYmFlLWFmNDU0MWRlLTU4MzMtNTMzNS05YWUyLTdhMjc1ZTBiMWFhOIA"
+)
+
+// Cursors of the documents created by cursorTestActions, taken from results ordered by age.
+const (
+	aliceAgeCursor = "gngoYmFlLTMyYjEyNWE4LWY5ZGQtNWVlZi04YzBiLWNkNTdlNjZkODNiNIET"
+	johnAgeCursor  = "gngoYmFlLTUyYjkxNzBkLWI3N2EtNTg4Ny1iODc3LWNiZGJiOTliMDA5ZoEV"
+)
+
+func cursorTestActions() []any {
+	return []any{
+		testUtils.SchemaUpdate{
+			Schema: userCollectionGQLSchema,
+		},
+		testUtils.CreateDoc{
+			Doc: `{
+				"Name": "John",
+				"Age": 21
+			}`,
+		},
+		testUtils.CreateDoc{
+			Doc: `{
+				"Name": "Bob",
+				"Age": 32
+			}`,
+		},
+		testUtils.CreateDoc{
+			Doc: `{
+				"Name": "Alice",
+				"Age": 19
+			}`,
+		},
+		testUtils.CreateDoc{
+			Doc: `{
+				"Name": "Carlo",
+				"Age": 55
+			}`,
+		},
+	}
+}
+
+func TestQuerySimpleWithLimitReturnsPageInfo(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with limit, returns page info",
+		Actions: append(
+			cursorTestActions(),
+			testUtils.Request{
+				Request: `query {
+					Users(limit: 2) {
+						Name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "Bob",
+					},
+					{
+						"Name": "Alice",
+					},
+				},
+				PageInfo: immutable.Some(client.PageInfo{
+					HasNextPage:     true,
+					HasPreviousPage: false,
+					StartCursor:     bobCursor,
+					EndCursor:       aliceCursor,
+				}),
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQuerySimpleWithAfterCursorAndLimit(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with after cursor and limit",
+		Actions: append(
+			cursorTestActions(),
+			testUtils.Request{
+				Request: `query {
+					Users(after: "` + aliceCursor + `", limit: 1) {
+						Name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "John",
+					},
+				},
+				PageInfo: immutable.Some(client.PageInfo{
+					HasNextPage:     true,
+					HasPreviousPage: true,
+					StartCursor:     johnCursor,
+					EndCursor:       johnCursor,
+				}),
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQuerySimpleWithAfterCursorOnLastPage(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with after cursor, on last page",
+		Actions: append(
+			cursorTestActions(),
+			testUtils.Request{
+				Request: `query {
+					Users(after: "` + aliceCursor + `", limit: 2) {
+						Name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "John",
+					},
+					{
+						"Name": "Carlo",
+					},
+				},
+				PageInfo: immutable.Some(client.PageInfo{
+					HasNextPage:     false,
+					HasPreviousPage: true,
+					StartCursor:     johnCursor,
+					EndCursor:       carloCursor,
+				}),
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQuerySimpleWithAfterCursorOfDeletedDocument(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with after cursor of a document deleted since the previous page",
+		Actions: append(
+			cursorTestActions(),
+			testUtils.DeleteDoc{
+				DocID: 2,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(after: "` + aliceCursor + `") {
+						Name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "John",
+					},
+					{
+						"Name": "Carlo",
+					},
+				},
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQuerySimpleWithBeforeCursorAndLimit(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with before cursor and limit, returns the documents immediately preceding it",
+		Actions: append(
+			cursorTestActions(),
+			testUtils.Request{
+				Request: `query {
+					Users(before: "` + carloCursor + `", limit: 2) {
+						Name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "Alice",
+					},
+					{
+						"Name": "John",
+					},
+				},
+				PageInfo: immutable.Some(client.PageInfo{
+					HasNextPage:     true,
+					HasPreviousPage: true,
+					StartCursor:     aliceCursor,
+					EndCursor:       johnCursor,
+				}),
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQuerySimpleWithBeforeCursorOfDeletedLastDocument(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with before cursor of the last document, deleted since the previous page, has no next page",
+		Actions: append(
+			cursorTestActions(),
+			testUtils.DeleteDoc{
+				DocID: 3,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(before: "` + carloCursor + `", limit: 2) {
+						Name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "Alice",
+					},
+					{
+						"Name": "John",
+					},
+				},
+				PageInfo: immutable.Some(client.PageInfo{
+					HasNextPage:     false,
+					HasPreviousPage: true,
+					StartCursor:     aliceCursor,
+					EndCursor:       johnCursor,
+				}),
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQuerySimpleWithAfterAndBeforeCursors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with after and before cursors",
+		Actions: append(
+			cursorTestActions(),
+			testUtils.Request{
+				Request: `query {
+					Users(after: "` + bobCursor + `", before: "` + carloCursor + `") {
+						Name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "Alice",
+					},
+					{
+						"Name": "John",
+					},
+				},
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQuerySimpleWithOrderAndAfterCursor(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with order and after cursor",
+		Actions: append(
+			cursorTestActions(),
+			testUtils.Request{
+				Request: `query {
+					Users(order: {Age: ASC}, after: "` + aliceAgeCursor + `", limit: 2) {
+						Name
+						Age
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "John",
+						"Age":  uint64(21),
+					},
+					{
+						"Name": "Bob",
+						"Age":  uint64(32),
+					},
+				},
+				PageInfo: immutable.Some(client.PageInfo{
+					HasNextPage:     true,
+					HasPreviousPage: true,
+					StartCursor:     johnAgeCursor,
+					EndCursor:       "gngoYmFlLTE0OTk3YzliLTM1MzctNTQwYS04Y2NiLTBmMDI1YjgwZjFiMYEYIA",
+				}),
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQuerySimpleWithDescendingOrderAndAfterCursor(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with descending order and after cursor",
+		Actions: append(
+			cursorTestActions(),
+			testUtils.Request{
+				Request: `query {
+					Users(order: {Age: DESC}, after: "` + johnAgeCursor + `") {
+						Name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "Alice",
+					},
+				},
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQuerySimpleWithCursorOfDifferentOrder(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with cursor taken from results of a different order, errors",
+		Actions: append(
+			cursorTestActions(),
+			testUtils.Request{
+				Request: `query {
+					Users(after: "` + aliceAgeCursor + `") {
+						Name
+					}
+				}`,
+				ExpectedError: "invalid cursor",
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQuerySimpleWithInvalidCursor(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with invalid cursor, errors",
+		Actions: append(
+			cursorTestActions(),
+			testUtils.Request{
+				Request: `query {
+					Users(after: "not a cursor") {
+						Name
+					}
+				}`,
+				ExpectedError: "invalid cursor",
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQuerySimpleWithCursorAndOffset(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with cursor and offset, errors",
+		Actions: append(
+			cursorTestActions(),
+			testUtils.Request{
+				Request: `query {
+					Users(after: "` + aliceCursor + `", offset: 1) {
+						Name
+					}
+				}`,
+				ExpectedError: "cursors can not be used together with offset",
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQuerySimpleWithCursorAndGroupBy(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with cursor and groupBy, errors",
+		Actions: append(
+			cursorTestActions(),
+			testUtils.Request{
+				Request: `query {
+					Users(groupBy: [Name], before: "` + aliceCursor + `") {
+						Name
+					}
+				}`,
+				ExpectedError: "cursors can not be used together with groupBy",
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQuerySimpleWithCursorAndMultipleSelects(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with cursor in a request with multiple selects, errors",
+		Actions: append(
+			cursorTestActions(),
+			testUtils.Request{
+				Request: `query {
+					first: Users(after: "` + aliceCursor + `") {
+						Name
+					}
+					second: Users {
+						Name
+					}
+				}`,
+				ExpectedError: "cursors can only be used in requests with a single select",
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
@@ -0,0 +1,211 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package simple
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+var distinctDocs = map[int][]string{
+	0: {
+		`{
+			"Name": "John",
+			"Age": 21
+		}`,
+		`{
+			"Name": "Bob",
+			"Age": 32
+		}`,
+		`{
+			"Name": "John",
+			"Age": 32
+		}`,
+		`{
+			"Name": "Alice",
+			"Age": 19
+		}`,
+	},
+}
+
+func TestQuerySimpleWithDistinctOrderedByDistinctField(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with distinct, ordered by the distinct field",
+		Request: `query {
+					Users(distinct: [Name], order: {Name: ASC}) {
+						Name
+					}
+				}`,
+		Docs: distinctDocs,
+		Results: []map[string]any{
+			{
+				"Name": "Alice",
+			},
+			{
+				"Name": "Bob",
+			},
+			{
+				"Name": "John",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithDistinctKeepsFirstInOrder(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with distinct, ordered by a different field",
+		Request: `query {
+					Users(distinct: [Name], order: {Age: DESC}) {
+						Name
+						Age
+					}
+				}`,
+		Docs: distinctDocs,
+		Results: []map[string]any{
+			{
+				"Name": "Bob",
+				"Age":  uint64(32),
+			},
+			{
+				"Name": "John",
+				"Age":  uint64(32),
+			},
+			{
+				"Name": "Alice",
+				"Age":  uint64(19),
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithDistinctOnMultipleFields(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with distinct on multiple fields",
+		Request: `query {
+					Users(distinct: [Name, Age], order: {Age: ASC}) {
+						Name
+						Age
+					}
+				}`,
+		Docs: distinctDocs,
+		Results: []map[string]any{
+			{
+				"Name": "Alice",
+				"Age":  uint64(19),
+			},
+			{
+				"Name": "John",
+				"Age":  uint64(21),
+			},
+			{
+				"Name": "Bob",
+				"Age":  uint64(32),
+			},
+			{
+				"Name": "John",
+				"Age":  uint64(32),
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithDistinctAndLimit(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with distinct and limit, limit applied after distinct",
+		Request: `query {
+					Users(distinct: [Age], order: {Age: DESC}, limit: 2) {
+						Age
+					}
+				}`,
+		Docs: distinctDocs,
+		Results: []map[string]any{
+			{
+				"Age": uint64(32),
+			},
+			{
+				"Age": uint64(21),
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithEmptyDistinct(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with empty distinct",
+		Request: `query {
+					Users(distinct: []) {
+						Name
+					}
+				}`,
+		Docs:          distinctDocs,
+		ExpectedError: "distinct must be given at least one field",
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithDistinctAndGroupBy(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with distinct and groupBy",
+		Request: `query {
+					Users(distinct: [Name], groupBy: [Name]) {
+						Name
+					}
+				}`,
+		Docs:          distinctDocs,
+		ExpectedError: "distinct can not be used together with groupBy",
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithCountDistinct(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query, count distinct",
+		Request: `query {
+					_count(Users: {distinct: [Name]})
+				}`,
+		Docs: distinctDocs,
+		Results: []map[string]any{
+			{
+				"_count": 3,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithCountDistinctAndFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query, count distinct with filter",
+		Request: `query {
+					_count(Users: {distinct: [Age], filter: {Age: {_gt: 20}}})
+				}`,
+		Docs: distinctDocs,
+		Results: []map[string]any{
+			{
+				"_count": 2,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
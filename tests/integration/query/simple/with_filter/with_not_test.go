@@ -0,0 +1,223 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package simple
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQuerySimpleWithNotEqualToXFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with logical compound filter (not)",
+		Request: `query {
+					Users(filter: {_not: {Name: {_eq: "John"}}}) {
+						Name
+						Age
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John",
+					"Age": 21
+				}`,
+				`{
+					"Name": "Bob",
+					"Age": 32
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name": "Bob",
+				"Age":  uint64(32),
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithNotAndFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with logical compound filter (not and)",
+		Request: `query {
+					Users(filter: {_not: {_and: [{Age: {_gt: 20}}, {Age: {_lt: 50}}]}}) {
+						Name
+						Age
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John",
+					"Age": 21
+				}`,
+				`{
+					"Name": "Bob",
+					"Age": 32
+				}`,
+				`{
+					"Name": "Carlo",
+					"Age": 55
+				}`,
+				`{
+					"Name": "Alice",
+					"Age": 19
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name": "Alice",
+				"Age":  uint64(19),
+			},
+			{
+				"Name": "Carlo",
+				"Age":  uint64(55),
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithNotOfMultipleFieldsFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with not filter on multiple fields, only all of them must match",
+		Request: `query {
+					Users(filter: {_not: {Name: {_eq: "John"}, Age: {_eq: 21}}}) {
+						Name
+						Age
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John",
+					"Age": 21
+				}`,
+				`{
+					"Name": "John",
+					"Age": 32
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name": "John",
+				"Age":  uint64(32),
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithNotFilterMatchesNullFields(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with not filter, null fields do not match the inner filter",
+		Request: `query {
+					Users(filter: {_not: {Age: {_gt: 30}}}) {
+						Name
+						Age
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John",
+					"Age": 21
+				}`,
+				`{
+					"Name": "Bob",
+					"Age": 32
+				}`,
+				`{
+					"Name": "Fred"
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name": "Fred",
+				"Age":  nil,
+			},
+			{
+				"Name": "John",
+				"Age":  uint64(21),
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithNotEqualToNullFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with not filter on null",
+		Request: `query {
+					Users(filter: {_not: {Age: {_eq: null}}}) {
+						Name
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John",
+					"Age": 21
+				}`,
+				`{
+					"Name": "Fred"
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name": "John",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithDoubleNotFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with nested not filters",
+		Request: `query {
+					Users(filter: {_not: {_not: {Name: {_eq: "John"}}}}) {
+						Name
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John",
+					"Age": 21
+				}`,
+				`{
+					"Name": "Bob",
+					"Age": 32
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name": "John",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
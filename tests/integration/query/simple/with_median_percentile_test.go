@@ -0,0 +1,215 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package simple
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+var statisticDocs = map[int][]string{
+	0: {
+		`{
+			"Name": "John",
+			"Age": 21,
+			"HeightM": 1.82
+		}`,
+		`{
+			"Name": "Bob",
+			"Age": 32,
+			"HeightM": 1.65
+		}`,
+		`{
+			"Name": "Carlo",
+			"Age": 55,
+			"HeightM": 1.71
+		}`,
+		`{
+			"Name": "Alice",
+			"Age": 19
+		}`,
+	},
+}
+
+func TestQuerySimpleWithMedianOnEmptyCollection(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query, median on empty",
+		Request: `query {
+					_median(Users: {field: Age})
+				}`,
+		Results: []map[string]any{
+			{
+				"_median": nil,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithMedianOfEvenNumberOfValues(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query, median of an even number of values",
+		Request: `query {
+					_median(Users: {field: Age})
+				}`,
+		Docs: statisticDocs,
+		Results: []map[string]any{
+			{
+				// (21 + 32) / 2
+				"_median": float64(26.5),
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithMedianOfOddNumberOfValues(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query, median of an odd number of values, ignoring null values",
+		Request: `query {
+					_median(Users: {field: HeightM})
+				}`,
+		Docs: statisticDocs,
+		Results: []map[string]any{
+			{
+				"_median": float64(1.71),
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithPercentile(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query, percentile",
+		Request: `query {
+					_percentile(p: 75, Users: {field: Age})
+				}`,
+		Docs: statisticDocs,
+		Results: []map[string]any{
+			{
+				// 32 + (55 - 32) * 0.25
+				"_percentile": float64(37.75),
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithPercentileAtBounds(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query, percentile at the lower and upper bounds",
+		Request: `query {
+					lowest: _percentile(p: 0, Users: {field: Age})
+					highest: _percentile(p: 100.0, Users: {field: Age})
+				}`,
+		Docs: statisticDocs,
+		Results: []map[string]any{
+			{
+				"lowest":  float64(19),
+				"highest": float64(55),
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithPercentileWithFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query, percentile with filter",
+		Request: `query {
+					_percentile(p: 50, Users: {field: Age, filter: {Age: {_gt: 20}}})
+				}`,
+		Docs: statisticDocs,
+		Results: []map[string]any{
+			{
+				"_percentile": float64(32),
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithPercentileOutOfRange(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query, percentile out of range",
+		Request: `query {
+					_percentile(p: 101, Users: {field: Age})
+				}`,
+		Docs:          statisticDocs,
+		ExpectedError: "percentile must be between 0 and 100",
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithPercentileWithoutPercentile(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query, percentile without a percentile",
+		Request: `query {
+					_percentile(Users: {field: Age})
+				}`,
+		Docs:          statisticDocs,
+		ExpectedError: "Field \"_percentile\" argument \"p\" of type \"Float!\" is required but not provided.",
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithGroupByNumberWithMedian(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with group by number, median of the groups",
+		Request: `query {
+					Users(groupBy: [Age]) {
+						Age
+						_median(_group: {field: HeightM})
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John",
+					"Age": 32,
+					"HeightM": 1.82
+				}`,
+				`{
+					"Name": "Bob",
+					"Age": 32,
+					"HeightM": 1.62
+				}`,
+				`{
+					"Name": "Alice",
+					"Age": 19
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Age":     uint64(19),
+				"_median": nil,
+			},
+			{
+				"Age": uint64(32),
+				// ...00002 is float math artifact
+				"_median": 1.7200000000000002,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
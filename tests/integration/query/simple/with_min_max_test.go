@@ -0,0 +1,260 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package simple
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+var minMaxDocs = map[int][]string{
+	0: {
+		`{
+			"Name": "John",
+			"Age": 21,
+			"HeightM": 1.82,
+			"CreatedAt": "2017-07-23T03:46:56.647Z"
+		}`,
+		`{
+			"Name": "Bob",
+			"Age": 30,
+			"HeightM": 1.65,
+			"CreatedAt": "2018-07-23T03:46:56.647Z"
+		}`,
+		`{
+			"Name": "Alice",
+			"CreatedAt": "2016-07-23T03:46:56.647Z"
+		}`,
+	},
+}
+
+func TestQuerySimpleWithMinOnEmptyCollection(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query, min on empty",
+		Request: `query {
+					_min(Users: {field: Age})
+				}`,
+		Results: []map[string]any{
+			{
+				"_min": nil,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithMinAndMaxOfInt(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query, min and max of an integer field, ignoring null values",
+		Request: `query {
+					_min(Users: {field: Age})
+					_max(Users: {field: Age})
+				}`,
+		Docs: minMaxDocs,
+		Results: []map[string]any{
+			{
+				"_min": int64(21),
+				"_max": int64(30),
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithMinAndMaxOfFloat(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query, min and max of a float field",
+		Request: `query {
+					_min(Users: {field: HeightM})
+					_max(Users: {field: HeightM})
+				}`,
+		Docs: minMaxDocs,
+		Results: []map[string]any{
+			{
+				"_min": float64(1.65),
+				"_max": float64(1.82),
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithMinAndMaxOfString(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query, min and max of a string field",
+		Request: `query {
+					_min(Users: {field: Name})
+					_max(Users: {field: Name})
+				}`,
+		Docs: minMaxDocs,
+		Results: []map[string]any{
+			{
+				"_min": "Alice",
+				"_max": "John",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithMinAndMaxOfDateTime(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query, min and max of a date time field",
+		Request: `query {
+					_min(Users: {field: CreatedAt})
+					_max(Users: {field: CreatedAt})
+				}`,
+		Docs: minMaxDocs,
+		Results: []map[string]any{
+			{
+				"_min": "2016-07-23T03:46:56.647Z",
+				"_max": "2018-07-23T03:46:56.647Z",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithMinAndMaxOfDateTimeWithDifferentOffsets(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query, min and max of date times with different offsets",
+		Request: `query {
+					_min(Users: {field: CreatedAt})
+					_max(Users: {field: CreatedAt})
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John",
+					"CreatedAt": "2021-01-01T10:00:00+05:00"
+				}`,
+				`{
+					"Name": "Bob",
+					"CreatedAt": "2021-01-01T06:00:00Z"
+				}`,
+				`{
+					"Name": "Alice",
+					"CreatedAt": "2021-01-01T05:30:00Z"
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"_min": "2021-01-01T10:00:00+05:00",
+				"_max": "2021-01-01T06:00:00Z",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithGroupByStringWithMinAndMaxOfDateTimeWithDifferentPrecisions(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with group by string, min and max of date times with fractional seconds",
+		Request: `query {
+					Users(groupBy: [Name]) {
+						Name
+						_min(_group: {field: CreatedAt})
+						_max(_group: {field: CreatedAt})
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John",
+					"CreatedAt": "2021-01-01T06:00:00Z"
+				}`,
+				`{
+					"Name": "John",
+					"CreatedAt": "2021-01-01T06:00:00.5Z"
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name": "John",
+				"_min": "2021-01-01T06:00:00Z",
+				"_max": "2021-01-01T06:00:00.5Z",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithMaxWithFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query, max with filter",
+		Request: `query {
+					_max(Users: {field: Age, filter: {Age: {_lt: 30}}})
+				}`,
+		Docs: minMaxDocs,
+		Results: []map[string]any{
+			{
+				"_max": int64(21),
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithGroupByNumberWithMinAndMax(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with group by number, min and max of the groups",
+		Request: `query {
+					Users(groupBy: [Age]) {
+						Age
+						_min(_group: {field: Name})
+						_max(_group: {field: HeightM})
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John",
+					"Age": 32,
+					"HeightM": 1.82
+				}`,
+				`{
+					"Name": "Bob",
+					"Age": 32,
+					"HeightM": 1.65
+				}`,
+				`{
+					"Name": "Alice",
+					"Age": 19
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Age":  uint64(19),
+				"_min": "Alice",
+				"_max": nil,
+			},
+			{
+				"Age":  uint64(32),
+				"_min": "Bob",
+				"_max": float64(1.82),
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
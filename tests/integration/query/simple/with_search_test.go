@@ -0,0 +1,388 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package simple
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func searchTestActions() []any {
+	return []any{
+		testUtils.SchemaUpdate{
+			Schema: `
+				type Users {
+					Name: String
+					Age: Int
+					Bio: String @index(type: fulltext)
+				}
+			`,
+		},
+		testUtils.CreateDoc{
+			Doc: `{
+				"Name": "John",
+				"Age": 21,
+				"Bio": "Likes running and hiking in the mountains"
+			}`,
+		},
+		testUtils.CreateDoc{
+			Doc: `{
+				"Name": "Bob",
+				"Age": 32,
+				"Bio": "Runs a small bakery"
+			}`,
+		},
+		testUtils.CreateDoc{
+			Doc: `{
+				"Name": "Alice",
+				"Age": 19,
+				"Bio": "Running, running and more running"
+			}`,
+		},
+		testUtils.CreateDoc{
+			Doc: `{
+				"Name": "Carlo",
+				"Age": 55,
+				"Bio": "Collects stamps"
+			}`,
+		},
+	}
+}
+
+func TestQuerySimpleWithSearchReturnsRankedResults(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with full-text search, returns results ranked by relevance",
+		Actions: append(
+			searchTestActions(),
+			testUtils.Request{
+				Request: `query {
+					Users(filter: {Bio: {_search: "run"}}) {
+						Name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "Alice",
+					},
+					{
+						"Name": "Bob",
+					},
+					{
+						"Name": "John",
+					},
+				},
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQuerySimpleWithSearchIgnoresCaseAndWordForm(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with full-text search, matches regardless of case and word form",
+		Actions: append(
+			searchTestActions(),
+			testUtils.Request{
+				Request: `query {
+					Users(filter: {Bio: {_search: "HIKES"}}) {
+						Name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "John",
+					},
+				},
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQuerySimpleWithSearchMatchesAnyTerm(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with full-text search of many terms, matches documents with any of the terms",
+		Actions: append(
+			searchTestActions(),
+			testUtils.Request{
+				Request: `query {
+					Users(filter: {Bio: {_search: "bakery stamp"}}) {
+						Name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "Carlo",
+					},
+					{
+						"Name": "Bob",
+					},
+				},
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQuerySimpleWithSearchNoMatches(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with full-text search, no documents match",
+		Actions: append(
+			searchTestActions(),
+			testUtils.Request{
+				Request: `query {
+					Users(filter: {Bio: {_search: "swimming"}}) {
+						Name
+					}
+				}`,
+				Results: []map[string]any{},
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQuerySimpleWithSearchAndFilter(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with full-text search and a filter on another field",
+		Actions: append(
+			searchTestActions(),
+			testUtils.Request{
+				Request: `query {
+					Users(filter: {Bio: {_search: "run"}, Age: {_gt: 20}}) {
+						Name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "Bob",
+					},
+					{
+						"Name": "John",
+					},
+				},
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQuerySimpleWithSearchAndLimit(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with full-text search and limit, returns the most relevant results",
+		Actions: append(
+			searchTestActions(),
+			testUtils.Request{
+				Request: `query {
+					Users(filter: {Bio: {_search: "run"}}, limit: 2) {
+						Name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "Alice",
+					},
+					{
+						"Name": "Bob",
+					},
+				},
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQuerySimpleWithSearchAndOrder(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with full-text search and order, results are ordered by the given field",
+		Actions: append(
+			searchTestActions(),
+			testUtils.Request{
+				Request: `query {
+					Users(filter: {Bio: {_search: "run"}}, order: {Age: DESC}) {
+						Name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "Bob",
+					},
+					{
+						"Name": "John",
+					},
+					{
+						"Name": "Alice",
+					},
+				},
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQuerySimpleWithSearchAfterUpdate(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with full-text search, after updating an indexed value",
+		Actions: append(
+			searchTestActions(),
+			testUtils.UpdateDoc{
+				DocID: 3,
+				Doc: `{
+					"Bio": "Runs marathons"
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(filter: {Bio: {_search: "run"}}) {
+						Name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "Alice",
+					},
+					{
+						"Name": "Carlo",
+					},
+					{
+						"Name": "Bob",
+					},
+					{
+						"Name": "John",
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(filter: {Bio: {_search: "stamps"}}) {
+						Name
+					}
+				}`,
+				Results: []map[string]any{},
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQuerySimpleWithSearchAfterDelete(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with full-text search, after deleting a matching document",
+		Actions: append(
+			searchTestActions(),
+			testUtils.DeleteDoc{
+				DocID: 2,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(filter: {Bio: {_search: "run"}}) {
+						Name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"Name": "Bob",
+					},
+					{
+						"Name": "John",
+					},
+				},
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQuerySimpleWithSearchOnFieldWithoutIndexErrors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with full-text search of a field without an index, errors",
+		Actions: append(
+			searchTestActions(),
+			testUtils.Request{
+				Request: `query {
+					Users(filter: {Name: {_search: "ALICE"}}) {
+						Name
+					}
+				}`,
+				ExpectedError: "a full-text search requires a full-text index on the field. Field: Name",
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQuerySimpleWithSearchWithinOrErrors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with full-text search within an or, errors",
+		Actions: append(
+			searchTestActions(),
+			testUtils.Request{
+				Request: `query {
+					Users(filter: {_or: [{Bio: {_search: "run"}}, {Age: {_gt: 20}}]}) {
+						Name
+					}
+				}`,
+				ExpectedError: "a full-text search can not be nested within another operator or a relation",
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQuerySimpleWithSearchWithinNotErrors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with full-text search within a not, errors",
+		Actions: append(
+			searchTestActions(),
+			testUtils.Request{
+				Request: `query {
+					Users(filter: {_not: {Bio: {_search: "run"}}}) {
+						Name
+					}
+				}`,
+				ExpectedError: "a full-text search can not be nested within another operator or a relation",
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestQuerySimpleWithSearchAndCursorErrors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query with full-text search and a cursor, errors",
+		Actions: append(
+			searchTestActions(),
+			testUtils.Request{
+				Request: `query {
+					Users(filter: {Bio: {_search: "run"}}, after: "gngoYmFlLTE0OTk3YzliLTM1MzctNTQwYS04Y2NiLTBmMDI1YjgwZjFiMYA") {
+						Name
+					}
+				}`,
+				ExpectedError: "cursors can not be used together with a full-text search",
+			},
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
@@ -0,0 +1,103 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package simple
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQuerySimpleWithVarianceOnEmptyCollection(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query, variance on empty",
+		Request: `query {
+					_variance(Users: {field: Age})
+				}`,
+		Results: []map[string]any{
+			{
+				"_variance": nil,
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithVarianceAndStdDev(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query, variance and standard deviation, ignoring null values",
+		Request: `query {
+					_variance(Users: {field: Age})
+					_stddev(Users: {field: Age})
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John",
+					"Age": 20
+				}`,
+				`{
+					"Name": "Bob",
+					"Age": 24
+				}`,
+				`{
+					"Name": "Carlo",
+					"Age": 28
+				}`,
+				`{
+					"Name": "Alice"
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				// mean is 24, ((-4)^2 + 0^2 + 4^2) / 3
+				"_variance": float64(32) / 3,
+				"_stddev":   float64(3.265986323710904),
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithStdDevWithFilter(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query, standard deviation with filter",
+		Request: `query {
+					_stddev(Users: {field: Age, filter: {Age: {_gt: 21}}})
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John",
+					"Age": 20
+				}`,
+				`{
+					"Name": "Bob",
+					"Age": 24
+				}`,
+				`{
+					"Name": "Carlo",
+					"Age": 28
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"_stddev": float64(2),
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
@@ -62,6 +62,12 @@ func TestSchemaAggregateInlineArrayCreatesUsersCount(t *testing.T) {
 										"type": map[string]any{
 											"name": "Users__favouriteIntegers__CountSelector",
 											"inputFields": []any{
+												map[string]any{
+													"name": "distinct",
+													"type": map[string]any{
+														"name": "Boolean",
+													},
+												},
 												map[string]any{
 													"name": "filter",
 													"type": map[string]any{
@@ -88,6 +94,12 @@ func TestSchemaAggregateInlineArrayCreatesUsersCount(t *testing.T) {
 										"type": map[string]any{
 											"name": "Users__CountSelector",
 											"inputFields": []any{
+												map[string]any{
+													"name": "distinct",
+													"type": map[string]any{
+														"name": nil,
+													},
+												},
 												map[string]any{
 													"name": "filter",
 													"type": map[string]any{
@@ -388,59 +400,103 @@ func TestSchemaAggregateInlineArrayCreatesUsersAverage(t *testing.T) {
 }
 */
 
-var aggregateGroupArg = map[string]any{
-	"name": "_group",
-	"type": map[string]any{
-		"name": "Users__CountSelector",
-		"inputFields": []any{
-			map[string]any{
-				"name": "filter",
-				"type": map[string]any{
-					"name": "UsersFilterArg",
-					"inputFields": []any{
-						map[string]any{
-							"name": "_and",
-							"type": map[string]any{
-								"name": nil,
-							},
-						},
-						map[string]any{
-							"name": "_key",
-							"type": map[string]any{
-								"name": "IDOperatorBlock",
-							},
-						},
-						map[string]any{
-							"name": "_not",
-							"type": map[string]any{
-								"name": "UsersFilterArg",
-							},
-						},
-						map[string]any{
-							"name": "_or",
-							"type": map[string]any{
-								"name": nil,
-							},
-						},
+// newAggregateGroupArg returns the expected group argument of the aggregates, with the filter
+// on the Favourites inline array of the given operator block.
+func newAggregateGroupArg(favouritesOperatorBlock string, isNumeric bool) map[string]any {
+	filterFields := []any{
+		map[string]any{
+			"name": "Favourites",
+			"type": map[string]any{
+				"name": favouritesOperatorBlock,
+			},
+		},
+		map[string]any{
+			"name": "_and",
+			"type": map[string]any{
+				"name": nil,
+			},
+		},
+	}
+	if isNumeric {
+		filterFields = append(filterFields, map[string]any{
+			"name": "_avg",
+			"type": map[string]any{
+				"name": "UsersNumericAggregateFilterArg",
+			},
+		})
+	}
+	filterFields = append(
+		filterFields,
+		map[string]any{
+			"name": "_count",
+			"type": map[string]any{
+				"name": "UsersCountFilterArg",
+			},
+		},
+		map[string]any{
+			"name": "_key",
+			"type": map[string]any{
+				"name": "IDOperatorBlock",
+			},
+		},
+		map[string]any{
+			"name": "_not",
+			"type": map[string]any{
+				"name": "UsersFilterArg",
+			},
+		},
+		map[string]any{
+			"name": "_or",
+			"type": map[string]any{
+				"name": nil,
+			},
+		},
+	)
+	if isNumeric {
+		filterFields = append(filterFields, map[string]any{
+			"name": "_sum",
+			"type": map[string]any{
+				"name": "UsersNumericAggregateFilterArg",
+			},
+		})
+	}
+
+	return map[string]any{
+		"name": "_group",
+		"type": map[string]any{
+			"name": "Users__CountSelector",
+			"inputFields": []any{
+				map[string]any{
+					"name": "distinct",
+					"type": map[string]any{
+						"name":        nil,
+						"inputFields": nil,
 					},
 				},
-			},
-			map[string]any{
-				"name": "limit",
-				"type": map[string]any{
-					"name":        "Int",
-					"inputFields": nil,
+				map[string]any{
+					"name": "filter",
+					"type": map[string]any{
+						"name":        "UsersFilterArg",
+						"inputFields": filterFields,
+					},
 				},
-			},
-			map[string]any{
-				"name": "offset",
-				"type": map[string]any{
-					"name":        "Int",
-					"inputFields": nil,
+				map[string]any{
+					"name": "limit",
+					"type": map[string]any{
+						"name":        "Int",
+						"inputFields": nil,
+					},
+				},
+				map[string]any{
+					"name": "offset",
+					"type": map[string]any{
+						"name":        "Int",
+						"inputFields": nil,
+					},
 				},
 			},
 		},
-	},
+	}
 }
 
 var aggregateVersionArg = map[string]any{
@@ -517,6 +573,13 @@ func TestSchemaAggregateInlineArrayCreatesUsersNillableBooleanCountFilter(t *tes
 										"type": map[string]any{
 											"name": "Users__Favourites__CountSelector",
 											"inputFields": []any{
+												map[string]any{
+													"name": "distinct",
+													"type": map[string]any{
+														"name":        "Boolean",
+														"inputFields": nil,
+													},
+												},
 												map[string]any{
 													"name": "filter",
 													"type": map[string]any{
@@ -552,6 +615,12 @@ func TestSchemaAggregateInlineArrayCreatesUsersNillableBooleanCountFilter(t *tes
 																	"name": nil,
 																},
 															},
+															map[string]any{
+																"name": "_not",
+																"type": map[string]any{
+																	"name": "BooleanFilterArg",
+																},
+															},
 															map[string]any{
 																"name": "_or",
 																"type": map[string]any{
@@ -578,7 +647,7 @@ func TestSchemaAggregateInlineArrayCreatesUsersNillableBooleanCountFilter(t *tes
 											},
 										},
 									},
-									aggregateGroupArg,
+									newAggregateGroupArg("BooleanListOperatorBlock", false),
 									aggregateVersionArg,
 								},
 							},
@@ -643,6 +712,13 @@ func TestSchemaAggregateInlineArrayCreatesUsersBooleanCountFilter(t *testing.T)
 										"type": map[string]any{
 											"name": "Users__Favourites__CountSelector",
 											"inputFields": []any{
+												map[string]any{
+													"name": "distinct",
+													"type": map[string]any{
+														"name":        "Boolean",
+														"inputFields": nil,
+													},
+												},
 												map[string]any{
 													"name": "filter",
 													"type": map[string]any{
@@ -678,6 +754,12 @@ func TestSchemaAggregateInlineArrayCreatesUsersBooleanCountFilter(t *testing.T)
 																	"name": nil,
 																},
 															},
+															map[string]any{
+																"name": "_not",
+																"type": map[string]any{
+																	"name": "NotNullBooleanFilterArg",
+																},
+															},
 															map[string]any{
 																"name": "_or",
 																"type": map[string]any{
@@ -704,7 +786,7 @@ func TestSchemaAggregateInlineArrayCreatesUsersBooleanCountFilter(t *testing.T)
 											},
 										},
 									},
-									aggregateGroupArg,
+									newAggregateGroupArg("NotNullBooleanListOperatorBlock", false),
 									aggregateVersionArg,
 								},
 							},
@@ -769,6 +851,13 @@ func TestSchemaAggregateInlineArrayCreatesUsersNillableIntegerCountFilter(t *tes
 										"type": map[string]any{
 											"name": "Users__Favourites__CountSelector",
 											"inputFields": []any{
+												map[string]any{
+													"name": "distinct",
+													"type": map[string]any{
+														"name":        "Boolean",
+														"inputFields": nil,
+													},
+												},
 												map[string]any{
 													"name": "filter",
 													"type": map[string]any{
@@ -828,6 +917,12 @@ func TestSchemaAggregateInlineArrayCreatesUsersNillableIntegerCountFilter(t *tes
 																	"name": nil,
 																},
 															},
+															map[string]any{
+																"name": "_not",
+																"type": map[string]any{
+																	"name": "IntFilterArg",
+																},
+															},
 															map[string]any{
 																"name": "_or",
 																"type": map[string]any{
@@ -854,7 +949,7 @@ func TestSchemaAggregateInlineArrayCreatesUsersNillableIntegerCountFilter(t *tes
 											},
 										},
 									},
-									aggregateGroupArg,
+									newAggregateGroupArg("IntListOperatorBlock", true),
 									aggregateVersionArg,
 								},
 							},
@@ -919,6 +1014,13 @@ func TestSchemaAggregateInlineArrayCreatesUsersIntegerCountFilter(t *testing.T)
 										"type": map[string]any{
 											"name": "Users__Favourites__CountSelector",
 											"inputFields": []any{
+												map[string]any{
+													"name": "distinct",
+													"type": map[string]any{
+														"name":        "Boolean",
+														"inputFields": nil,
+													},
+												},
 												map[string]any{
 													"name": "filter",
 													"type": map[string]any{
@@ -978,6 +1080,12 @@ func TestSchemaAggregateInlineArrayCreatesUsersIntegerCountFilter(t *testing.T)
 																	"name": nil,
 																},
 															},
+															map[string]any{
+																"name": "_not",
+																"type": map[string]any{
+																	"name": "NotNullIntFilterArg",
+																},
+															},
 															map[string]any{
 																"name": "_or",
 																"type": map[string]any{
@@ -1004,7 +1112,7 @@ func TestSchemaAggregateInlineArrayCreatesUsersIntegerCountFilter(t *testing.T)
 											},
 										},
 									},
-									aggregateGroupArg,
+									newAggregateGroupArg("NotNullIntListOperatorBlock", true),
 									aggregateVersionArg,
 								},
 							},
@@ -1069,6 +1177,13 @@ func TestSchemaAggregateInlineArrayCreatesUsersNillableFloatCountFilter(t *testi
 										"type": map[string]any{
 											"name": "Users__Favourites__CountSelector",
 											"inputFields": []any{
+												map[string]any{
+													"name": "distinct",
+													"type": map[string]any{
+														"name":        "Boolean",
+														"inputFields": nil,
+													},
+												},
 												map[string]any{
 													"name": "filter",
 													"type": map[string]any{
@@ -1128,6 +1243,12 @@ func TestSchemaAggregateInlineArrayCreatesUsersNillableFloatCountFilter(t *testi
 																	"name": nil,
 																},
 															},
+															map[string]any{
+																"name": "_not",
+																"type": map[string]any{
+																	"name": "FloatFilterArg",
+																},
+															},
 															map[string]any{
 																"name": "_or",
 																"type": map[string]any{
@@ -1154,7 +1275,7 @@ func TestSchemaAggregateInlineArrayCreatesUsersNillableFloatCountFilter(t *testi
 											},
 										},
 									},
-									aggregateGroupArg,
+									newAggregateGroupArg("FloatListOperatorBlock", true),
 									aggregateVersionArg,
 								},
 							},
@@ -1219,6 +1340,13 @@ func TestSchemaAggregateInlineArrayCreatesUsersFloatCountFilter(t *testing.T) {
 										"type": map[string]any{
 											"name": "Users__Favourites__CountSelector",
 											"inputFields": []any{
+												map[string]any{
+													"name": "distinct",
+													"type": map[string]any{
+														"name":        "Boolean",
+														"inputFields": nil,
+													},
+												},
 												map[string]any{
 													"name": "filter",
 													"type": map[string]any{
@@ -1278,6 +1406,12 @@ func TestSchemaAggregateInlineArrayCreatesUsersFloatCountFilter(t *testing.T) {
 																	"name": nil,
 																},
 															},
+															map[string]any{
+																"name": "_not",
+																"type": map[string]any{
+																	"name": "NotNullFloatFilterArg",
+																},
+															},
 															map[string]any{
 																"name": "_or",
 																"type": map[string]any{
@@ -1304,7 +1438,7 @@ func TestSchemaAggregateInlineArrayCreatesUsersFloatCountFilter(t *testing.T) {
 											},
 										},
 									},
-									aggregateGroupArg,
+									newAggregateGroupArg("NotNullFloatListOperatorBlock", true),
 									aggregateVersionArg,
 								},
 							},
@@ -1369,6 +1503,13 @@ func TestSchemaAggregateInlineArrayCreatesUsersNillableStringCountFilter(t *test
 										"type": map[string]any{
 											"name": "Users__Favourites__CountSelector",
 											"inputFields": []any{
+												map[string]any{
+													"name": "distinct",
+													"type": map[string]any{
+														"name":        "Boolean",
+														"inputFields": nil,
+													},
+												},
 												map[string]any{
 													"name": "filter",
 													"type": map[string]any{
@@ -1386,6 +1527,12 @@ func TestSchemaAggregateInlineArrayCreatesUsersNillableStringCountFilter(t *test
 																	"name": "String",
 																},
 															},
+															map[string]any{
+																"name": "_ilike",
+																"type": map[string]any{
+																	"name": "String",
+																},
+															},
 															map[string]any{
 																"name": "_in",
 																"type": map[string]any{
@@ -1404,6 +1551,12 @@ func TestSchemaAggregateInlineArrayCreatesUsersNillableStringCountFilter(t *test
 																	"name": "String",
 																},
 															},
+															map[string]any{
+																"name": "_nilike",
+																"type": map[string]any{
+																	"name": "String",
+																},
+															},
 															map[string]any{
 																"name": "_nin",
 																"type": map[string]any{
@@ -1416,12 +1569,36 @@ func TestSchemaAggregateInlineArrayCreatesUsersNillableStringCountFilter(t *test
 																	"name": "String",
 																},
 															},
+															map[string]any{
+																"name": "_not",
+																"type": map[string]any{
+																	"name": "StringFilterArg",
+																},
+															},
+															map[string]any{
+																"name": "_nregex",
+																"type": map[string]any{
+																	"name": "String",
+																},
+															},
 															map[string]any{
 																"name": "_or",
 																"type": map[string]any{
 																	"name": nil,
 																},
 															},
+															map[string]any{
+																"name": "_regex",
+																"type": map[string]any{
+																	"name": "String",
+																},
+															},
+															map[string]any{
+																"name": "_search",
+																"type": map[string]any{
+																	"name": "String",
+																},
+															},
 														},
 													},
 												},
@@ -1442,7 +1619,7 @@ func TestSchemaAggregateInlineArrayCreatesUsersNillableStringCountFilter(t *test
 											},
 										},
 									},
-									aggregateGroupArg,
+									newAggregateGroupArg("StringListOperatorBlock", false),
 									aggregateVersionArg,
 								},
 							},
@@ -1507,6 +1684,13 @@ func TestSchemaAggregateInlineArrayCreatesUsersStringCountFilter(t *testing.T) {
 										"type": map[string]any{
 											"name": "Users__Favourites__CountSelector",
 											"inputFields": []any{
+												map[string]any{
+													"name": "distinct",
+													"type": map[string]any{
+														"name":        "Boolean",
+														"inputFields": nil,
+													},
+												},
 												map[string]any{
 													"name": "filter",
 													"type": map[string]any{
@@ -1524,6 +1708,12 @@ func TestSchemaAggregateInlineArrayCreatesUsersStringCountFilter(t *testing.T) {
 																	"name": "String",
 																},
 															},
+															map[string]any{
+																"name": "_ilike",
+																"type": map[string]any{
+																	"name": "String",
+																},
+															},
 															map[string]any{
 																"name": "_in",
 																"type": map[string]any{
@@ -1542,6 +1732,12 @@ func TestSchemaAggregateInlineArrayCreatesUsersStringCountFilter(t *testing.T) {
 																	"name": "String",
 																},
 															},
+															map[string]any{
+																"name": "_nilike",
+																"type": map[string]any{
+																	"name": "String",
+																},
+															},
 															map[string]any{
 																"name": "_nin",
 																"type": map[string]any{
@@ -1554,12 +1750,36 @@ func TestSchemaAggregateInlineArrayCreatesUsersStringCountFilter(t *testing.T) {
 																	"name": "String",
 																},
 															},
+															map[string]any{
+																"name": "_not",
+																"type": map[string]any{
+																	"name": "NotNullStringFilterArg",
+																},
+															},
+															map[string]any{
+																"name": "_nregex",
+																"type": map[string]any{
+																	"name": "String",
+																},
+															},
 															map[string]any{
 																"name": "_or",
 																"type": map[string]any{
 																	"name": nil,
 																},
 															},
+															map[string]any{
+																"name": "_regex",
+																"type": map[string]any{
+																	"name": "String",
+																},
+															},
+															map[string]any{
+																"name": "_search",
+																"type": map[string]any{
+																	"name": "String",
+																},
+															},
 														},
 													},
 												},
@@ -1580,7 +1800,7 @@ func TestSchemaAggregateInlineArrayCreatesUsersStringCountFilter(t *testing.T) {
 											},
 										},
 									},
-									aggregateGroupArg,
+									newAggregateGroupArg("NotNullStringListOperatorBlock", false),
 									aggregateVersionArg,
 								},
 							},
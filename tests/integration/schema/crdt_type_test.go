@@ -0,0 +1,184 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/defradb/client"
+	schemaTypes "github.com/sourcenetwork/defradb/request/graphql/schema/types"
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestSchemaCRDTTypeWithLWWDirective(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema, field with explicit lww crdt type",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String @crdt(type: lww)
+					}
+				`,
+			},
+			testUtils.IntrospectionRequest{
+				Request: `
+					query {
+						__type (name: "Users") {
+							name
+							fields {
+								name
+								description
+							}
+						}
+					}
+				`,
+				ContainsData: map[string]any{
+					"__type": map[string]any{
+						"name": "Users",
+						"fields": []any{
+							map[string]any{
+								"name":        "name",
+								"description": schemaTypes.CRDTTypeDescription(client.LWW_REGISTER),
+							},
+						},
+					},
+				},
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"name": "John"
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "John",
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestSchemaCRDTTypeWithStringLWWDirective(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema, field with explicit lww crdt type given as a string",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String @crdt(type: "lww")
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"name": "John"
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "John",
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestSchemaCRDTTypeWithUnknownTypeErrors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema, field with unknown crdt type",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String @crdt(type: composite)
+					}
+				`,
+				ExpectedError: "CRDT type is not supported for fields. Field: name, CRDTType: composite, Supported: [lww]",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestSchemaCRDTTypeOnRelationFieldErrors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema, relation field with crdt type",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Books {
+						name: String
+						author: Authors @crdt(type: lww)
+					}
+
+					type Authors {
+						name: String
+						published: [Books]
+					}
+				`,
+				ExpectedError: "CRDT type is not valid for the field type. Field: author, CRDTType: lww, Kind: 16",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Books", "Authors"}, test)
+}
+
+func TestSchemaCRDTTypeIntrospectionDefinesDirective(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.IntrospectionRequest{
+				Request: `
+					query {
+						__schema {
+							directives {
+								name
+							}
+						}
+					}
+				`,
+				ContainsData: map[string]any{
+					"__schema": map[string]any{
+						"directives": []any{
+							map[string]any{
+								"name": "crdt",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{}, test)
+}
@@ -113,6 +113,41 @@ var aggregateFields = fields{
 			"name": "Int",
 		},
 	},
+	map[string]any{
+		"name": "_max",
+		"type": map[string]any{
+			"kind": "SCALAR",
+			"name": "ComparableValue",
+		},
+	},
+	map[string]any{
+		"name": "_median",
+		"type": map[string]any{
+			"kind": "SCALAR",
+			"name": "Float",
+		},
+	},
+	map[string]any{
+		"name": "_min",
+		"type": map[string]any{
+			"kind": "SCALAR",
+			"name": "ComparableValue",
+		},
+	},
+	map[string]any{
+		"name": "_percentile",
+		"type": map[string]any{
+			"kind": "SCALAR",
+			"name": "Float",
+		},
+	},
+	map[string]any{
+		"name": "_stddev",
+		"type": map[string]any{
+			"kind": "SCALAR",
+			"name": "Float",
+		},
+	},
 	map[string]any{
 		"name": "_sum",
 		"type": map[string]any{
@@ -120,6 +155,13 @@ var aggregateFields = fields{
 			"name": "Float",
 		},
 	},
+	map[string]any{
+		"name": "_variance",
+		"type": map[string]any{
+			"kind": "SCALAR",
+			"name": "Float",
+		},
+	},
 }
 
 var cidArg = Field{
@@ -129,6 +171,13 @@ var cidArg = Field{
 		"inputFields": nil,
 	},
 }
+var asOfArg = Field{
+	"name": "asOf",
+	"type": map[string]any{
+		"name":        "DateTime",
+		"inputFields": nil,
+	},
+}
 var dockeyArg = Field{
 	"name": "dockey",
 	"type": map[string]any{
@@ -167,6 +216,18 @@ var groupByArg = Field{
 	},
 }
 
+var distinctArg = Field{
+	"name": "distinct",
+	"type": map[string]any{
+		"name":        nil,
+		"inputFields": nil,
+		"ofType": map[string]any{
+			"kind": "NON_NULL",
+			"name": nil,
+		},
+	},
+}
+
 var limitArg = Field{
 	"name": "limit",
 	"type": map[string]any{
@@ -185,6 +246,24 @@ var offsetArg = Field{
 	},
 }
 
+var afterArg = Field{
+	"name": "after",
+	"type": map[string]any{
+		"name":        "String",
+		"inputFields": nil,
+		"ofType":      nil,
+	},
+}
+
+var beforeArg = Field{
+	"name": "before",
+	"type": map[string]any{
+		"name":        "String",
+		"inputFields": nil,
+		"ofType":      nil,
+	},
+}
+
 type argDef struct {
 	fieldName string
 	typeName  string
@@ -132,12 +132,16 @@ var testFilterForSimpleSchemaArgProps = map[string]any{
 var defaultUserArgsWithoutFilter = trimFields(
 	fields{
 		cidArg,
+		asOfArg,
 		dockeyArg,
 		dockeysArg,
 		showDeletedArg,
 		groupByArg,
+		distinctArg,
 		limitArg,
 		offsetArg,
+		afterArg,
+		beforeArg,
 		buildOrderArg("Users", []argDef{
 			{
 				fieldName: "name",
@@ -273,6 +277,203 @@ func TestFilterForOneToOneSchema(t *testing.T) {
 	testUtils.ExecuteTestCase(t, []string{"Book", "Author"}, test)
 }
 
+func TestFilterForOneToManySchema(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Book {
+						name: String
+						author: Author
+					}
+
+					type Author {
+						age: Int
+						published: [Book]
+					}
+				`,
+			},
+			testUtils.IntrospectionRequest{
+				Request: `
+					query {
+						__type (name: "BookListFilterArg") {
+							name
+							inputFields {
+								name
+								type {
+									name
+								}
+							}
+						}
+					}
+				`,
+				ContainsData: map[string]any{
+					"__type": map[string]any{
+						"name": "BookListFilterArg",
+						"inputFields": []any{
+							map[string]any{
+								"name": "_every",
+								"type": map[string]any{
+									"name": "BookFilterArg",
+								},
+							},
+							map[string]any{
+								"name": "_none",
+								"type": map[string]any{
+									"name": "BookFilterArg",
+								},
+							},
+							map[string]any{
+								"name": "_some",
+								"type": map[string]any{
+									"name": "BookFilterArg",
+								},
+							},
+							map[string]any{
+								"name": "name",
+								"type": map[string]any{
+									"name": "StringOperatorBlock",
+								},
+							},
+						},
+					},
+				},
+			},
+			testUtils.IntrospectionRequest{
+				Request: `
+					query {
+						__type (name: "AuthorFilterArg") {
+							inputFields {
+								name
+								type {
+									name
+								}
+							}
+						}
+					}
+				`,
+				ContainsData: map[string]any{
+					"__type": map[string]any{
+						"inputFields": []any{
+							map[string]any{
+								"name": "published",
+								"type": map[string]any{
+									"name": "BookListFilterArg",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Book", "Author"}, test)
+}
+
+func TestFilterOnAggregatesForOneToManySchema(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Book {
+						name: String
+						rating: Float
+						author: Author
+					}
+
+					type Author {
+						age: Int
+						published: [Book]
+					}
+				`,
+			},
+			testUtils.IntrospectionRequest{
+				Request: `
+					query {
+						__type (name: "AuthorFilterArg") {
+							inputFields {
+								name
+								type {
+									name
+									inputFields {
+										name
+										type {
+											name
+										}
+									}
+								}
+							}
+						}
+					}
+				`,
+				ContainsData: map[string]any{
+					"__type": map[string]any{
+						"inputFields": []any{
+							map[string]any{
+								"name": "_avg",
+								"type": map[string]any{
+									"name": "AuthorNumericAggregateFilterArg",
+									"inputFields": []any{
+										map[string]any{
+											"name": "published",
+											"type": map[string]any{
+												"name": "BookNumericFieldsFilterArg",
+											},
+										},
+									},
+								},
+							},
+							map[string]any{
+								"name": "_count",
+								"type": map[string]any{
+									"name": "AuthorCountFilterArg",
+									"inputFields": []any{
+										map[string]any{
+											"name": "published",
+											"type": map[string]any{
+												"name": "NotNullIntOperatorBlock",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			testUtils.IntrospectionRequest{
+				Request: `
+					query {
+						__type (name: "BookNumericFieldsFilterArg") {
+							inputFields {
+								name
+								type {
+									name
+								}
+							}
+						}
+					}
+				`,
+				ExpectedData: map[string]any{
+					"__type": map[string]any{
+						"inputFields": []any{
+							map[string]any{
+								"name": "rating",
+								"type": map[string]any{
+									"name": "NotNullFloatOperatorBlock",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Book", "Author"}, test)
+}
+
 var testFilterForOneToOneSchemaArgProps = map[string]any{
 	"name": struct{}{},
 	"type": map[string]any{
@@ -284,12 +485,16 @@ var testFilterForOneToOneSchemaArgProps = map[string]any{
 var defaultBookArgsWithoutFilter = trimFields(
 	fields{
 		cidArg,
+		asOfArg,
 		dockeyArg,
 		dockeysArg,
 		showDeletedArg,
 		groupByArg,
+		distinctArg,
 		limitArg,
 		offsetArg,
+		afterArg,
+		beforeArg,
 		buildOrderArg("Book", []argDef{
 			{
 				fieldName: "author",
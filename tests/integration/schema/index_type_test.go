@@ -0,0 +1,170 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package schema
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestSchemaIndexTypeWithFullTextDirective(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema, field with full-text index",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String @index(type: fulltext)
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"name": "John"
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "John",
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestSchemaIndexTypeWithStringFullTextDirective(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema, field with full-text index given as a string",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String @index(type: "fulltext")
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"name": "John"
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "John",
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestSchemaIndexTypeWithUnknownTypeErrors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema, field with unknown index type",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String @index(type: "btree")
+					}
+				`,
+				ExpectedError: "no index type found for given name. Field: name, IndexType: btree",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestSchemaIndexTypeWithoutTypeErrors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema, field with index directive without a type",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String @index
+					}
+				`,
+				ExpectedError: "the @index directive requires a type argument. Field: name",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestSchemaIndexTypeOnIntFieldErrors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema, int field with full-text index",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						age: Int @index(type: fulltext)
+					}
+				`,
+				ExpectedError: "index type is not valid for the field type. Field: age, IndexType: fulltext, Kind: 4",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
+
+func TestSchemaIndexTypeIntrospectionDefinesDirective(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.IntrospectionRequest{
+				Request: `
+					query {
+						__schema {
+							directives {
+								name
+							}
+						}
+					}
+				`,
+				ContainsData: map[string]any{
+					"__schema": map[string]any{
+						"directives": []any{
+							map[string]any{
+								"name": "index",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{}, test)
+}
@@ -141,6 +141,129 @@ func TestInputTypeOfOrderFieldWhereSchemaHasRelationType(t *testing.T) {
 	testUtils.ExecuteTestCase(t, []string{"book", "author"}, test)
 }
 
+func TestInputTypeOfOrderFieldOnAggregatesWhereSchemaHasOneToManyRelation(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Book {
+						name: String
+						rating: Float
+						author: Author
+					}
+
+					type Author {
+						age: Int
+						published: [Book]
+					}
+				`,
+			},
+			testUtils.IntrospectionRequest{
+				Request: `
+					query {
+						__type (name: "AuthorOrderArg") {
+							inputFields {
+								name
+								type {
+									name
+									inputFields {
+										name
+										type {
+											name
+										}
+									}
+								}
+							}
+						}
+					}
+				`,
+				ContainsData: map[string]any{
+					"__type": map[string]any{
+						"inputFields": []any{
+							map[string]any{
+								"name": "_avg",
+								"type": map[string]any{
+									"name": "AuthorNumericAggregateOrderArg",
+									"inputFields": []any{
+										map[string]any{
+											"name": "published",
+											"type": map[string]any{
+												"name": "BookNumericFieldsOrderArg",
+											},
+										},
+									},
+								},
+							},
+							map[string]any{
+								"name": "_count",
+								"type": map[string]any{
+									"name": "AuthorCountOrderArg",
+									"inputFields": []any{
+										map[string]any{
+											"name": "published",
+											"type": map[string]any{
+												"name": "Ordering",
+											},
+										},
+									},
+								},
+							},
+							map[string]any{
+								"name": "_max",
+								"type": map[string]any{
+									"name": "AuthorMinMaxAggregateOrderArg",
+									"inputFields": []any{
+										map[string]any{
+											"name": "published",
+											"type": map[string]any{
+												"name": "BookComparableFieldsOrderArg",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			testUtils.IntrospectionRequest{
+				Request: `
+					query {
+						__type (name: "BookComparableFieldsOrderArg") {
+							inputFields {
+								name
+								type {
+									name
+								}
+							}
+						}
+					}
+				`,
+				ExpectedData: map[string]any{
+					"__type": map[string]any{
+						"inputFields": []any{
+							map[string]any{
+								"name": "name",
+								"type": map[string]any{
+									"name": "Ordering",
+								},
+							},
+							map[string]any{
+								"name": "rating",
+								"type": map[string]any{
+									"name": "Ordering",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, []string{"Book", "Author"}, test)
+}
+
 var testInputTypeOfOrderFieldWhereSchemaHasRelationTypeArgProps = map[string]any{
 	"name": struct{}{},
 	"type": map[string]any{
@@ -180,6 +303,7 @@ var defaultGroupArgsWithoutOrder = trimFields(
 			},
 		}),
 		groupByArg,
+		distinctArg,
 		limitArg,
 		offsetArg,
 	},
@@ -0,0 +1,42 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package replace
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestSchemaUpdatesReplaceFieldCRDTTypeErrors(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test schema update, replace field crdt type",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String @crdt(type: lww)
+					}
+				`,
+			},
+			testUtils.SchemaPatch{
+				Patch: `
+					[
+						{ "op": "replace", "path": "/Users/Schema/Fields/1/Typ", "value": 3 }
+					]
+				`,
+				ExpectedError: "the CRDT type of an existing field cannot be changed. Name: name, " +
+					"ExistingCRDTType: 1, ProposedCRDTType: 3",
+			},
+		},
+	}
+	testUtils.ExecuteTestCase(t, []string{"Users"}, test)
+}
@@ -11,8 +11,11 @@
 package tests
 
 import (
+	"time"
+
 	"github.com/sourcenetwork/immutable"
 
+	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/config"
 )
 
@@ -87,6 +90,10 @@ type CreateDoc struct {
 	// The document to create, in JSON string format.
 	Doc string
 
+	// Identity may hold the identity of the user making this create, recorded as the
+	// author of the commits it creates.
+	Identity immutable.Option[string]
+
 	// Any error expected from the action. Optional.
 	//
 	// String can be a partial, and the test will pass if an error is returned that
@@ -110,6 +117,10 @@ type DeleteDoc struct {
 	// database.
 	DocID int
 
+	// Identity may hold the identity of the user making this delete, recorded as the
+	// author of the commits it creates.
+	Identity immutable.Option[string]
+
 	// Any error expected from the action. Optional.
 	//
 	// String can be a partial, and the test will pass if an error is returned that
@@ -120,6 +131,29 @@ type DeleteDoc struct {
 	DontSync bool
 }
 
+// PurgeDoc will attempt to permanently erase the given document from the given
+// collection using the collection api.
+type PurgeDoc struct {
+	// NodeID may hold the ID (index) of a node to apply this purge to.
+	//
+	// If a value is not provided the document will be purged in all nodes.
+	NodeID immutable.Option[int]
+
+	// The collection in which this document should be purged.
+	CollectionID int
+
+	// The index-identifier of the document within the collection.  This is based on
+	// the order in which it was created, not the ordering of the document within the
+	// database.
+	DocID int
+
+	// Any error expected from the action. Optional.
+	//
+	// String can be a partial, and the test will pass if an error is returned that
+	// contains this string.
+	ExpectedError string
+}
+
 // UpdateDoc will attempt to update the given document in the given collection
 // using the collection api.
 type UpdateDoc struct {
@@ -140,6 +174,10 @@ type UpdateDoc struct {
 	// provided.
 	Doc string
 
+	// Identity may hold the identity of the user making this update, recorded as the
+	// author of the commits it creates.
+	Identity immutable.Option[string]
+
 	// Any error expected from the action. Optional.
 	//
 	// String can be a partial, and the test will pass if an error is returned that
@@ -150,6 +188,43 @@ type UpdateDoc struct {
 	DontSync bool
 }
 
+// CompactDocs will attempt to compact the history of documents in the given collection
+// using the collection api.
+type CompactDocs struct {
+	// NodeID may hold the ID (index) of a node to compact on.
+	//
+	// If a value is not provided the compaction will be applied to all nodes.
+	NodeID immutable.Option[int]
+
+	// The collection in which the documents exist.
+	CollectionID int
+
+	// The index-identifiers of the documents to compact. If empty, all documents
+	// in the collection will be compacted.
+	DocIDs []int
+
+	// The highest height to fold into the snapshot.
+	Height uint64
+
+	// The number of latest commits to keep, used if Height is not set.
+	Depth uint64
+
+	// Any error expected from the action. Optional.
+	//
+	// String can be a partial, and the test will pass if an error is returned that
+	// contains this string.
+	ExpectedError string
+}
+
+// SetCommitTime sets the time recorded in the commits made on all nodes from this point
+// onwards.
+//
+// No time is recorded in commits by default so that their Cids remain deterministic.
+type SetCommitTime struct {
+	// The time to record in commits.  A zero time stops the recording of commit times.
+	Time time.Time
+}
+
 // Request represents a standard Defra (GQL) request.
 type Request struct {
 	// NodeID may hold the ID (index) of a node to execute this request on.
@@ -161,9 +236,18 @@ type Request struct {
 	// The request to execute.
 	Request string
 
+	// Identity may hold the identity of the user making this request, recorded as the
+	// author of the commits it creates.
+	Identity immutable.Option[string]
+
 	// The expected (data) results of the issued request.
 	Results []map[string]any
 
+	// The page info expected to be returned alongside the results of a paged request.
+	//
+	// If a value is not provided the page info will not be asserted.
+	PageInfo immutable.Option[client.PageInfo]
+
 	// Any error expected from the action. Optional.
 	//
 	// String can be a partial, and the test will pass if an error is returned that
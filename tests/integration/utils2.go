@@ -164,7 +164,7 @@ func NewBadgerMemoryDB(ctx context.Context, dbopts ...db.Option) (client.DB, err
 		return nil, err
 	}
 
-	dbopts = append(dbopts, db.WithUpdateEvents())
+	dbopts = append(dbopts, db.WithUpdateEvents(), db.WithConflictEvents(), db.WithCommitClock(commitClock.Now))
 
 	db, err := db.NewDB(ctx, rootstore, dbopts...)
 	if err != nil {
@@ -174,9 +174,12 @@ func NewBadgerMemoryDB(ctx context.Context, dbopts ...db.Option) (client.DB, err
 	return db, nil
 }
 
-func NewInMemoryDB(ctx context.Context) (client.DB, error) {
+func NewInMemoryDB(ctx context.Context, dbopts ...db.Option) (client.DB, error) {
 	rootstore := memory.NewDatastore(ctx)
-	db, err := db.NewDB(ctx, rootstore, db.WithUpdateEvents())
+
+	dbopts = append(dbopts, db.WithUpdateEvents(), db.WithConflictEvents(), db.WithCommitClock(commitClock.Now))
+
+	db, err := db.NewDB(ctx, rootstore, dbopts...)
 	if err != nil {
 		return nil, err
 	}
@@ -184,7 +187,7 @@ func NewInMemoryDB(ctx context.Context) (client.DB, error) {
 	return db, nil
 }
 
-func NewBadgerFileDB(ctx context.Context, t testing.TB) (client.DB, error) {
+func NewBadgerFileDB(ctx context.Context, t testing.TB, dbopts ...db.Option) (client.DB, error) {
 	var dbPath string
 	if databaseDir != "" {
 		dbPath = databaseDir
@@ -194,17 +197,19 @@ func NewBadgerFileDB(ctx context.Context, t testing.TB) (client.DB, error) {
 		dbPath = t.TempDir()
 	}
 
-	return newBadgerFileDB(ctx, t, dbPath)
+	return newBadgerFileDB(ctx, t, dbPath, dbopts...)
 }
 
-func newBadgerFileDB(ctx context.Context, t testing.TB, path string) (client.DB, error) {
+func newBadgerFileDB(ctx context.Context, t testing.TB, path string, dbopts ...db.Option) (client.DB, error) {
 	opts := badgerds.Options{Options: badger.DefaultOptions(path)}
 	rootstore, err := badgerds.NewDatastore(path, &opts)
 	if err != nil {
 		return nil, err
 	}
 
-	db, err := db.NewDB(ctx, rootstore, db.WithUpdateEvents())
+	dbopts = append(dbopts, db.WithUpdateEvents(), db.WithConflictEvents(), db.WithCommitClock(commitClock.Now))
+
+	db, err := db.NewDB(ctx, rootstore, dbopts...)
 	if err != nil {
 		return nil, err
 	}
@@ -230,24 +235,24 @@ func GetDatabaseTypes() []DatabaseType {
 	return databases
 }
 
-func GetDatabase(ctx context.Context, t *testing.T, dbt DatabaseType) (client.DB, error) {
+func GetDatabase(ctx context.Context, t *testing.T, dbt DatabaseType, dbopts ...db.Option) (client.DB, error) {
 	switch dbt {
 	case badgerIMType:
-		db, err := NewBadgerMemoryDB(ctx, db.WithUpdateEvents())
+		db, err := NewBadgerMemoryDB(ctx, append(dbopts, db.WithUpdateEvents())...)
 		if err != nil {
 			return nil, err
 		}
 		return db, nil
 
 	case badgerFileType:
-		db, err := NewBadgerFileDB(ctx, t)
+		db, err := NewBadgerFileDB(ctx, t, dbopts...)
 		if err != nil {
 			return nil, err
 		}
 		return db, nil
 
 	case defraIMType:
-		db, err := NewInMemoryDB(ctx)
+		db, err := NewInMemoryDB(ctx, dbopts...)
 		if err != nil {
 			return nil, err
 		}
@@ -293,6 +298,8 @@ func executeTestCase(
 	log.Info(ctx, testCase.Description, logging.NewKV("Database", dbt))
 
 	flattenActions(&testCase)
+	// Make sure that the commit time set by a previous test case is not carried over.
+	commitClock.Set(time.Time{})
 	startActionIndex, endActionIndex := getActionRange(testCase)
 	txns := []datastore.Txn{}
 	allActionsDone := make(chan struct{})
@@ -361,9 +368,18 @@ func executeTestCase(
 		case DeleteDoc:
 			deleteDoc(ctx, t, testCase, nodes, collections, documents, action)
 
+		case PurgeDoc:
+			purgeDoc(ctx, t, testCase, nodes, collections, documents, action)
+
 		case UpdateDoc:
 			updateDoc(ctx, t, testCase, nodes, collections, documents, action)
 
+		case CompactDocs:
+			compactDocs(ctx, t, testCase, nodes, collections, documents, action)
+
+		case SetCommitTime:
+			commitClock.Set(action.Time)
+
 		case TransactionRequest2:
 			txns = executeTransactionRequest(ctx, t, db, txns, testCase, action)
 
@@ -620,7 +636,11 @@ func configureNode(
 	// an in memory store.
 	cfg.Datastore.Badger.Path = t.TempDir()
 
-	db, err := GetDatabase(ctx, t, dbt) //disable change dector, or allow it?
+	// blocks are signed with the key of the libp2p host of the node, as done by the cli
+	hostKey, err := node.GetHostKey(cfg.Datastore.Badger.Path)
+	require.NoError(t, err)
+
+	db, err := GetDatabase(ctx, t, dbt, db.WithSigningKey(hostKey)) //disable change dector, or allow it?
 	require.NoError(t, err)
 
 	var n *node.Node
@@ -744,6 +764,7 @@ func createDoc(
 ) [][]*client.Document {
 	// All the docs should be identical, and we only need 1 copy so taking the last
 	// is okay.
+	ctx = withIdentity(ctx, action.Identity)
 	var doc *client.Document
 	actionNodes := getNodes(action.NodeID, nodes)
 	for nodeID, collections := range getNodeCollections(action.NodeID, nodeCollections) {
@@ -785,6 +806,7 @@ func deleteDoc(
 	documents [][]*client.Document,
 	action DeleteDoc,
 ) {
+	ctx = withIdentity(ctx, action.Identity)
 	doc := documents[action.CollectionID][action.DocID]
 
 	var expectedErrorRaised bool
@@ -804,6 +826,43 @@ func deleteDoc(
 	assertExpectedErrorRaised(t, testCase.Description, action.ExpectedError, expectedErrorRaised)
 }
 
+// purgeDoc permanently erases a document using the collection api.
+func purgeDoc(
+	ctx context.Context,
+	t *testing.T,
+	testCase TestCase,
+	nodes []*node.Node,
+	nodeCollections [][]client.Collection,
+	documents [][]*client.Document,
+	action PurgeDoc,
+) {
+	doc := documents[action.CollectionID][action.DocID]
+
+	var expectedErrorRaised bool
+	actionNodes := getNodes(action.NodeID, nodes)
+	for nodeID, collections := range getNodeCollections(action.NodeID, nodeCollections) {
+		err := withRetry(
+			actionNodes,
+			nodeID,
+			func() error {
+				_, err := collections[action.CollectionID].Purge(ctx, doc.Key())
+				return err
+			},
+		)
+		expectedErrorRaised = AssertError(t, testCase.Description, err, action.ExpectedError)
+	}
+
+	assertExpectedErrorRaised(t, testCase.Description, action.ExpectedError, expectedErrorRaised)
+}
+
+// withIdentity returns a copy of the given context carrying the given identity, if any.
+func withIdentity(ctx context.Context, identity immutable.Option[string]) context.Context {
+	if !identity.HasValue() {
+		return ctx
+	}
+	return client.WithIdentity(ctx, identity.Value())
+}
+
 // updateDoc updates a document using the collection api.
 func updateDoc(
 	ctx context.Context,
@@ -814,6 +873,7 @@ func updateDoc(
 	documents [][]*client.Document,
 	action UpdateDoc,
 ) {
+	ctx = withIdentity(ctx, action.Identity)
 	doc := documents[action.CollectionID][action.DocID]
 
 	err := doc.SetWithJSON([]byte(action.Doc))
@@ -835,6 +895,41 @@ func updateDoc(
 	assertExpectedErrorRaised(t, testCase.Description, action.ExpectedError, expectedErrorRaised)
 }
 
+// compactDocs compacts the history of documents using the collection api.
+func compactDocs(
+	ctx context.Context,
+	t *testing.T,
+	testCase TestCase,
+	nodes []*node.Node,
+	nodeCollections [][]client.Collection,
+	documents [][]*client.Document,
+	action CompactDocs,
+) {
+	opts := client.CompactOptions{
+		Height: action.Height,
+		Depth:  action.Depth,
+	}
+	for _, docID := range action.DocIDs {
+		opts.DocKeys = append(opts.DocKeys, documents[action.CollectionID][docID].Key())
+	}
+
+	var expectedErrorRaised bool
+	actionNodes := getNodes(action.NodeID, nodes)
+	for nodeID, collections := range getNodeCollections(action.NodeID, nodeCollections) {
+		err := withRetry(
+			actionNodes,
+			nodeID,
+			func() error {
+				_, err := collections[action.CollectionID].Compact(ctx, opts)
+				return err
+			},
+		)
+		expectedErrorRaised = AssertError(t, testCase.Description, err, action.ExpectedError)
+	}
+
+	assertExpectedErrorRaised(t, testCase.Description, action.ExpectedError, expectedErrorRaised)
+}
+
 // withRetry attempts to perform the given action, retrying up to a DB-defined
 // maximum attempt count if a transaction conflict error is returned.
 //
@@ -941,6 +1036,7 @@ func executeRequest(
 	testCase TestCase,
 	action Request,
 ) {
+	ctx = withIdentity(ctx, action.Identity)
 	var expectedErrorRaised bool
 	for nodeID, node := range getNodes(action.NodeID, nodes) {
 		result := node.DB.ExecRequest(ctx, action.Request)
@@ -956,6 +1052,15 @@ func executeRequest(
 			nodeID,
 			anyOfByFieldKey,
 		)
+
+		if action.PageInfo.HasValue() && !expectedErrorRaised {
+			assert.Equal(
+				t,
+				action.PageInfo.Value(),
+				result.GQL.Extensions[client.PageInfoExtension],
+				testCase.Description,
+			)
+		}
 	}
 
 	assertExpectedErrorRaised(t, testCase.Description, action.ExpectedError, expectedErrorRaised)